@@ -0,0 +1,36 @@
+package acl
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/acl/token"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "acl",
+		Short: "Interact with the Sherpa ACL system",
+		Run: func(cmd *cobra.Command, args []string) {
+			runACL(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	if err := registerCommands(cmd); err != nil {
+		fmt.Println("Error registering commands:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	return nil
+}
+
+func runACL(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(cmd *cobra.Command) error {
+	return token.RegisterCommand(cmd)
+}
@@ -0,0 +1,58 @@
+package token
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/acl/token/create"
+	"github.com/jrasell/sherpa/cmd/acl/token/delete"
+	"github.com/jrasell/sherpa/cmd/acl/token/info"
+	"github.com/jrasell/sherpa/cmd/acl/token/list"
+	"github.com/jrasell/sherpa/cmd/acl/token/self"
+	aclCfg "github.com/jrasell/sherpa/pkg/config/acl"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Interact with ACL tokens",
+		Run: func(cmd *cobra.Command, args []string) {
+			runToken(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+	aclCfg.RegisterConfig(cmd)
+
+	if err := registerCommands(cmd); err != nil {
+		fmt.Println("Error registering commands:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	return nil
+}
+
+func runToken(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(cmd *cobra.Command) error {
+	if err := create.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
+	if err := delete.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
+	if err := info.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
+	if err := list.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
+	return self.RegisterCommand(cmd)
+}
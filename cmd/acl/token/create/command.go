@@ -0,0 +1,89 @@
+package create
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jrasell/sherpa/cmd/acl/token/info"
+	"github.com/jrasell/sherpa/pkg/api"
+	aclCfg "github.com/jrasell/sherpa/pkg/config/acl"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new ACL token",
+		Run: func(cmd *cobra.Command, args []string) {
+			runCreate(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runCreate(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) > 0:
+		fmt.Println("Too many arguments, expected 0 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	aclConfig := aclCfg.GetConfig()
+
+	rules, err := parseRules(aclConfig.Rules)
+	if err != nil {
+		fmt.Println("Error parsing ACL token rules:", err)
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	req := &api.ACLTokenRequest{
+		Name:       aclConfig.Name,
+		Management: aclConfig.Management,
+		Rules:      rules,
+	}
+
+	t, err := client.ACLTokens().Create(req)
+	if err != nil {
+		fmt.Println("Error creating ACL token:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(info.FormatToken(t))
+}
+
+// parseRules parses rules in the form <job-prefix>=<capability>. Rules sharing a job prefix are
+// merged, retaining the order in which the prefixes were first specified.
+func parseRules(input []string) ([]*api.ACLRule, error) {
+	var out []*api.ACLRule
+	prefixes := make(map[string]*api.ACLRule)
+
+	for _, r := range input {
+		split := strings.SplitN(r, "=", 2)
+		if len(split) != 2 || split[1] == "" {
+			return nil, fmt.Errorf("invalid rule %q, expected <job-prefix>=<capability>", r)
+		}
+
+		rule, ok := prefixes[split[0]]
+		if !ok {
+			rule = &api.ACLRule{JobPrefix: split[0]}
+			prefixes[split[0]] = rule
+			out = append(out, rule)
+		}
+		rule.Capabilities = append(rule.Capabilities, split[1])
+	}
+	return out, nil
+}
@@ -0,0 +1,46 @@
+package create
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRules(t *testing.T) {
+	testCases := []struct {
+		input          []string
+		expectedOutput []*api.ACLRule
+		expectError    bool
+	}{
+		{
+			input:          nil,
+			expectedOutput: nil,
+		},
+		{
+			input: []string{"=read", "platform/=scale", "platform/=policy-write"},
+			expectedOutput: []*api.ACLRule{
+				{JobPrefix: "", Capabilities: []string{"read"}},
+				{JobPrefix: "platform/", Capabilities: []string{"scale", "policy-write"}},
+			},
+		},
+		{
+			input:       []string{"platform/"},
+			expectError: true,
+		},
+		{
+			input:       []string{"platform/="},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		actual, err := parseRules(tc.input)
+		if tc.expectError {
+			assert.NotNil(t, err)
+			continue
+		}
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectedOutput, actual)
+	}
+}
@@ -0,0 +1,51 @@
+package delete
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Deletes an ACL token from Sherpa",
+		Run: func(cmd *cobra.Command, args []string) {
+			runDelete(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runDelete(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) < 1:
+		fmt.Println("Not enough arguments, expected 1 arg got", len(args))
+		os.Exit(sysexits.Usage)
+	case len(args) > 1:
+		fmt.Println("Too many arguments, expected 1 arg got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	if err := client.ACLTokens().Delete(args[0]); err != nil {
+		fmt.Println("Error deleting ACL token:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println("Successfully deleted ACL token")
+}
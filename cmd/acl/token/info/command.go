@@ -0,0 +1,74 @@
+package info
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jrasell/sherpa/cmd/helper"
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Display information about an ACL token",
+		Run: func(cmd *cobra.Command, args []string) {
+			runInfo(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runInfo(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) < 1:
+		fmt.Println("Not enough arguments, expected 1 arg got", len(args))
+		os.Exit(sysexits.Usage)
+	case len(args) > 1:
+		fmt.Println("Too many arguments, expected 1 arg got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	t, err := client.ACLTokens().Info(args[0])
+	if err != nil {
+		fmt.Println("Error querying ACL token:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(FormatToken(t))
+}
+
+// FormatToken formats the ACL token for output by the CLI.
+func FormatToken(t *api.ACLToken) string {
+	out := []string{
+		fmt.Sprintf("Accessor ID|%s", t.AccessorID),
+		fmt.Sprintf("Secret ID|%s", t.SecretID),
+		fmt.Sprintf("Name|%s", t.Name),
+		fmt.Sprintf("Management|%v", t.Management),
+	}
+
+	if t.CreateTime > 0 {
+		out = append(out, fmt.Sprintf("Create Time|%v", time.Unix(0, t.CreateTime).UTC()))
+	}
+
+	for _, rule := range t.Rules {
+		out = append(out, fmt.Sprintf("Rule (%s*)|%s", rule.JobPrefix, strings.Join(rule.Capabilities, ",")))
+	}
+	return helper.FormatKV(out)
+}
@@ -0,0 +1,63 @@
+package list
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/helper"
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputHeader = "Accessor ID|Name|Management|Rules"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists all ACL tokens",
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runList(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) > 0:
+		fmt.Println("Too many arguments, expected 0 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	tokens, err := client.ACLTokens().List()
+	if err != nil {
+		fmt.Println("Error querying ACL token list:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	if len(tokens) == 0 {
+		os.Exit(sysexits.OK)
+	}
+
+	out := []string{outputHeader}
+	for _, t := range tokens {
+		out = append(out, fmt.Sprintf("%s|%s|%v|%v", t.AccessorID, t.Name, t.Management, len(t.Rules)))
+	}
+
+	fmt.Println(helper.FormatList(out))
+}
@@ -0,0 +1,50 @@
+package self
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/acl/token/info"
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "self",
+		Short: "Display information about the ACL token in use",
+		Run: func(cmd *cobra.Command, args []string) {
+			runSelf(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runSelf(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) > 0:
+		fmt.Println("Too many arguments, expected 0 args got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	t, err := client.ACLTokens().Self()
+	if err != nil {
+		fmt.Println("Error querying ACL token:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(info.FormatToken(t))
+}
@@ -0,0 +1,36 @@
+package autoscale
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/autoscale/explain"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "autoscale",
+		Short: "Inspect the decisions of the Sherpa internal autoscaler",
+		Run: func(cmd *cobra.Command, args []string) {
+			runAutoScale(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	if err := registerCommands(cmd); err != nil {
+		fmt.Println("Error registering commands:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	return nil
+}
+
+func runAutoScale(cmd *cobra.Command, _ []string) {
+	_ = cmd.Usage()
+}
+
+func registerCommands(rootCmd *cobra.Command) error {
+	return explain.RegisterCommand(rootCmd)
+}
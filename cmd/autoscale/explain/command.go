@@ -0,0 +1,153 @@
+package explain
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/jrasell/sherpa/cmd/helper"
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const checksOutputHeader = "Check|Type|Value|Threshold|Breaches|Result|Error"
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the autoscaler evaluation of a Nomad job without triggering scaling",
+		Run: func(cmd *cobra.Command, args []string) {
+			runExplain(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+
+	return nil
+}
+
+func runExplain(_ *cobra.Command, args []string) {
+	switch {
+	case len(args) < 1:
+		fmt.Println("Not enough arguments, expected 1 got", len(args))
+		os.Exit(sysexits.Usage)
+	case len(args) > 1:
+		fmt.Println("Too many arguments, expected 1 got", len(args))
+		os.Exit(sysexits.Usage)
+	}
+
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	exp, err := client.AutoScale().Explain(args[0])
+	if err != nil {
+		fmt.Println("Error explaining autoscaler evaluation:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	fmt.Println(helper.FormatKV([]string{
+		fmt.Sprintf("Job|%s", exp.Job),
+		fmt.Sprintf("Time|%v", helper.UnixNanoToHumanUTC(exp.Time)),
+	}))
+
+	groups := make([]string, 0, len(exp.Groups))
+	for group := range exp.Groups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		fmt.Println("")
+		fmt.Println(formatGroup(group, exp.Groups[group]))
+	}
+}
+
+func formatGroup(name string, group *api.GroupExplanation) string {
+	kv := []string{
+		fmt.Sprintf("Group|%s", name),
+		fmt.Sprintf("Evaluated|%v", group.Evaluated),
+	}
+	if group.SkipReason != "" {
+		kv = append(kv, fmt.Sprintf("Skip Reason|%s", group.SkipReason))
+	}
+
+	actions := make([]string, 0, len(group.Conditions))
+	for action := range group.Conditions {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		kv = append(kv, fmt.Sprintf("Condition %s Met|%v", action, group.Conditions[action]))
+	}
+
+	if group.ActionCooldown != "" {
+		kv = append(kv, fmt.Sprintf("Action Cooldown|%s", group.ActionCooldown))
+	}
+
+	if group.Decision != nil {
+		kv = append(kv,
+			fmt.Sprintf("Decision|%s", group.Decision.Direction),
+			fmt.Sprintf("Count|%v", group.Decision.Count),
+			fmt.Sprintf("Absolute|%v", group.Decision.Absolute),
+			fmt.Sprintf("Percentage|%v", group.Decision.Percentage),
+			fmt.Sprintf("Dry Run|%v", group.Decision.DryRun),
+			fmt.Sprintf("Source|%s", group.Decision.Source),
+		)
+	} else if group.Evaluated {
+		kv = append(kv, "Decision|none")
+	}
+
+	out := helper.FormatKV(kv)
+
+	if len(group.Checks) == 0 {
+		return out
+	}
+
+	checks := make([]string, 0, len(group.Checks))
+	for check := range group.Checks {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+
+	list := []string{checksOutputHeader}
+	for _, name := range checks {
+		c := group.Checks[name]
+		list = append(list, fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+			name, c.Type, formatFloat(c.Value), formatThreshold(c), formatBreaches(c.Breaches), c.Result, c.Error))
+	}
+	return out + "\n\n" + helper.FormatList(list)
+}
+
+// formatThreshold returns the check threshold, prefixed with the comparison operator if the check
+// is a threshold comparison.
+func formatThreshold(c *api.CheckExplanation) string {
+	t := formatFloat(c.Threshold)
+	if c.ComparisonOperator != "" && c.Threshold != nil {
+		return c.ComparisonOperator + " " + t
+	}
+	return t
+}
+
+// formatBreaches returns the number of recent evaluations the check breached its threshold within,
+// alongside the number required, if the check is configured with breach periods.
+func formatBreaches(b *api.BreachExplanation) string {
+	if b == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d (requires %d)", b.Breaching, b.EvaluationPeriods, b.Datapoints)
+}
+
+func formatFloat(f *float64) string {
+	if f == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", *f)
+}
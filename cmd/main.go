@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jrasell/sherpa/cmd/acl"
+	"github.com/jrasell/sherpa/cmd/autoscale"
 	"github.com/jrasell/sherpa/cmd/policy"
 	"github.com/jrasell/sherpa/cmd/scale"
 	"github.com/jrasell/sherpa/cmd/server"
@@ -54,5 +56,13 @@ func registerCommands(rootCmd *cobra.Command) error {
 		return err
 	}
 
+	if err := autoscale.RegisterCommand(rootCmd); err != nil {
+		return err
+	}
+
+	if err := acl.RegisterCommand(rootCmd); err != nil {
+		return err
+	}
+
 	return policy.RegisterCommand(rootCmd)
 }
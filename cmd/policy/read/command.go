@@ -84,6 +84,8 @@ func formatGroupOutput(group string, policy *api.JobGroupPolicy) {
 		fmt.Sprintf("MinCount|%v", policy.MinCount),
 		fmt.Sprintf("MaxCount|%v", policy.MaxCount),
 		fmt.Sprintf("Cooldown|%v", policy.Cooldown),
+		fmt.Sprintf("ScaleOutCooldown|%v", policy.ScaleOutCooldown),
+		fmt.Sprintf("ScaleInCooldown|%v", policy.ScaleInCooldown),
 		fmt.Sprintf("ScaleInCount|%v", policy.ScaleInCount),
 		fmt.Sprintf("ScaleOutCount|%v", policy.ScaleOutCount),
 	}
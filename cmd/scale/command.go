@@ -6,6 +6,7 @@ import (
 
 	"github.com/jrasell/sherpa/cmd/scale/in"
 	"github.com/jrasell/sherpa/cmd/scale/out"
+	"github.com/jrasell/sherpa/cmd/scale/set"
 	"github.com/jrasell/sherpa/cmd/scale/status"
 	scaleCfg "github.com/jrasell/sherpa/pkg/config/scale"
 	"github.com/sean-/sysexits"
@@ -40,6 +41,10 @@ func registerCommands(cmd *cobra.Command) error {
 		return err
 	}
 
+	if err := set.RegisterCommand(cmd); err != nil {
+		return err
+	}
+
 	if err := status.RegisterCommand(cmd); err != nil {
 		return err
 	}
@@ -2,9 +2,11 @@ package status
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/jrasell/sherpa/cmd/helper"
@@ -16,8 +18,16 @@ import (
 )
 
 const (
-	listOutputHeader = "ID|Job:Group|Status|Time"
-	infoOutputHeader = "Job:Group|ChangeCount|Direction|Meta"
+	// followReconnectAttempts and followReconnectInterval control reconnecting to the event stream
+	// once it ends, which happens when the Sherpa server loses the cluster leadership.
+	followReconnectAttempts = 10
+	followReconnectInterval = time.Second
+)
+
+const (
+	listOutputHeader    = "ID|Job:Group|Status|Time"
+	infoOutputHeader    = "Job:Group|ChangeCount|Direction|Outcome|Meta"
+	reasonsOutputHeader = "Job:Group|Reason"
 )
 
 func RegisterCommand(rootCmd *cobra.Command) error {
@@ -50,16 +60,24 @@ func runStatus(_ *cobra.Command, args []string) {
 		os.Exit(sysexits.Software)
 	}
 
+	if latestConfig.Follow {
+		if len(args) > 0 {
+			fmt.Println("Too many arguments, expected 0 when following, got", len(args))
+			os.Exit(sysexits.Usage)
+		}
+		os.Exit(runFollow(client, latestConfig))
+	}
+
 	switch len(args) {
 	case 0:
-		os.Exit(runList(client, latestConfig.Latest))
+		os.Exit(runList(client, latestConfig.Latest, latestConfig.Status))
 	case 1:
 		os.Exit(runInfo(client, args[0]))
 	}
 }
 
-func runList(c *api.Client, latest bool) int {
-	resp, err := c.Scale().List(latest)
+func runList(c *api.Client, latest bool, status string) int {
+	resp, err := c.Scale().ListWithStatus(latest, status)
 	if err != nil {
 		fmt.Println("Error getting scaling list:", err)
 		os.Exit(sysexits.Software)
@@ -112,18 +130,32 @@ func runInfo(c *api.Client, id string) int {
 	var header []string
 
 	events := []string{infoOutputHeader}
+	reasons := []string{reasonsOutputHeader}
+
 	for jobGroup, event := range resp {
-		events = append(events, fmt.Sprintf("%s|%v|%v|%s",
-			jobGroup, event.Details.Count, event.Details.Direction, strings.Join(metaToStrings(event.Meta), ",")))
+		events = append(events, fmt.Sprintf("%s|%v|%v|%s|%s",
+			jobGroup, event.Details.Count, event.Details.Direction, formatOutcome(event.Outcome),
+			strings.Join(metaToStrings(event.Meta), ",")))
+
+		if event.Outcome != nil {
+			for _, reason := range event.Outcome.Reasons {
+				reasons = append(reasons, fmt.Sprintf("%s|%s", jobGroup, reason))
+			}
+		}
 
 		if len(header) == 0 {
 			header = []string{
 				fmt.Sprintf("ID|%s", id),
 				fmt.Sprintf("EvalID|%v", event.EvalID),
+				fmt.Sprintf("NomadEventIndex|%v", event.NomadEventIndex),
 				fmt.Sprintf("Status|%s", event.Status),
+				fmt.Sprintf("Failures|%v", event.Failures),
 				fmt.Sprintf("Source|%v", event.Source),
 				fmt.Sprintf("Time|%v", helper.UnixNanoToHumanUTC(event.Time)),
 			}
+			if event.Identity != "" {
+				header = append(header, fmt.Sprintf("Identity|%s", event.Identity))
+			}
 		}
 	}
 
@@ -131,9 +163,23 @@ func runInfo(c *api.Client, id string) int {
 	fmt.Println("")
 	fmt.Println(helper.FormatList(events))
 
+	if len(reasons) > 1 {
+		fmt.Println("")
+		fmt.Println(helper.FormatList(reasons))
+	}
+
 	return sysexits.OK
 }
 
+// formatOutcome returns the outcome status of the scaling event, or a placeholder if the outcome
+// has not yet been determined.
+func formatOutcome(outcome *api.EventOutcome) string {
+	if outcome == nil {
+		return "-"
+	}
+	return outcome.Status
+}
+
 func metaToStrings(meta map[string]string) []string {
 	out := []string{}
 	for k, v := range meta {
@@ -141,3 +187,92 @@ func metaToStrings(meta map[string]string) []string {
 	}
 	return out
 }
+
+// runFollow prints events from the event stream as they are received, reconnecting whenever the
+// stream ends so that leadership changes are followed.
+func runFollow(c *api.Client, cfg *scale.StatusConfig) int {
+	filter := &api.StreamFilter{Topics: cfg.Topics, Job: cfg.Job, Group: cfg.Group, Source: cfg.Source}
+
+	stream, err := c.Scale().Stream(filter)
+	if err != nil {
+		fmt.Println("Error streaming scaling events:", err)
+		return sysexits.Software
+	}
+
+	for {
+		if err := printStream(stream); err != nil {
+			fmt.Println("Error reading scaling event stream:", err)
+		}
+
+		if stream, err = reconnectStream(c, filter); err != nil {
+			fmt.Println("Error streaming scaling events:", err)
+			return sysexits.Software
+		}
+	}
+}
+
+func reconnectStream(c *api.Client, filter *api.StreamFilter) (*api.EventStream, error) {
+	var err error
+
+	for i := 0; i < followReconnectAttempts; i++ {
+		time.Sleep(followReconnectInterval)
+
+		var stream *api.EventStream
+		if stream, err = c.Scale().Stream(filter); err == nil {
+			return stream, nil
+		}
+	}
+	return nil, err
+}
+
+// printStream prints each event received until the stream ends, returning any error other than
+// the end of the stream.
+func printStream(stream *api.EventStream) error {
+	defer stream.Close() // nolint:errcheck
+
+	for {
+		event, err := stream.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(formatStreamEvent(event))
+	}
+}
+
+func formatStreamEvent(e *api.StreamEvent) string {
+	prefix := fmt.Sprintf("%v %s", helper.UnixNanoToHumanUTC(e.Time), e.Topic)
+
+	switch e.Topic {
+	case api.StreamTopicScaling:
+		event, err := e.ScalingEvent()
+		if err != nil {
+			return fmt.Sprintf("%s %s:%s failed to decode event: %v", prefix, e.Job, e.Group, err)
+		}
+		return fmt.Sprintf("%s %s:%s %s %s %v %s (%s)", prefix, e.Job, e.Group, event.ID,
+			event.Details.Direction, event.Details.Count, event.Status, event.Source)
+
+	case api.StreamTopicPolicy:
+		change, err := e.PolicyChange()
+		if err != nil {
+			return fmt.Sprintf("%s %s:%s failed to decode event: %v", prefix, e.Job, e.Group, err)
+		}
+		if change.Policy == nil {
+			return fmt.Sprintf("%s %s:%s deleted", prefix, e.Job, e.Group)
+		}
+		return fmt.Sprintf("%s %s:%s updated", prefix, e.Job, e.Group)
+
+	case api.StreamTopicLeadership:
+		change, err := e.LeadershipChange()
+		if err != nil {
+			return fmt.Sprintf("%s failed to decode event: %v", prefix, err)
+		}
+		if change.IsLeader {
+			return fmt.Sprintf("%s %s obtained leadership", prefix, change.Addr)
+		}
+		return fmt.Sprintf("%s %s lost leadership", prefix, change.Addr)
+	}
+	return prefix
+}
@@ -28,6 +28,8 @@ func RegisterCommand(rootCmd *cobra.Command) error {
 	serverCfg.RegisterClusterConfig(cmd)
 	serverCfg.RegisterMetricProviderConfig(cmd)
 	serverCfg.RegisterDebugConfig(cmd)
+	serverCfg.RegisterAuditConfig(cmd)
+	serverCfg.RegisterNotifierConfig(cmd)
 	logCfg.RegisterConfig(cmd)
 	rootCmd.AddCommand(cmd)
 
@@ -40,6 +42,8 @@ func runServer(_ *cobra.Command, _ []string) {
 	telemetryConfig := serverCfg.GetTelemetryConfig()
 	clusterConfig := serverCfg.GetClusterConfig()
 	metricProviderConfig := serverCfg.GetMetricProviderConfig()
+	auditConfig := serverCfg.GetAuditConfig()
+	notifierConfig := serverCfg.GetNotifierConfig()
 
 	if err := verifyServerConfig(serverConfig); err != nil {
 		fmt.Println(err)
@@ -54,9 +58,11 @@ func runServer(_ *cobra.Command, _ []string) {
 	}
 
 	cfg := &server.Config{
+		Audit:          &auditConfig,
 		Debug:          serverCfg.GetDebugEnabled(),
 		Cluster:        &clusterConfig,
 		MetricProvider: metricProviderConfig,
+		Notifier:       &notifierConfig,
 		Server:         &serverConfig,
 		TLS:            &tlsConfig,
 		Telemetry:      &telemetryConfig,
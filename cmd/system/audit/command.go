@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jrasell/sherpa/cmd/helper"
+	"github.com/jrasell/sherpa/pkg/api"
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/jrasell/sherpa/pkg/config/system"
+	"github.com/sean-/sysexits"
+	"github.com/spf13/cobra"
+)
+
+const outputHeader = "ID|Time|Operation|Job:Group|Identity|Token|Remote Addr|Result"
+
+func RegisterCommand(rootCmd *cobra.Command) error {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "List the most recent audit log entries",
+		Run: func(cmd *cobra.Command, args []string) {
+			runAudit(cmd, args)
+		},
+	}
+	rootCmd.AddCommand(cmd)
+	system.RegisterAuditConfig(cmd)
+
+	return nil
+}
+
+func runAudit(_ *cobra.Command, _ []string) {
+	clientConfig := clientCfg.GetConfig()
+	mergedConfig := api.DefaultConfig(&clientConfig)
+	auditConfig := system.GetAuditConfig()
+
+	client, err := api.NewClient(mergedConfig)
+	if err != nil {
+		fmt.Println("Error setting up Sherpa client:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	entries, err := client.System().Audit(auditConfig.Job, auditConfig.Operation, auditConfig.Limit)
+	if err != nil {
+		fmt.Println("Error calling server audit:", err)
+		os.Exit(sysexits.Software)
+	}
+
+	out := []string{outputHeader}
+
+	for _, e := range entries {
+		jobGroup := ""
+		if e.Job != "" {
+			jobGroup = e.Job + ":" + e.Group
+		}
+		out = append(out, fmt.Sprintf("%s|%v|%s|%s|%s|%s|%s|%s",
+			e.ID, helper.UnixNanoToHumanUTC(e.Time), e.Operation, jobGroup, e.Identity, e.TokenName,
+			e.RemoteAddr, formatResult(e)))
+	}
+
+	if len(out) > 1 {
+		fmt.Println(helper.FormatList(out))
+	}
+}
+
+func formatResult(e *api.AuditEntry) string {
+	if e.Error != "" {
+		return fmt.Sprintf("%s (%v: %s)", e.Result, e.ResponseCode, e.Error)
+	}
+	return fmt.Sprintf("%s (%v)", e.Result, e.ResponseCode)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/jrasell/sherpa/cmd/system/audit"
 	"github.com/jrasell/sherpa/cmd/system/health"
 	"github.com/jrasell/sherpa/cmd/system/info"
 	"github.com/jrasell/sherpa/cmd/system/leader"
@@ -35,6 +36,10 @@ func runSystem(cmd *cobra.Command, _ []string) {
 }
 
 func registerCommands(rootCmd *cobra.Command) error {
+	if err := audit.RegisterCommand(rootCmd); err != nil {
+		return err
+	}
+
 	if err := info.RegisterCommand(rootCmd); err != nil {
 		return err
 	}
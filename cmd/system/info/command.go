@@ -3,6 +3,7 @@ package info
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/jrasell/sherpa/cmd/helper"
 	"github.com/jrasell/sherpa/pkg/api"
@@ -42,6 +43,17 @@ func runInfo(_ *cobra.Command, _ []string) {
 
 	var out []string
 	out = append(out, fmt.Sprintf("%s|%s", "Nomad Address", info.NomadAddress))
+
+	regions := make([]string, 0, len(info.NomadRegions))
+	for region := range info.NomadRegions {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	for _, region := range regions {
+		out = append(out, fmt.Sprintf("%s|%s", "Nomad Address ("+region+")", info.NomadRegions[region]))
+	}
+
 	out = append(out, fmt.Sprintf("%s|%s", "Policy Engine", info.PolicyEngine))
 	out = append(out, fmt.Sprintf("%s|%s", "Storage Backend", info.StorageBackend))
 	out = append(out, fmt.Sprintf("%s|%v", "Internal AutoScaling Engine", info.InternalAutoScalingEngine))
@@ -0,0 +1,167 @@
+package acl
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TokenHeader is the HTTP header used by clients to pass the secret ID of their ACL token.
+	TokenHeader = "X-Sherpa-Token"
+
+	// BootstrapAccessorID is the accessor ID of the bootstrap token configured on the Sherpa
+	// server. The bootstrap token is not stored in the backend and therefore cannot be deleted.
+	BootstrapAccessorID = "bootstrap"
+)
+
+// The capabilities which can be granted by an ACL rule.
+const (
+	// CapabilityRead allows reading scaling state, scaling policies and autoscaler information.
+	CapabilityRead = "read"
+
+	// CapabilityScale allows triggering scaling actions.
+	CapabilityScale = "scale"
+
+	// CapabilityPolicyWrite allows writing and deleting scaling policies.
+	CapabilityPolicyWrite = "policy-write"
+
+	// CapabilitySystem allows reading system information, metrics and debug endpoints. It is only
+	// effective on rules which match all jobs.
+	CapabilitySystem = "system"
+)
+
+var validCapabilities = map[string]bool{
+	CapabilityRead:        true,
+	CapabilityScale:       true,
+	CapabilityPolicyWrite: true,
+	CapabilitySystem:      true,
+}
+
+// ErrTokenNotFound is returned when the secret ID passed by a client does not match a known
+// token.
+var ErrTokenNotFound = errors.New("ACL token not found")
+
+type contextKey struct{}
+
+// Resolver looks up the ACL token of an API request.
+type Resolver struct {
+	bootstrap string
+	backend   token.Backend
+}
+
+// NewResolver creates a new ACL token resolver. The bootstrap token is granted management
+// privileges and is always resolved, regardless of the contents of the backend.
+func NewResolver(bootstrapToken string, backend token.Backend) *Resolver {
+	return &Resolver{bootstrap: bootstrapToken, backend: backend}
+}
+
+// Resolve returns the ACL token identified by the secret ID, or ErrTokenNotFound if the secret ID
+// does not match a known token.
+func (r *Resolver) Resolve(secretID string) (*state.ACLToken, error) {
+	if secretID == "" {
+		return nil, ErrTokenNotFound
+	}
+
+	if r.bootstrap != "" && subtle.ConstantTimeCompare([]byte(secretID), []byte(r.bootstrap)) == 1 {
+		return &state.ACLToken{AccessorID: BootstrapAccessorID, Name: "Bootstrap Token", Management: true}, nil
+	}
+
+	t, err := r.backend.GetTokenBySecret(secretID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup ACL token")
+	}
+	if t == nil {
+		return nil, ErrTokenNotFound
+	}
+	return t, nil
+}
+
+// Allowed determines whether the token grants the capability on the job. An empty job indicates
+// the action is not scoped to a single job, in which case only rules matching all jobs apply.
+func Allowed(t *state.ACLToken, capability, job string) bool {
+	if t == nil {
+		return false
+	}
+	if t.Management {
+		return true
+	}
+
+	for _, rule := range t.Rules {
+		if job == "" && rule.JobPrefix != "" {
+			continue
+		}
+		if !strings.HasPrefix(job, rule.JobPrefix) {
+			continue
+		}
+		for _, c := range rule.Capabilities {
+			if c == capability {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewToken builds a new ACL token with randomly generated accessor and secret IDs.
+func NewToken(name string, management bool, rules []*state.ACLRule) (*state.ACLToken, error) {
+	accessor, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate accessor ID")
+	}
+
+	secret, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate secret ID")
+	}
+
+	t := &state.ACLToken{
+		AccessorID: accessor.String(),
+		SecretID:   secret.String(),
+		Name:       name,
+		Management: management,
+		Rules:      rules,
+		CreateTime: time.Now().UTC().UnixNano(),
+	}
+	return t, Validate(t)
+}
+
+// Validate checks the ACL token rules for correctness.
+func Validate(t *state.ACLToken) error {
+	if t.Management {
+		return nil
+	}
+
+	if len(t.Rules) == 0 {
+		return errors.New("non-management tokens must include at least one rule")
+	}
+
+	for _, rule := range t.Rules {
+		if rule == nil || len(rule.Capabilities) == 0 {
+			return errors.New("rules must include at least one capability")
+		}
+		for _, c := range rule.Capabilities {
+			if !validCapabilities[c] {
+				return errors.Errorf("invalid capability %q", c)
+			}
+		}
+	}
+	return nil
+}
+
+// ContextWithToken returns a copy of the context holding the resolved ACL token of the request.
+func ContextWithToken(ctx context.Context, t *state.ACLToken) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// TokenFromContext returns the resolved ACL token of the request, or nil if ACLs are not enabled.
+func TokenFromContext(ctx context.Context) *state.ACLToken {
+	t, _ := ctx.Value(contextKey{}).(*state.ACLToken)
+	return t
+}
@@ -0,0 +1,101 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/token/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Allowed(t *testing.T) {
+	tok := &state.ACLToken{
+		Rules: []*state.ACLRule{
+			{JobPrefix: "", Capabilities: []string{CapabilityRead}},
+			{JobPrefix: "platform/", Capabilities: []string{CapabilityScale, CapabilityPolicyWrite}},
+			{JobPrefix: "eu-west-1@", Capabilities: []string{CapabilityScale}},
+		},
+	}
+
+	testCases := []struct {
+		token          *state.ACLToken
+		capability     string
+		job            string
+		expectedResult bool
+		name           string
+	}{
+		{token: nil, capability: CapabilityRead, job: "", expectedResult: false, name: "no token"},
+		{token: &state.ACLToken{Management: true}, capability: CapabilitySystem, job: "", expectedResult: true, name: "management token"},
+		{token: tok, capability: CapabilityRead, job: "", expectedResult: true, name: "read all jobs"},
+		{token: tok, capability: CapabilityRead, job: "platform/example", expectedResult: true, name: "read single job"},
+		{token: tok, capability: CapabilityScale, job: "platform/example", expectedResult: true, name: "scale matching prefix"},
+		{token: tok, capability: CapabilityScale, job: "example", expectedResult: false, name: "scale non-matching prefix"},
+		{token: tok, capability: CapabilityScale, job: "eu-west-1@example", expectedResult: true, name: "scale matching region prefix"},
+		{token: tok, capability: CapabilityScale, job: "", expectedResult: false, name: "scale without job scope"},
+		{token: tok, capability: CapabilitySystem, job: "", expectedResult: false, name: "capability not granted"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedResult, Allowed(tc.token, tc.capability, tc.job), tc.name)
+	}
+}
+
+func Test_Validate(t *testing.T) {
+	testCases := []struct {
+		token       *state.ACLToken
+		expectError bool
+		name        string
+	}{
+		{token: &state.ACLToken{Management: true}, expectError: false, name: "management token"},
+		{token: &state.ACLToken{}, expectError: true, name: "no rules"},
+		{
+			token:       &state.ACLToken{Rules: []*state.ACLRule{{JobPrefix: "platform/"}}},
+			expectError: true,
+			name:        "no capabilities",
+		},
+		{
+			token:       &state.ACLToken{Rules: []*state.ACLRule{{Capabilities: []string{"delete"}}}},
+			expectError: true,
+			name:        "invalid capability",
+		},
+		{
+			token:       &state.ACLToken{Rules: []*state.ACLRule{{Capabilities: []string{CapabilityRead, CapabilityScale}}}},
+			expectError: false,
+			name:        "valid rules",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := Validate(tc.token)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	backend := memory.NewStateBackend()
+
+	stored, err := NewToken("operator", false, []*state.ACLRule{{Capabilities: []string{CapabilityRead}}})
+	assert.Nil(t, err)
+	assert.Nil(t, backend.PutToken(stored))
+
+	r := NewResolver("bootstrap-secret", backend)
+
+	actual, err := r.Resolve("bootstrap-secret")
+	assert.Nil(t, err)
+	assert.True(t, actual.Management)
+	assert.Equal(t, BootstrapAccessorID, actual.AccessorID)
+
+	actual, err = r.Resolve(stored.SecretID)
+	assert.Nil(t, err)
+	assert.Equal(t, stored, actual)
+
+	_, err = r.Resolve("unknown")
+	assert.Equal(t, ErrTokenNotFound, err)
+
+	_, err = r.Resolve("")
+	assert.Equal(t, ErrTokenNotFound, err)
+}
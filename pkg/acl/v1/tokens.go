@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/acl"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/token"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	readBodyFailureMsg    = "failed to read request body"
+	marshalRespFailureMsg = "failed to marshall HTTP response"
+)
+
+type ACL struct {
+	logger  zerolog.Logger
+	backend token.Backend
+}
+
+// TokenRequest is the request body used to create a new ACL token.
+type TokenRequest struct {
+	Name       string
+	Management bool
+	Rules      []*state.ACLRule
+}
+
+func NewACLServer(l zerolog.Logger, backend token.Backend) *ACL {
+	return &ACL{logger: l, backend: backend}
+}
+
+// ListTokens returns all stored ACL tokens. The secret IDs are redacted, so that they can only be
+// read by looking up each individual token.
+func (a *ACL) ListTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := a.backend.GetTokens()
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to call ACL token backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for i := range tokens {
+		tokens[i].SecretID = ""
+	}
+
+	a.writeObject(w, tokens, http.StatusOK)
+}
+
+func (a *ACL) CreateToken(w http.ResponseWriter, r *http.Request) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.logger.Error().Msg(readBodyFailureMsg)
+		http.Error(w, readBodyFailureMsg, http.StatusInternalServerError)
+		return
+	}
+
+	var req TokenRequest
+	if err := json.Unmarshal(b, &req); err != nil {
+		a.logger.Error().Err(err).Msg("failed to decode request body")
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	t, err := acl.NewToken(req.Name, req.Management, req.Rules)
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to validate ACL token")
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := a.backend.PutToken(t); err != nil {
+		a.logger.Error().Err(err).Msg("failed to call ACL token backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	a.logger.Info().
+		Str("accessor-id", t.AccessorID).
+		Str("name", t.Name).
+		Bool("management", t.Management).
+		Msg("created ACL token")
+
+	a.writeObject(w, t, http.StatusCreated)
+}
+
+func (a *ACL) GetToken(w http.ResponseWriter, r *http.Request) {
+	t, err := a.backend.GetToken(mux.Vars(r)["accessor_id"])
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to call ACL token backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if t == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	a.writeObject(w, t, http.StatusOK)
+}
+
+// GetSelf returns the ACL token used to authenticate the request.
+func (a *ACL) GetSelf(w http.ResponseWriter, r *http.Request) {
+	t := acl.TokenFromContext(r.Context())
+	if t == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	a.writeObject(w, t, http.StatusOK)
+}
+
+func (a *ACL) DeleteToken(w http.ResponseWriter, r *http.Request) {
+	accessorID := mux.Vars(r)["accessor_id"]
+
+	t, err := a.backend.GetToken(accessorID)
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to call ACL token backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if t == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := a.backend.DeleteToken(accessorID); err != nil {
+		a.logger.Error().Err(err).Msg("failed to call ACL token backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	a.logger.Info().Str("accessor-id", accessorID).Msg("deleted ACL token")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *ACL) writeObject(w http.ResponseWriter, obj interface{}, statusCode int) {
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, bytes, statusCode)
+}
+
+func writeJSONResponse(w http.ResponseWriter, bytes []byte, statusCode int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(bytes); err != nil {
+		log.Error().Err(err).Msg("failed to write JSON response")
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+type ACLTokens struct {
+	client *Client
+}
+
+func (c *Client) ACLTokens() *ACLTokens {
+	return &ACLTokens{client: c}
+}
+
+// ACLToken represents an ACL token used to authenticate requests to the Sherpa API.
+type ACLToken struct {
+	AccessorID string
+	SecretID   string
+	Name       string
+	Management bool
+	Rules      []*ACLRule
+	CreateTime int64
+}
+
+// ACLRule grants capabilities on all jobs whose ID starts with the JobPrefix.
+type ACLRule struct {
+	JobPrefix    string
+	Capabilities []string
+}
+
+// ACLTokenRequest is the request used to create a new ACL token.
+type ACLTokenRequest struct {
+	Name       string
+	Management bool
+	Rules      []*ACLRule
+}
+
+// List returns all stored ACL tokens, with their secret IDs redacted.
+func (a *ACLTokens) List() ([]*ACLToken, error) {
+	var resp []*ACLToken
+	err := a.client.get("/v1/acl/tokens", &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (a *ACLTokens) Create(req *ACLTokenRequest) (*ACLToken, error) {
+	var resp ACLToken
+	err := a.client.post("/v1/acl/token", req, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (a *ACLTokens) Info(accessorID string) (*ACLToken, error) {
+	var resp ACLToken
+	err := a.client.get("/v1/acl/token/"+accessorID, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Self returns the ACL token used by the client.
+func (a *ACLTokens) Self() (*ACLToken, error) {
+	var resp ACLToken
+	err := a.client.get("/v1/acl/token/self", &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (a *ACLTokens) Delete(accessorID string) error {
+	return a.client.delete("/v1/acl/token/"+accessorID, nil)
+}
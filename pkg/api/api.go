@@ -17,7 +17,20 @@ import (
 )
 
 type Config struct {
-	Address    string
+	Address string
+
+	// Namespace is the Nomad namespace of the jobs referenced by requests. If empty, the Sherpa
+	// server uses the default namespace.
+	Namespace string
+
+	// Region is the Nomad region of the jobs referenced by requests. If empty, the Sherpa server
+	// uses its primary Nomad target.
+	Region string
+
+	// Token is the secret ID of the ACL token used to authenticate requests, required when the
+	// Sherpa server has ACLs enabled.
+	Token string
+
 	TLSConfig  *TLSConfig
 	httpClient *http.Client
 }
@@ -47,6 +60,15 @@ func DefaultConfig(cfg *clientCfg.Config) *Config {
 	if cfg.Addr != "" {
 		config.Address = cfg.Addr
 	}
+	if cfg.Namespace != "" {
+		config.Namespace = cfg.Namespace
+	}
+	if cfg.Region != "" {
+		config.Region = cfg.Region
+	}
+	if cfg.Token != "" {
+		config.Token = cfg.Token
+	}
 	if cfg.CAPath != "" {
 		config.TLSConfig.CACert = cfg.CAPath
 	}
@@ -216,6 +238,13 @@ func (c *Client) newRequest(method, path string) (*request, error) {
 		params: make(map[string][]string),
 	}
 
+	if c.config.Namespace != "" {
+		r.params.Set("namespace", c.config.Namespace)
+	}
+	if c.config.Region != "" {
+		r.params.Set("region", c.config.Region)
+	}
+
 	return r, nil
 }
 
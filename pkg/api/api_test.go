@@ -11,6 +11,9 @@ func Test_DefaultConfig(t *testing.T) {
 	testCases := []struct {
 		inputConfig             *clientCfg.Config
 		expectedAddrReturn      string
+		expectedNamespaceReturn string
+		expectedRegionReturn    string
+		expectedTokenReturn     string
 		expectedTLSConfigReturn *TLSConfig
 	}{
 		{
@@ -18,12 +21,52 @@ func Test_DefaultConfig(t *testing.T) {
 			expectedAddrReturn:      "http://127.0.0.1:8000",
 			expectedTLSConfigReturn: &TLSConfig{},
 		},
+		{
+			inputConfig:             &clientCfg.Config{Namespace: "platform"},
+			expectedAddrReturn:      "http://127.0.0.1:8000",
+			expectedNamespaceReturn: "platform",
+			expectedTLSConfigReturn: &TLSConfig{},
+		},
+		{
+			inputConfig:             &clientCfg.Config{Namespace: "platform", Region: "eu-west-1"},
+			expectedAddrReturn:      "http://127.0.0.1:8000",
+			expectedNamespaceReturn: "platform",
+			expectedRegionReturn:    "eu-west-1",
+			expectedTLSConfigReturn: &TLSConfig{},
+		},
+		{
+			inputConfig:             &clientCfg.Config{Token: "secret"},
+			expectedAddrReturn:      "http://127.0.0.1:8000",
+			expectedTokenReturn:     "secret",
+			expectedTLSConfigReturn: &TLSConfig{},
+		},
 	}
 
 	for _, tc := range testCases {
 		actualReturn := DefaultConfig(tc.inputConfig)
 
 		assert.Equal(t, tc.expectedAddrReturn, actualReturn.Address)
+		assert.Equal(t, tc.expectedNamespaceReturn, actualReturn.Namespace)
+		assert.Equal(t, tc.expectedRegionReturn, actualReturn.Region)
+		assert.Equal(t, tc.expectedTokenReturn, actualReturn.Token)
 		assert.Equal(t, tc.expectedTLSConfigReturn, actualReturn.TLSConfig)
 	}
 }
+
+func TestClient_newRequestNamespace(t *testing.T) {
+	client, err := NewClient(&Config{Address: "http://127.0.0.1:8000", Namespace: "platform", Region: "eu-west-1"})
+	assert.Nil(t, err)
+
+	r, err := client.newRequest("GET", "/v1/policy/example")
+	assert.Nil(t, err)
+	assert.Equal(t, "platform", r.params.Get("namespace"))
+	assert.Equal(t, "eu-west-1", r.params.Get("region"))
+
+	client, err = NewClient(&Config{Address: "http://127.0.0.1:8000"})
+	assert.Nil(t, err)
+
+	r, err = client.newRequest("GET", "/v1/policy/example")
+	assert.Nil(t, err)
+	assert.Equal(t, "", r.params.Get("namespace"))
+	assert.Equal(t, "", r.params.Get("region"))
+}
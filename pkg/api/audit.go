@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// AuditEntry is a single audit log entry, recording a mutating API operation.
+type AuditEntry struct {
+	ID              string
+	Time            int64
+	Operation       string
+	Method          string
+	Path            string
+	Job             string
+	Group           string
+	RemoteAddr      string
+	Identity        string
+	TokenAccessorID string
+	TokenName       string
+	RequestBody     json.RawMessage
+	PolicyDiff      *AuditPolicyDiff
+	ResponseCode    int
+	Result          string
+	Error           string
+}
+
+// AuditPolicyDiff holds the scaling policy before and after an audited operation.
+type AuditPolicyDiff struct {
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// Audit lists the most recent audit log entries, newest first. The job and operation filter the
+// entries if they are not empty, and a limit of zero uses the server default.
+func (s *System) Audit(job, operation string, limit int) ([]*AuditEntry, error) {
+	var resp []*AuditEntry
+
+	q := QueryOptions{Params: map[string]string{}}
+	if job != "" {
+		q.Params["job"] = job
+	}
+	if operation != "" {
+		q.Params["operation"] = operation
+	}
+	if limit > 0 {
+		q.Params["limit"] = strconv.Itoa(limit)
+	}
+
+	err := s.client.get("/v1/system/audit", &resp, &q)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,118 @@
+package api
+
+import "fmt"
+
+type AutoScale struct {
+	client *Client
+}
+
+func (c *Client) AutoScale() *AutoScale {
+	return &AutoScale{client: c}
+}
+
+// Explanation details the checks performed and decisions made during an autoscaling evaluation of
+// a job.
+type Explanation struct {
+	Job    string
+	Time   int64
+	Groups map[string]*GroupExplanation
+}
+
+// GroupExplanation details the evaluation of a single job group.
+type GroupExplanation struct {
+	Evaluated      bool
+	SkipReason     string
+	Checks         map[string]*CheckExplanation
+	Conditions     map[string]bool
+	ActionCooldown string
+	Decision       *DecisionExplanation
+}
+
+// CheckExplanation details the result of a single check performed on a job group.
+type CheckExplanation struct {
+	Type               string
+	Value              *float64
+	Threshold          *float64
+	ComparisonOperator string
+	Action             string
+	Result             string
+	Error              string
+	Breaches           *BreachExplanation
+}
+
+// BreachExplanation details the recent evaluations of a check configured with breach periods.
+type BreachExplanation struct {
+	Breaching         int
+	Datapoints        int
+	EvaluationPeriods int
+}
+
+// DecisionExplanation details the final scaling decision for a job group.
+type DecisionExplanation struct {
+	Direction  string
+	Count      int
+	Absolute   bool
+	Percentage bool
+	DryRun     bool
+	Source     string
+	Meta       map[string]string
+}
+
+// Explain performs an autoscaling evaluation of the job without triggering any scaling action,
+// returning the explanation of the decisions made.
+func (a *AutoScale) Explain(job string) (*Explanation, error) {
+	var resp Explanation
+	err := a.client.get("/v1/autoscale/evaluate/"+job, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Evaluation is the result of a single internal autoscaler evaluation of a job group.
+type Evaluation struct {
+	Time     int64
+	Checks   map[string]*EvaluationCheck
+	Decision *EvaluationDecision
+}
+
+// EvaluationCheck is the result of a single check performed during an evaluation.
+type EvaluationCheck struct {
+	Type      string
+	Value     *float64
+	Threshold *float64
+	Result    string
+	Error     string
+}
+
+// EvaluationDecision is the scaling decision made during an evaluation.
+type EvaluationDecision struct {
+	Direction string
+	Count     int
+	Absolute  bool
+	DryRun    bool
+}
+
+// JobHistory returns the autoscaler evaluation history of each group within the job.
+func (a *AutoScale) JobHistory(job string) (map[string][]*Evaluation, error) {
+	var resp map[string][]*Evaluation
+	err := a.client.get("/v1/autoscale/history/"+job, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// JobGroupHistory returns the autoscaler evaluation history of the job group, ordered oldest
+// first.
+func (a *AutoScale) JobGroupHistory(job, group string) ([]*Evaluation, error) {
+	var resp []*Evaluation
+
+	path := fmt.Sprintf("/v1/autoscale/history/%s/%s", job, group)
+
+	err := a.client.get(path, &resp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
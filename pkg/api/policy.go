@@ -16,6 +16,8 @@ func (c *Client) Policies() *Policies {
 type JobGroupPolicy struct {
 	Enabled                           bool
 	Cooldown                          int
+	ScaleOutCooldown                  int
+	ScaleInCooldown                   int
 	MaxCount                          int
 	MinCount                          int
 	ScaleOutCount                     int
@@ -6,6 +6,9 @@ import (
 	"net/url"
 )
 
+// TokenHeader is the HTTP header used to pass the ACL token secret ID to the Sherpa server.
+const TokenHeader = "X-Sherpa-Token"
+
 // QueryOptions are used to create a query which includes query params. This is used for GET, POST
 // and PUT calls.
 type QueryOptions struct {
@@ -55,5 +58,9 @@ func (r *request) toHTTP() (*http.Request, error) {
 	req.URL.Host = r.url.Host
 	req.URL.Scheme = r.url.Scheme
 	req.Host = r.url.Host
+
+	if r.config != nil && r.config.Token != "" {
+		req.Header.Set(TokenHeader, r.config.Token)
+	}
 	return req, nil
 }
@@ -78,6 +78,23 @@ func TestRequest_toHTTP(t *testing.T) {
 	}
 }
 
+func TestRequest_toHTTPToken(t *testing.T) {
+	r := &request{
+		config: &Config{Token: "secret"},
+		url:    &url.URL{Scheme: "http", Host: "127.0.0.1:8000"},
+		method: "GET",
+	}
+
+	req, err := r.toHTTP()
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", req.Header.Get(TokenHeader))
+
+	r.config.Token = ""
+	req, err = r.toHTTP()
+	assert.Nil(t, err)
+	assert.Equal(t, "", req.Header.Get(TokenHeader))
+}
+
 func generateRequest(method string, url *url.URL) *http.Request {
 	req, _ := http.NewRequest(method, url.RequestURI(), nil)
 	req.URL.Host = url.Host
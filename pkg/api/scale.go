@@ -25,13 +25,23 @@ type ScaleResp struct {
 }
 
 type ScalingEvent struct {
-	ID      string
-	EvalID  string
-	Source  string
-	Time    int64
+	ID              string
+	EvalID          string
+	NomadEventIndex uint64
+	Source          string
+	Time            int64
+	Status          string
+	Details         EventDetails
+	Failures        int
+	Outcome         *EventOutcome
+	Identity        string
+	Meta            map[string]string
+}
+
+type EventOutcome struct {
 	Status  string
-	Details EventDetails
-	Meta    map[string]string
+	Reasons []string
+	Time    int64
 }
 
 type EventDetails struct {
@@ -79,10 +89,34 @@ func (s *Scale) JobGroupIn(job, group string, count int, meta map[string]string)
 	return &resp, nil
 }
 
+// JobGroupSet scales the job group to the absolute count specified.
+func (s *Scale) JobGroupSet(job, group string, count int, meta map[string]string) (*ScaleResp, error) {
+	var resp ScaleResp
+
+	q := QueryOptions{Params: map[string]string{"count": strconv.Itoa(count)}}
+
+	path := fmt.Sprintf("/v1/scale/set/%s/%s", job, group)
+
+	err := s.client.post(path, buildScaleReqBody(meta), &resp, &q)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (s *Scale) List(latest bool) (map[uuid.UUID]map[string]*ScalingEvent, error) {
+	return s.ListWithStatus(latest, "")
+}
+
+// ListWithStatus lists the scaling events which have the passed status. If the status is empty,
+// all events are listed.
+func (s *Scale) ListWithStatus(latest bool, status string) (map[uuid.UUID]map[string]*ScalingEvent, error) {
 	var resp map[uuid.UUID]map[string]*ScalingEvent
 
 	q := QueryOptions{Params: map[string]string{"latest": strconv.FormatBool(latest)}}
+	if status != "" {
+		q.Params["status"] = status
+	}
 
 	err := s.client.get("/v1/scale/status", &resp, &q)
 	if err != nil {
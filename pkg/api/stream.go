@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// The topics of events published to the scaling event stream.
+const (
+	StreamTopicScaling    = "ScalingEvent"
+	StreamTopicPolicy     = "Policy"
+	StreamTopicLeadership = "Leadership"
+)
+
+// maxStreamEventSize is the maximum size of a single event read from the event stream.
+const maxStreamEventSize = 1024 * 1024
+
+// StreamFilter selects the events sent by the event stream. Empty fields match all events.
+type StreamFilter struct {
+	Topics []string
+	Job    string
+	Group  string
+	Source string
+}
+
+// StreamEvent is a single event received from the event stream. The Payload type depends on the
+// Topic, and can be decoded using the helper functions.
+type StreamEvent struct {
+	Index   uint64
+	Topic   string
+	Time    int64
+	Job     string
+	Group   string
+	Source  string
+	Payload json.RawMessage
+}
+
+// StreamPolicyChange is the payload of policy events. The Policy is nil if the policy was
+// deleted.
+type StreamPolicyChange struct {
+	Policy *JobGroupPolicy
+}
+
+// StreamLeadershipChange is the payload of leadership events.
+type StreamLeadershipChange struct {
+	IsLeader bool
+	Addr     string
+}
+
+// EventStream reads events from the server-sent event stream.
+type EventStream struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// Stream opens the scaling event stream. The stream must be closed once it is no longer required.
+func (s *Scale) Stream(filter *StreamFilter) (*EventStream, error) {
+	r, err := s.client.newRequest(http.MethodGet, "/v1/scale/stream")
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != nil {
+		if len(filter.Topics) > 0 {
+			r.params.Set("topic", strings.Join(filter.Topics, ","))
+		}
+		if filter.Job != "" {
+			r.params.Set("job", filter.Job)
+		}
+		if filter.Group != "" {
+			r.params.Set("group", filter.Group)
+		}
+		if filter.Source != "" {
+			r.params.Set("source", filter.Source)
+		}
+	}
+
+	resp, err := s.client.doRequest(r)
+	resp, err = requireOK(resp, err, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 4096), maxStreamEventSize)
+
+	return &EventStream{body: resp.Body, scanner: scanner}, nil
+}
+
+// Next blocks until the next event is received. It returns io.EOF when the server ends the
+// stream, which happens when the server loses the cluster leadership.
+func (e *EventStream) Next() (*StreamEvent, error) {
+	var data bytes.Buffer
+
+	for e.scanner.Scan() {
+		line := e.scanner.Text()
+
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var event StreamEvent
+			if err := json.Unmarshal(data.Bytes(), &event); err != nil {
+				return nil, err
+			}
+			return &event, nil
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := e.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close closes the event stream.
+func (e *EventStream) Close() error {
+	return e.body.Close()
+}
+
+// ScalingEvent decodes the payload of a scaling event.
+func (e *StreamEvent) ScalingEvent() (*ScalingEvent, error) {
+	var out ScalingEvent
+	if err := json.Unmarshal(e.Payload, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PolicyChange decodes the payload of a policy event.
+func (e *StreamEvent) PolicyChange() (*StreamPolicyChange, error) {
+	var out StreamPolicyChange
+	if err := json.Unmarshal(e.Payload, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// LeadershipChange decodes the payload of a leadership event.
+func (e *StreamEvent) LeadershipChange() (*StreamLeadershipChange, error) {
+	var out StreamLeadershipChange
+	if err := json.Unmarshal(e.Payload, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	clientCfg "github.com/jrasell/sherpa/pkg/config/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScale_Stream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/scale/stream", r.URL.Path)
+		assert.Equal(t, "ScalingEvent,Policy", r.URL.Query().Get("topic"))
+		assert.Equal(t, "example", r.URL.Query().Get("job"))
+		assert.Equal(t, "platform", r.URL.Query().Get("namespace"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, ": heartbeat\n\n")
+		_, _ = io.WriteString(w, "id: 1\nevent: ScalingEvent\n"+
+			`data: {"Index":1,"Topic":"ScalingEvent","Job":"example","Group":"cache","Source":"API","Payload":{"Status":"Completed","Details":{"Count":2,"Direction":"out"}}}`+"\n\n")
+		_, _ = io.WriteString(w, "id: 2\nevent: Policy\n"+
+			`data: {"Index":2,"Topic":"Policy","Job":"example","Group":"cache","Payload":{"Policy":null}}`+"\n\n")
+	}))
+	defer ts.Close()
+
+	cfg := DefaultConfig(&clientCfg.Config{Addr: ts.URL, Namespace: "platform"})
+	client, err := NewClient(cfg)
+	assert.Nil(t, err)
+
+	stream, err := client.Scale().Stream(&StreamFilter{Topics: []string{StreamTopicScaling, StreamTopicPolicy}, Job: "example"})
+	assert.Nil(t, err)
+	defer stream.Close()
+
+	event, err := stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), event.Index)
+	assert.Equal(t, StreamTopicScaling, event.Topic)
+	assert.Equal(t, "cache", event.Group)
+
+	scalingEvent, err := event.ScalingEvent()
+	assert.Nil(t, err)
+	assert.Equal(t, "Completed", scalingEvent.Status)
+	assert.Equal(t, EventDetails{Count: 2, Direction: "out"}, scalingEvent.Details)
+
+	event, err = stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, StreamTopicPolicy, event.Topic)
+
+	change, err := event.PolicyChange()
+	assert.Nil(t, err)
+	assert.Nil(t, change.Policy)
+
+	_, err = stream.Next()
+	assert.Equal(t, io.EOF, err)
+}
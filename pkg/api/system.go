@@ -12,6 +12,7 @@ type HealthResp struct {
 
 type InfoResp struct {
 	NomadAddress              string
+	NomadRegions              map[string]string
 	PolicyEngine              string
 	StorageBackend            string
 	InternalAutoScalingEngine bool
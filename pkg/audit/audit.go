@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/gofrs/uuid"
+	"github.com/rs/zerolog"
+)
+
+// The results recorded on audit entries.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Define our metric keys.
+var (
+	metricKeyRecord      = []string{"audit", "record"}
+	metricKeyWriteFailed = []string{"audit", "write_failed"}
+)
+
+// Entry is a single audit log entry, recording a mutating API operation.
+type Entry struct {
+
+	// ID uniquely identifies the audit entry.
+	ID string
+
+	// Time is a UnixNano timestamp declaring when the operation was requested.
+	Time int64
+
+	// Operation is the name of the API route which was called, such as ScaleOutJobGroup.
+	Operation string
+
+	// Method and Path are the HTTP method and path of the request.
+	Method string
+	Path   string
+
+	// Job and Group identify the job group targeted by the operation, if any.
+	Job   string `json:",omitempty"`
+	Group string `json:",omitempty"`
+
+	// RemoteAddr is the source IP address of the request.
+	RemoteAddr string
+
+	// Identity is the identity of the client, taken from its verified TLS client certificate.
+	Identity string `json:",omitempty"`
+
+	// TokenAccessorID and TokenName identify the ACL token used by the request.
+	TokenAccessorID string `json:",omitempty"`
+	TokenName       string `json:",omitempty"`
+
+	// RequestBody is the body of the request. This is stored as raw JSON when the body is valid
+	// JSON, otherwise as a string.
+	RequestBody json.RawMessage `json:",omitempty"`
+
+	// PolicyDiff holds the scaling policy before and after the operation, for operations which
+	// write or delete scaling policies.
+	PolicyDiff *PolicyDiff `json:",omitempty"`
+
+	// ResponseCode is the HTTP status code returned to the client.
+	ResponseCode int
+
+	// Result is either success or failure, based on the response code.
+	Result string
+
+	// Error is the error returned to the client when the operation failed.
+	Error string `json:",omitempty"`
+}
+
+// PolicyDiff holds the scaling policy before and after an operation. A nil value indicates the
+// policy did not exist.
+type PolicyDiff struct {
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// Sink is the interface which audit log sinks implement in order to durably store audit entries.
+type Sink interface {
+
+	// Name returns the name of the sink for logging purposes.
+	Name() string
+
+	// Write stores the audit entry.
+	Write(entry *Entry) error
+}
+
+// Reader is implemented by sinks which support querying the stored audit entries.
+type Reader interface {
+
+	// List returns the most recent audit entries which match the filter, newest first.
+	List(filter *Filter) ([]*Entry, error)
+}
+
+// Filter is used to filter audit entries when querying a Reader.
+type Filter struct {
+
+	// Job only returns entries targeting the job, if set.
+	Job string
+
+	// Operation only returns entries of the operation, if set.
+	Operation string
+
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+// Matches returns whether the entry matches the filter, ignoring the limit.
+func (f *Filter) Matches(e *Entry) bool {
+	if f.Job != "" && e.Job != f.Job {
+		return false
+	}
+	if f.Operation != "" && e.Operation != f.Operation {
+		return false
+	}
+	return true
+}
+
+// Newest takes entries stored oldest first and returns up to limit of the most recent, newest
+// first. A limit of zero or less returns all entries.
+func Newest(entries []*Entry, limit int) []*Entry {
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	out := make([]*Entry, len(entries))
+	for i := range entries {
+		out[len(entries)-1-i] = entries[i]
+	}
+	return out
+}
+
+// Auditor writes audit entries to all configured sinks.
+type Auditor struct {
+	logger zerolog.Logger
+	sinks  []Sink
+	reader Reader
+}
+
+// NewAuditor creates an auditor which writes to the sinks. The first sink which implements the
+// Reader interface is used to serve audit log queries.
+func NewAuditor(logger zerolog.Logger, sinks ...Sink) *Auditor {
+	a := &Auditor{logger: logger, sinks: sinks}
+
+	for _, s := range sinks {
+		if r, ok := s.(Reader); ok {
+			a.reader = r
+			break
+		}
+	}
+	return a
+}
+
+// NewEntry creates an audit entry with a unique ID and the current time.
+func NewEntry() *Entry {
+	e := &Entry{Time: time.Now().UTC().UnixNano()}
+
+	if id, err := uuid.NewV4(); err == nil {
+		e.ID = id.String()
+	}
+	return e
+}
+
+// Record writes the entry to all sinks. Failures are logged rather than returned, so that a sink
+// outage does not affect the operation being audited.
+func (a *Auditor) Record(e *Entry) {
+	defer metrics.MeasureSince(metricKeyRecord, time.Now())
+
+	for _, s := range a.sinks {
+		if err := s.Write(e); err != nil {
+			metrics.IncrCounter(metricKeyWriteFailed, 1)
+			a.logger.Error().
+				Err(err).
+				Str("sink", s.Name()).
+				Str("audit-id", e.ID).
+				Msg("failed to write audit log entry")
+		}
+	}
+}
+
+// Reader returns the sink used to query audit entries, or nil if no configured sink supports
+// querying.
+func (a *Auditor) Reader() Reader { return a.reader }
+
+type contextKey struct{}
+
+// ContextWithEntry returns a copy of the context holding the audit entry of the request, allowing
+// handlers further down the chain to annotate it.
+func ContextWithEntry(ctx context.Context, e *Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, e)
+}
+
+// EntryFromContext returns the audit entry of the request, or nil if the request is not audited.
+func EntryFromContext(ctx context.Context) *Entry {
+	e, _ := ctx.Value(contextKey{}).(*Entry)
+	return e
+}
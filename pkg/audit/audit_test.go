@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewest(t *testing.T) {
+	entries := []*Entry{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	testCases := []struct {
+		limit       int
+		expectedIDs []string
+		name        string
+	}{
+		{limit: 0, expectedIDs: []string{"3", "2", "1"}, name: "no limit"},
+		{limit: 2, expectedIDs: []string{"3", "2"}, name: "limit less than entries"},
+		{limit: 5, expectedIDs: []string{"3", "2", "1"}, name: "limit greater than entries"},
+	}
+
+	for _, tc := range testCases {
+		var ids []string
+		for _, e := range Newest(entries, tc.limit) {
+			ids = append(ids, e.ID)
+		}
+		assert.Equal(t, tc.expectedIDs, ids, tc.name)
+	}
+}
+
+func TestFilter_Matches(t *testing.T) {
+	entry := &Entry{Job: "platform/example", Operation: "ScaleOutJobGroup"}
+
+	testCases := []struct {
+		filter         *Filter
+		expectedOutput bool
+		name           string
+	}{
+		{filter: &Filter{}, expectedOutput: true, name: "empty filter"},
+		{filter: &Filter{Job: "platform/example"}, expectedOutput: true, name: "matching job"},
+		{filter: &Filter{Job: "example"}, expectedOutput: false, name: "non-matching job"},
+		{
+			filter:         &Filter{Job: "platform/example", Operation: "ScaleInJobGroup"},
+			expectedOutput: false,
+			name:           "non-matching operation",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.filter.Matches(entry), tc.name)
+	}
+}
@@ -0,0 +1,156 @@
+package consul
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/pkg/errors"
+)
+
+var (
+	_ audit.Sink   = (*Sink)(nil)
+	_ audit.Reader = (*Sink)(nil)
+)
+
+const (
+	auditKVPath        = "audit/"
+	auditHeadKVKey     = "head"
+	auditEntriesKVPath = "entries/"
+
+	// maxHeadAttempts is the number of times a write will attempt to claim the next sequence
+	// number before giving up, when contended by concurrent writers.
+	maxHeadAttempts = 10
+)
+
+// Define our metric keys.
+var (
+	metricKeyWrite = []string{"audit", "sink", "consul", "write"}
+	metricKeyList  = []string{"audit", "sink", "consul", "list"}
+)
+
+// Sink writes audit entries to a fixed size ring buffer within Consul KV. Each entry is assigned
+// a sequence number by atomically incrementing the head key, and is stored in the slot given by
+// the sequence modulo the ring size, overwriting the oldest entry once the ring is full. As the
+// ring is stored in Consul, it survives Sherpa leadership changes.
+type Sink struct {
+	path string
+	size uint64
+
+	kv *api.KV
+}
+
+// record is the Consul KV value of a ring slot.
+type record struct {
+	Seq   uint64
+	Entry *audit.Entry
+}
+
+// NewSink returns a Consul audit sink retaining up to size entries.
+func NewSink(path string, client *api.Client, size int) (*Sink, error) {
+	if size < 1 {
+		return nil, errors.New("audit Consul ring size must be greater than zero")
+	}
+	return &Sink{
+		path: path + auditKVPath,
+		size: uint64(size),
+		kv:   client.KV(),
+	}, nil
+}
+
+func (s *Sink) Name() string { return "consul" }
+
+func (s *Sink) Write(entry *audit.Entry) error {
+	defer metrics.MeasureSince(metricKeyWrite, time.Now())
+
+	seq, err := s.nextSeq()
+	if err != nil {
+		return err
+	}
+
+	marshal, err := json.Marshal(&record{Seq: seq, Entry: entry})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit entry")
+	}
+
+	pair := &api.KVPair{
+		Key:   s.path + auditEntriesKVPath + strconv.FormatUint(seq%s.size, 10),
+		Value: marshal,
+	}
+
+	_, err = s.kv.Put(pair, nil)
+	return err
+}
+
+// nextSeq claims the next sequence number, using a check-and-set write of the head key so that
+// concurrent writers do not claim the same slot.
+func (s *Sink) nextSeq() (uint64, error) {
+	for i := 0; i < maxHeadAttempts; i++ {
+		kv, _, err := s.kv.Get(s.path+auditHeadKVKey, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		var (
+			seq   uint64
+			index uint64
+		)
+
+		if kv != nil {
+			if seq, err = strconv.ParseUint(string(kv.Value), 10, 64); err != nil {
+				return 0, errors.Wrap(err, "failed to parse audit head sequence")
+			}
+			index = kv.ModifyIndex
+		}
+		seq++
+
+		ok, _, err := s.kv.CAS(&api.KVPair{
+			Key:         s.path + auditHeadKVKey,
+			Value:       []byte(strconv.FormatUint(seq, 10)),
+			ModifyIndex: index,
+		}, nil)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return seq, nil
+		}
+	}
+	return 0, errors.New("failed to claim audit head sequence, too many concurrent writers")
+}
+
+// List returns the most recent audit entries held in the ring which match the filter, newest
+// first.
+func (s *Sink) List(filter *audit.Filter) ([]*audit.Entry, error) {
+	defer metrics.MeasureSince(metricKeyList, time.Now())
+
+	kv, _, err := s.kv.List(s.path+auditEntriesKVPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*record, 0, len(kv))
+
+	for i := range kv {
+		var r record
+		if err := json.Unmarshal(kv[i].Value, &r); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+		}
+		records = append(records, &r)
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Seq < records[j].Seq })
+
+	var entries []*audit.Entry
+
+	for i := range records {
+		if records[i].Entry != nil && filter.Matches(records[i].Entry) {
+			entries = append(entries, records[i].Entry)
+		}
+	}
+	return audit.Newest(entries, filter.Limit), nil
+}
@@ -0,0 +1,96 @@
+package file
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/pkg/errors"
+)
+
+// Sink writes audit entries to a local file, one JSON document per line. The file is only local
+// to the Sherpa server which wrote it, so it should be shipped elsewhere if the trail is required
+// to survive the loss of the host.
+type Sink struct {
+	path string
+
+	// lock serialises writes so that lines from concurrent requests are not interleaved.
+	lock sync.Mutex
+}
+
+var (
+	_ audit.Sink   = (*Sink)(nil)
+	_ audit.Reader = (*Sink)(nil)
+)
+
+// NewSink creates a file audit sink, ensuring the file at path can be opened for writing.
+func NewSink(path string) (*Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log file")
+	}
+	if err := f.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close audit log file")
+	}
+	return &Sink{path: path}, nil
+}
+
+func (s *Sink) Name() string { return "file" }
+
+// Write appends the entry to the audit log file. The file is opened per write so that external
+// log rotation is picked up without a restart.
+func (s *Sink) Write(entry *audit.Entry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit entry")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open audit log file")
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "failed to write audit log file")
+	}
+	return f.Close()
+}
+
+// List reads the audit log file and returns the most recent entries which match the filter,
+// newest first.
+func (s *Sink) List(filter *audit.Filter) ([]*audit.Entry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open audit log file")
+	}
+	defer f.Close()
+
+	var entries []*audit.Entry
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		var e audit.Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal audit entry")
+		}
+		if filter.Matches(&e) {
+			entries = append(entries, &e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read audit log file")
+	}
+
+	return audit.Newest(entries, filter.Limit), nil
+}
@@ -0,0 +1,40 @@
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sherpa-audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	sink, err := NewSink(filepath.Join(dir, "audit.log"))
+	assert.Nil(t, err)
+
+	entries, err := sink.List(&audit.Filter{})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 0)
+
+	assert.Nil(t, sink.Write(&audit.Entry{ID: "1", Job: "example", RequestBody: []byte(`{"Count":1}`)}))
+	assert.Nil(t, sink.Write(&audit.Entry{ID: "2", Job: "platform/example"}))
+	assert.Nil(t, sink.Write(&audit.Entry{ID: "3", Job: "example"}))
+
+	entries, err = sink.List(&audit.Filter{Job: "example"})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "3", entries[0].ID)
+	assert.Equal(t, "1", entries[1].ID)
+	assert.JSONEq(t, `{"Count":1}`, string(entries[1].RequestBody))
+
+	entries, err = sink.List(&audit.Filter{Limit: 1})
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "3", entries[0].ID)
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	marshalRespFailureMsg = "failed to marshall HTTP response"
+
+	// defaultListLimit is the number of entries returned when the request does not specify a
+	// limit.
+	defaultListLimit = 100
+)
+
+type Audit struct {
+	logger  zerolog.Logger
+	auditor *audit.Auditor
+}
+
+func NewAuditServer(l zerolog.Logger, auditor *audit.Auditor) *Audit {
+	return &Audit{logger: l, auditor: auditor}
+}
+
+// ListEntries returns the most recent audit log entries, newest first. The entries can be
+// filtered using the job and operation query parameters, and the number returned set using the
+// limit query parameter.
+func (a *Audit) ListEntries(w http.ResponseWriter, r *http.Request) {
+	reader := a.auditor.Reader()
+	if reader == nil {
+		http.Error(w, "no configured audit sink supports querying", http.StatusBadRequest)
+		return
+	}
+
+	filter := &audit.Filter{
+		Job:       r.URL.Query().Get("job"),
+		Operation: r.URL.Query().Get("operation"),
+		Limit:     defaultListLimit,
+	}
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		limit, err := strconv.Atoi(l)
+		if err != nil || limit < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := reader.List(filter)
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to list audit entries")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if entries == nil {
+		entries = []*audit.Entry{}
+	}
+
+	bytes, err := json.Marshal(entries)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, marshalRespFailureMsg, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(bytes); err != nil {
+		log.Error().Err(err).Msg("failed to write JSON response")
+	}
+}
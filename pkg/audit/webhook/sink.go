@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var _ audit.Sink = (*Sink)(nil)
+
+const (
+	// queueSize is the number of entries which can be waiting to be sent before new entries are
+	// rejected.
+	queueSize = 1024
+
+	requestTimeout = 10 * time.Second
+)
+
+// Define our metric keys.
+var metricKeySend = []string{"audit", "sink", "webhook", "send"}
+
+// Sink sends audit entries to a HTTP endpoint as JSON POST requests. Entries are sent
+// asynchronously so that a slow endpoint does not delay API responses; the sink does not support
+// querying.
+type Sink struct {
+	addr   string
+	logger zerolog.Logger
+	client *http.Client
+	queue  chan *audit.Entry
+}
+
+// NewSink returns a webhook audit sink and starts the routine which sends queued entries.
+func NewSink(logger zerolog.Logger, addr string) *Sink {
+	s := &Sink{
+		addr:   addr,
+		logger: logger,
+		client: &http.Client{Timeout: requestTimeout},
+		queue:  make(chan *audit.Entry, queueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *Sink) Name() string { return "webhook" }
+
+// Write queues the entry to be sent, returning an error if the queue is full.
+func (s *Sink) Write(entry *audit.Entry) error {
+	select {
+	case s.queue <- entry:
+		return nil
+	default:
+		return errors.New("audit webhook queue is full, dropping entry")
+	}
+}
+
+func (s *Sink) run() {
+	for entry := range s.queue {
+		if err := s.send(entry); err != nil {
+			s.logger.Error().
+				Err(err).
+				Str("audit-id", entry.ID).
+				Msg("failed to send audit entry to webhook")
+		}
+	}
+}
+
+func (s *Sink) send(entry *audit.Entry) error {
+	defer metrics.MeasureSince(metricKeySend, time.Now())
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal audit entry")
+	}
+
+	resp, err := s.client.Post(s.addr, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("webhook responded with unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -2,14 +2,18 @@ package autoscale
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	sendMetrics "github.com/armon/go-metrics"
 	nomad "github.com/hashicorp/nomad/api"
 	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
+	"github.com/jrasell/sherpa/pkg/autoscale/predictive"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/scale"
 	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/breach"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
 	"github.com/rs/zerolog"
 )
 
@@ -17,10 +21,19 @@ type autoscaleEvaluation struct {
 	nomad          *nomad.Client
 	metricProvider map[policy.MetricsProvider]metrics.Provider
 	scaler         scale.Scale
+	predictor      *predictive.Predictor
 
 	// policies are the job group policies that will be evaluated during this run.
 	policies map[string]*policy.GroupScalingPolicy
 
+	// groupCounts are the current counts of the job groups, keyed by group name. This is only
+	// populated when at least one group policy uses target tracking or predictive scaling.
+	groupCounts map[string]int
+
+	// dryRun indicates the autoscaler is running in dry-run mode, and so all scaling decisions
+	// should be recorded rather than acted upon.
+	dryRun bool
+
 	// jobID is the Nomad job which is under evaluation.
 	jobID string
 
@@ -29,6 +42,29 @@ type autoscaleEvaluation struct {
 
 	// log has the jobID context to save repeating this effort.
 	log zerolog.Logger
+
+	// explain records the checks and decisions made during the evaluation. This is nil unless the
+	// evaluation is being explained, or evaluation history is enabled.
+	explain *Explanation
+
+	// explainOnly indicates the evaluation is being run to explain the autoscaler decisions, and
+	// therefore must not have any side effects.
+	explainOnly bool
+
+	// history stores the evaluation history of each job group. If this is nil, evaluation history
+	// is not recorded.
+	history evaluation.Backend
+
+	// breach stores the recent threshold check results of each job group, used by checks
+	// configured with breach periods.
+	breach breach.Backend
+
+	// breaches are the breach histories of the job groups read from the breach backend during
+	// this evaluation, keyed by group name. A nil entry indicates the history could not be read.
+	breaches map[string]*state.BreachHistory
+
+	// interval is the autoscaler evaluation interval in seconds.
+	interval int
 }
 
 func (ae *autoscaleEvaluation) evaluateJob() {
@@ -36,8 +72,17 @@ func (ae *autoscaleEvaluation) evaluateJob() {
 
 	defer sendMetrics.MeasureSince([]string{"autoscale", ae.jobID, "evaluation"}, time.Now())
 
+	ae.evaluateDecisions(ae.calculateJobDecisions())
+	ae.saveBreachHistory()
+	ae.recordHistory()
+}
+
+// calculateJobDecisions performs the checks configured for each job group under evaluation,
+// returning the Nomad, external and predictive decisions respectively.
+func (ae *autoscaleEvaluation) calculateJobDecisions() (map[string]*scalingDecision, map[string]*scalingDecision, map[string]*scalingDecision) {
 	externalDecision := make(map[string]*scalingDecision)
 	nomadDecision := make(map[string]*scalingDecision)
+	predictiveDecision := make(map[string]*scalingDecision)
 
 	// We need to check to see whether the the job policies contain a group which is using Nomad
 	// checks. This dictates whether we run the initial gatherNomadMetrics function and then
@@ -52,18 +97,31 @@ func (ae *autoscaleEvaluation) evaluateJob() {
 
 	var (
 		nomadMetricData *nomadGatheredMetrics
+		nomadMetricErr  error
 		err             error
 	)
 
+	// Target tracking and predictive policies rely on the current count, so gather the job group
+	// counts once for the whole job. If this fails, these decisions will be skipped for this
+	// evaluation.
+	for _, p := range ae.policies {
+		if p.TargetTrackingEnabled() || ae.predictiveConfigured(p) {
+			if ae.groupCounts, err = ae.getJobGroupCounts(); err != nil {
+				ae.log.Error().Err(err).Msg("failed to collect Nomad job group counts, skipping target tracking and predictive checks")
+			}
+			break
+		}
+	}
+
 	// If the job policy contains groups which rely on Nomad data, we should collect this now. It
 	// is most efficient to collect this data on a per job basis rather than per group. If we get
 	// an error when performing this, log it and continue. It is possible external checks are also
 	// in place and working; we can nil check the nomadMetricData to skip Nomad checks during this
 	// evaluation.
 	if nomadCheck {
-		nomadMetricData, err = ae.gatherNomadMetrics()
-		if err != nil {
-			ae.log.Error().Err(err).Msg("failed to collect Nomad metrics, skipping Nomad based checks")
+		nomadMetricData, nomadMetricErr = ae.gatherNomadMetrics()
+		if nomadMetricErr != nil {
+			ae.log.Error().Err(nomadMetricErr).Msg("failed to collect Nomad metrics, skipping Nomad based checks")
 		}
 	}
 
@@ -74,19 +132,48 @@ func (ae *autoscaleEvaluation) evaluateJob() {
 		start := time.Now()
 		ae.log.Debug().Str("group", group).Msg("triggering autoscaling job group evaluation")
 
-		// If the group policy has Nomad checks enabled, and we managed to successfully get the
-		// Nomad metric data, perform the evaluation.
-		if p.NomadChecksEnabled() && nomadMetricData != nil {
-			if nomadDec := ae.evaluateNomadJobMetrics(group, p, nomadMetricData); nomadDec != nil {
-				nomadDecision[group] = nomadDec
+		if p.NomadChecksEnabled() && nomadMetricErr != nil {
+			ae.explain.checkError(group, nomadMetricsCheckName, CheckTypeNomad,
+				"failed to collect Nomad metrics: "+nomadMetricErr.Error())
+		}
+
+		// If the group has check conditions, the Nomad and external checks are combined into a
+		// single decision. This is tracked alongside the Nomad decisions, as the group will not
+		// have a separate external decision.
+		if p.CheckConditionsEnabled() {
+			if dec := ae.calculateConditionalDecision(group, p, nomadMetricData); dec != nil {
+				nomadDecision[group] = dec
+			}
+		} else if p.TargetTrackingEnabled() {
+
+			// Target tracking combines the Nomad and external targets into a single desired count,
+			// which is also tracked alongside the Nomad decisions.
+			if dec := ae.calculateTargetDecision(group, p, nomadMetricData); dec != nil {
+				nomadDecision[group] = dec
+			}
+		} else {
+
+			// If the group policy has Nomad checks enabled, and we managed to successfully get
+			// the Nomad metric data, perform the evaluation.
+			if p.NomadChecksEnabled() && nomadMetricData != nil {
+				if nomadDec := ae.evaluateNomadJobMetrics(group, p, nomadMetricData); nomadDec != nil {
+					nomadDecision[group] = nomadDec
+				}
+			}
+
+			// If the group has external checks, perform these and ensure the decision if not
+			// nil, before adding this to the decision tree.
+			if p.ExternalChecks != nil {
+				if extDec := ae.calculateExternalScalingDecision(group, p); extDec != nil {
+					externalDecision[group] = extDec
+				}
 			}
 		}
 
-		// If the group has external checks, perform these and ensure the decision if not nil,
-		// before adding this to the decision tree.
-		if p.ExternalChecks != nil {
-			if extDec := ae.calculateExternalScalingDecision(group, p); extDec != nil {
-				externalDecision[group] = extDec
+		// If the group has predictive config, update the baseline and perform the prediction.
+		if ae.predictiveConfigured(p) {
+			if predDec := ae.evaluatePredictive(group, p); predDec != nil {
+				predictiveDecision[group] = predDec
 			}
 		}
 
@@ -94,15 +181,44 @@ func (ae *autoscaleEvaluation) evaluateJob() {
 		sendMetrics.MeasureSince([]string{"autoscale", ae.jobID, group, "evaluation"}, start)
 	}
 
-	ae.evaluateDecisions(nomadDecision, externalDecision)
+	return nomadDecision, externalDecision, predictiveDecision
 }
 
-func (ae *autoscaleEvaluation) evaluateDecisions(nomadDecision, externalDecision map[string]*scalingDecision) {
+func (ae *autoscaleEvaluation) evaluateDecisions(nomadDecision, externalDecision, predictiveDecision map[string]*scalingDecision) {
+	finalDecision := ae.buildFinalDecision(nomadDecision, externalDecision, predictiveDecision)
+	if len(finalDecision) == 0 {
+		return
+	}
+
+	// Build the scaling request to send to the scaler backend.
+	scaleReq := ae.buildScalingReq(finalDecision)
+
+	for _, req := range scaleReq {
+		ae.explain.decision(req, ae.dryRun, state.SourceInternalAutoscaler)
+	}
+
+	// Group requests which are in dry-run mode are recorded rather than triggered.
+	scaleReq, dryRunReq := splitDryRunReqs(scaleReq, ae.dryRun)
+	if len(dryRunReq) > 0 {
+		go ae.triggerDryRun(dryRunReq)
+	}
+
+	// If group scaling requests have been added to the array for the job that is currently being
+	// checked, trigger a scaling event. Run this in a routine as from this point there is nothing
+	// we can do.
+	if len(scaleReq) > 0 {
+		go ae.triggerScaling(scaleReq)
+	}
+}
+
+// buildFinalDecision takes the decisions from the Nomad, external and predictive checks, producing
+// the final decision for each job group which requires scaling.
+func (ae *autoscaleEvaluation) buildFinalDecision(nomadDecision, externalDecision, predictiveDecision map[string]*scalingDecision) map[string]*scalingDecision {
 
 	// Exit quickly if there are now scaling decisions to process.
-	if len(nomadDecision) == 0 && len(externalDecision) == 0 {
+	if len(nomadDecision) == 0 && len(externalDecision) == 0 && len(predictiveDecision) == 0 {
 		ae.log.Info().Msg("scaling evaluation completed and no scaling required")
-		return
+		return nil
 	}
 	var finalDecision map[string]*scalingDecision
 
@@ -127,15 +243,15 @@ func (ae *autoscaleEvaluation) evaluateDecisions(nomadDecision, externalDecision
 		finalDecision = ae.buildSingleDecision(nomadDecision, externalDecision)
 	}
 
-	// Build the scaling request to send to the scaler backend.
-	scaleReq := ae.buildScalingReq(finalDecision)
-
-	// If group scaling requests have been added to the array for the job that is currently being
-	// checked, trigger a scaling event. Run this in a routine as from this point there is nothing
-	// we can do.
-	if len(scaleReq) > 0 {
-		go ae.triggerScaling(scaleReq)
+	// Predictive decisions only ever scale out, and are merged with the reactive decisions so
+	// that the larger of the two counts is used.
+	if len(predictiveDecision) > 0 {
+		ae.log.Debug().Msg("scaling evaluation completed, merging predictive scaling decisions")
+		finalDecision = ae.mergePredictiveDecisions(finalDecision, predictiveDecision)
 	}
+
+	ae.applyActionCooldowns(finalDecision)
+	return finalDecision
 }
 
 // triggerScaling is used to trigger the scaling of a job based on one or more group changes as
@@ -161,7 +277,15 @@ func (ae *autoscaleEvaluation) triggerScaling(req []*scale.GroupReq) {
 func (ae *autoscaleEvaluation) buildScalingReq(dec map[string]*scalingDecision) []*scale.GroupReq {
 	var scaleReq []*scale.GroupReq // nolint:prealloc
 
-	for group, decision := range dec {
+	// Iterate the groups in a consistent order so that the scaling request is deterministic.
+	groups := make([]string, 0, len(dec))
+	for group := range dec {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		decision := dec[group]
 
 		// Iterate over the resource metrics which have broken their thresholds and ensure these
 		// are added to the submission meta.
@@ -175,6 +299,8 @@ func (ae *autoscaleEvaluation) buildScalingReq(dec map[string]*scalingDecision)
 		req := &scale.GroupReq{
 			Direction:          decision.direction,
 			Count:              decision.count,
+			Absolute:           decision.absolute,
+			Percentage:         decision.percentage,
 			GroupName:          group,
 			GroupScalingPolicy: ae.policies[group],
 			Time:               ae.time,
@@ -197,7 +323,10 @@ func (ae *autoscaleEvaluation) buildSingleDecision(nomad, external map[string]*s
 
 	for group, nomadDec := range nomad {
 		if extDec, ok := external[group]; ok {
-			final[group] = ae.buildSingleGroupDecision(nomadDec, extDec)
+			if dec := ae.buildSingleGroupDecision(nomadDec, extDec); dec != nil {
+				final[group] = dec
+				continue
+			}
 		}
 		final[group] = nomadDec
 	}
@@ -236,6 +365,23 @@ func (ae *autoscaleEvaluation) buildSingleGroupDecision(nomad, external *scaling
 		for key, metric := range external.metrics {
 			nomad.metrics[key] = metric
 		}
+
+		// Step adjustments may select a different count for each source. When scaling out the
+		// larger count is used to ensure the group can handle the load seen by either source,
+		// whereas when scaling in the smaller count is used so that the group is only reduced by
+		// the amount both sources agree on. Target tracking decisions carry the desired count, so
+		// the larger count is the least aggressive in either direction.
+		switch {
+		case nomad.absolute != external.absolute:
+		case nomad.absolute || nomad.direction == scale.DirectionOut:
+			if external.count > nomad.count {
+				nomad.count = external.count
+			}
+		default:
+			if external.count < nomad.count {
+				nomad.count = external.count
+			}
+		}
 		return nomad
 	}
 	return nil
@@ -270,6 +270,72 @@ func Test_autoscaleEvaluation_buildSingleGroupDecision(t *testing.T) {
 			},
 			name: "nomad decision out, external decision out",
 		},
+		{
+			inputNomadDec: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     5,
+				metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 5, threshold: 30}},
+			},
+			inputExtDec: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     1,
+				metrics:   map[string]*scalingMetricDecision{"queue": {value: 15, threshold: 20}},
+			},
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     1,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu": {value: 5, threshold: 30},
+					"queue":     {value: 15, threshold: 20},
+				},
+			},
+			name: "different scale in steps use the smaller count",
+		},
+		{
+			inputNomadDec: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     1,
+				metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 85, threshold: 80}},
+			},
+			inputExtDec: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     5,
+				metrics:   map[string]*scalingMetricDecision{"queue": {value: 300, threshold: 100}},
+			},
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     5,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu": {value: 85, threshold: 80},
+					"queue":     {value: 300, threshold: 100},
+				},
+			},
+			name: "different scale out steps use the larger count",
+		},
+		{
+			inputNomadDec: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     6,
+				absolute:  true,
+				metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 30, threshold: 50}},
+			},
+			inputExtDec: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     4,
+				absolute:  true,
+				metrics:   map[string]*scalingMetricDecision{"queue": {value: 40, threshold: 100}},
+			},
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     6,
+				absolute:  true,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu": {value: 30, threshold: 50},
+					"queue":     {value: 40, threshold: 100},
+				},
+			},
+			name: "absolute scale in uses the larger desired count",
+		},
 	}
 	ae := autoscaleEvaluation{}
 
@@ -0,0 +1,113 @@
+package autoscale
+
+import (
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// applyBreachPeriods records whether the named check broke its threshold during this evaluation
+// within the job group breach history. If the check has not broken its threshold within enough of
+// the most recent evaluations, the returned decision does not request scaling. A nil periods
+// indicates the check is not configured with breach periods, and the decision is returned as is.
+func (ae *autoscaleEvaluation) applyBreachPeriods(group, name string, periods *policy.BreachPeriods, dec *scalingDecision) *scalingDecision {
+	if periods == nil || dec == nil || ae.breach == nil {
+		return dec
+	}
+
+	// If the breach history could not be read, fall back to using the single evaluation rather
+	// than blocking scaling of the group.
+	history := ae.groupBreachHistory(group)
+	if history == nil {
+		return dec
+	}
+
+	points := append(ae.recentBreachDatapoints(history.Checks[name], periods),
+		&state.BreachDatapoint{Time: ae.time, Breaching: dec.direction != scale.DirectionNone})
+
+	if len(points) > periods.EvaluationPeriods {
+		points = points[len(points)-periods.EvaluationPeriods:]
+	}
+	history.Checks[name] = points
+
+	var breaching int
+	for _, point := range points {
+		if point.Breaching {
+			breaching++
+		}
+	}
+
+	if breaching < periods.Datapoints && dec.direction != scale.DirectionNone {
+		ae.log.Info().
+			Str("group", group).
+			Str("check", name).
+			Int("breaching", breaching).
+			Int("datapoints", periods.Datapoints).
+			Int("evaluation-periods", periods.EvaluationPeriods).
+			Msg("check has not breached its threshold for enough evaluations, ignoring result")
+		dec = &scalingDecision{direction: scale.DirectionNone, metrics: make(map[string]*scalingMetricDecision)}
+	}
+
+	ae.explain.breaches(group, name, breaching, periods, dec)
+	return dec
+}
+
+// recentBreachDatapoints filters the datapoints to those recorded within the evaluation periods.
+// Datapoints are not recorded while the group is not evaluated, such as during cooldown, so
+// filtering by time ensures stale datapoints are not used.
+func (ae *autoscaleEvaluation) recentBreachDatapoints(points []*state.BreachDatapoint, periods *policy.BreachPeriods) []*state.BreachDatapoint {
+	if ae.interval < 1 {
+		return points
+	}
+
+	cutoff := ae.time - int64(periods.EvaluationPeriods+1)*int64(ae.interval)*int64(time.Second)
+
+	recent := make([]*state.BreachDatapoint, 0, len(points)+1)
+	for _, point := range points {
+		if point.Time > cutoff {
+			recent = append(recent, point)
+		}
+	}
+	return recent
+}
+
+// groupBreachHistory returns the breach history of the job group, reading it from the backend on
+// first use during the evaluation. A nil return indicates the history could not be read.
+func (ae *autoscaleEvaluation) groupBreachHistory(group string) *state.BreachHistory {
+	if history, ok := ae.breaches[group]; ok {
+		return history
+	}
+
+	history, err := ae.breach.GetBreachHistory(ae.jobID, group)
+	if err != nil {
+		ae.log.Error().Err(err).Str("group", group).Msg("failed to read job group breach history")
+		ae.breaches[group] = nil
+		return nil
+	}
+
+	if history == nil {
+		history = &state.BreachHistory{}
+	}
+	if history.Checks == nil {
+		history.Checks = make(map[string][]*state.BreachDatapoint)
+	}
+	ae.breaches[group] = history
+	return history
+}
+
+// saveBreachHistory writes the breach history of each job group updated during the evaluation to
+// the backend, so that it is available to subsequent evaluations and survives leadership changes.
+func (ae *autoscaleEvaluation) saveBreachHistory() {
+	for group, history := range ae.breaches {
+		if history == nil {
+			continue
+		}
+		history.Updated = ae.time
+
+		if err := ae.breach.PutBreachHistory(ae.jobID, group, history); err != nil {
+			ae.log.Error().Err(err).Str("group", group).Msg("failed to write job group breach history")
+		}
+	}
+}
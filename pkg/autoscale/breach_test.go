@@ -0,0 +1,77 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/breach/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_autoscaleEvaluation_applyBreachPeriods(t *testing.T) {
+	backend := memory.NewStateBackend()
+	periods := &policy.BreachPeriods{Datapoints: 2, EvaluationPeriods: 3}
+	start := time.Date(2019, 11, 4, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		inputDirection    scale.Direction
+		inputTime         time.Time
+		expectedDirection scale.Direction
+		name              string
+	}{
+		{
+			inputDirection:    scale.DirectionOut,
+			inputTime:         start,
+			expectedDirection: scale.DirectionNone,
+			name:              "single breach ignored",
+		},
+		{
+			inputDirection:    scale.DirectionNone,
+			inputTime:         start.Add(60 * time.Second),
+			expectedDirection: scale.DirectionNone,
+			name:              "no breach",
+		},
+		{
+			inputDirection:    scale.DirectionOut,
+			inputTime:         start.Add(120 * time.Second),
+			expectedDirection: scale.DirectionOut,
+			name:              "two of the last three evaluations breached",
+		},
+		{
+			inputDirection:    scale.DirectionOut,
+			inputTime:         start.Add(600 * time.Second),
+			expectedDirection: scale.DirectionNone,
+			name:              "stale datapoints ignored",
+		},
+	}
+
+	for _, tc := range testCases {
+		ae := autoscaleEvaluation{
+			log:      zerolog.Nop(),
+			jobID:    "example",
+			time:     tc.inputTime.UnixNano(),
+			interval: 60,
+			breach:   backend,
+			breaches: make(map[string]*state.BreachHistory),
+		}
+
+		dec := &scalingDecision{direction: tc.inputDirection, metrics: make(map[string]*scalingMetricDecision)}
+		actual := ae.applyBreachPeriods("cache", "queue", periods, dec)
+		assert.Equal(t, tc.expectedDirection, actual.direction, tc.name)
+
+		ae.saveBreachHistory()
+	}
+
+	history, err := backend.GetBreachHistory("example", "cache")
+	assert.Nil(t, err)
+	assert.Len(t, history.Checks["queue"], 1)
+
+	// A check without breach periods should always use the single evaluation result.
+	ae := autoscaleEvaluation{log: zerolog.Nop(), breach: backend, breaches: make(map[string]*state.BreachHistory)}
+	dec := &scalingDecision{direction: scale.DirectionIn, metrics: make(map[string]*scalingMetricDecision)}
+	assert.Equal(t, dec, ae.applyBreachPeriods("cache", "idle", nil, dec))
+}
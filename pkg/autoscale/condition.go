@@ -0,0 +1,63 @@
+package autoscale
+
+import (
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+)
+
+// calculateConditionalDecision is used to figure out the scaling decision for a group with check
+// conditions configured. The Nomad and external threshold checks are combined, so that the
+// conditions are evaluated against all the checks which requested each scaling direction.
+func (ae *autoscaleEvaluation) calculateConditionalDecision(group string, pol *policy.GroupScalingPolicy, resources *nomadGatheredMetrics) *scalingDecision {
+	decisions := make(map[scale.Direction]*scalingDecision)
+
+	if pol.NomadChecksEnabled() && resources != nil {
+		if use := ae.groupNomadResources(group, resources); use != nil {
+			ae.performNomadThresholdChecks(group, use, pol, decisions)
+		}
+	}
+	ae.performExternalThresholdChecks(group, pol, decisions)
+
+	ae.applyCheckConditions(group, pol, decisions)
+	return ae.choseCorrectDecision(group, decisions)
+}
+
+// applyCheckConditions removes the scaling directions from the decisions map where the policy
+// check condition for the direction has not been met.
+func (ae *autoscaleEvaluation) applyCheckConditions(group string, pol *policy.GroupScalingPolicy, decisions map[scale.Direction]*scalingDecision) {
+	conditions := []struct {
+		direction scale.Direction
+		action    policy.ComparisonAction
+		condition *policy.CheckCondition
+	}{
+		{direction: scale.DirectionOut, action: policy.ActionScaleOut, condition: pol.ScaleOutCondition},
+		{direction: scale.DirectionIn, action: policy.ActionScaleIn, condition: pol.ScaleInCondition},
+	}
+
+	for _, c := range conditions {
+		if c.condition == nil {
+			continue
+		}
+
+		dec, ok := decisions[c.direction]
+		met := ok && c.condition.Met(breachedChecks(dec))
+		ae.explain.condition(group, c.action, met)
+
+		if ok && !met {
+			ae.log.Info().
+				Str("group", group).
+				Str("action", c.action.String()).
+				Msg("check condition not met, ignoring checks requesting scaling action")
+			delete(decisions, c.direction)
+		}
+	}
+}
+
+// breachedChecks returns the names of the checks which requested the scaling decision.
+func breachedChecks(dec *scalingDecision) map[string]struct{} {
+	out := make(map[string]struct{}, len(dec.metrics))
+	for name := range dec.metrics {
+		out[name] = struct{}{}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package autoscale
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_autoscaleEvaluation_applyCheckConditions(t *testing.T) {
+	testCases := []struct {
+		inputPolicy       *policy.GroupScalingPolicy
+		inputDecisions    map[scale.Direction][]string
+		expectedDirection []scale.Direction
+		name              string
+	}{
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				ScaleOutCondition: policy.NewExpressionCondition("nomad-cpu AND queue"),
+			},
+			inputDecisions: map[scale.Direction][]string{
+				scale.DirectionOut: {"nomad-cpu", "queue"},
+			},
+			expectedDirection: []scale.Direction{scale.DirectionOut},
+			name:              "scale out expression met",
+		},
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				ScaleOutCondition: policy.NewExpressionCondition("nomad-cpu AND queue"),
+			},
+			inputDecisions: map[scale.Direction][]string{
+				scale.DirectionOut: {"nomad-cpu"},
+				scale.DirectionIn:  {"idle"},
+			},
+			expectedDirection: []scale.Direction{scale.DirectionIn},
+			name:              "scale out expression not met leaves scale in without condition",
+		},
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				ScaleInCondition: &policy.CheckCondition{Quorum: 2},
+			},
+			inputDecisions: map[scale.Direction][]string{
+				scale.DirectionIn: {"nomad-cpu"},
+			},
+			expectedDirection: []scale.Direction{},
+			name:              "scale in quorum not met",
+		},
+	}
+
+	for _, tc := range testCases {
+		ae := autoscaleEvaluation{log: zerolog.Nop()}
+
+		decisions := make(map[scale.Direction]*scalingDecision)
+		for direction, names := range tc.inputDecisions {
+			decisions[direction] = &scalingDecision{direction: direction, metrics: make(map[string]*scalingMetricDecision)}
+			for _, name := range names {
+				decisions[direction].metrics[name] = &scalingMetricDecision{}
+			}
+		}
+
+		ae.applyCheckConditions("cache", tc.inputPolicy, decisions)
+
+		actual := []scale.Direction{}
+		for direction := range decisions {
+			actual = append(actual, direction)
+		}
+		assert.ElementsMatch(t, tc.expectedDirection, actual, tc.name)
+	}
+}
@@ -1,10 +1,13 @@
 package autoscale
 
 import (
-	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/autoscale/predictive"
+	"github.com/jrasell/sherpa/pkg/client"
 	"github.com/jrasell/sherpa/pkg/config/server"
 	policyBackend "github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state/breach"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
 	"github.com/rs/zerolog"
 )
 
@@ -12,17 +15,32 @@ type SetupConfig struct {
 	ScalingInterval   int
 	ScalingThreads    int
 	StrictChecking    bool
+	DryRun            bool
 	MetricProviderCfg *server.MetricProviderConfig
 
 	Logger        zerolog.Logger
 	PolicyBackend policyBackend.PolicyBackend
 	Scale         scale.Scale
-	Nomad         *api.Client
+	Nomad         *client.NomadTargets
+
+	// Predictor is used to record job group baselines and make predictive scaling decisions. If
+	// this is nil, predictive scaling is disabled.
+	Predictor *predictive.Predictor
+
+	// History stores the evaluation history of each job group. If this is nil, evaluation
+	// history is not recorded.
+	History evaluation.Backend
+
+	// Breach stores the recent threshold check results of each job group, used by checks
+	// configured with breach periods. If this is nil, checks request scaling based on a single
+	// evaluation.
+	Breach breach.Backend
 }
 
 type Config struct {
 	ScalingInterval   int
 	ScalingThreads    int
 	StrictChecking    bool
+	DryRun            bool
 	MetricProviderCfg *server.MetricProviderConfig
 }
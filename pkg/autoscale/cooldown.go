@@ -0,0 +1,49 @@
+package autoscale
+
+import (
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+)
+
+// applyActionCooldowns removes the decisions of job groups whose cooldown for the decided scaling
+// action is still active. Groups are evaluated once their shortest action cooldown has passed, so
+// this is only required for groups configured with separate action cooldowns.
+func (ae *autoscaleEvaluation) applyActionCooldowns(decisions map[string]*scalingDecision) {
+	for group, dec := range decisions {
+		pol := ae.policies[group]
+		if !pol.ActionCooldownsEnabled() {
+			continue
+		}
+
+		action := directionAction(dec.direction)
+		cooldown := pol.ActionCooldown(action)
+
+		if cooldown <= pol.MinCooldown() {
+			continue
+		}
+
+		cool, err := ae.scaler.JobGroupIsInCooldown(ae.jobID, group, cooldown, ae.time)
+		if err != nil {
+			ae.log.Error().Err(err).Str("group", group).Msg("failed to determine if job group is in action cooldown")
+		}
+
+		// If the cooldown could not be determined, the decision is removed to ensure the group is
+		// not scaled before its cooldown has passed.
+		if cool || err != nil {
+			ae.log.Debug().
+				Str("group", group).
+				Str("action", action.String()).
+				Msg("job group is currently in action cooldown, skipping scaling decision")
+			ae.explain.actionCooldown(group, action)
+			delete(decisions, group)
+		}
+	}
+}
+
+// directionAction converts the scaling direction to the policy action which requests it.
+func directionAction(direction scale.Direction) policy.ComparisonAction {
+	if direction == scale.DirectionIn {
+		return policy.ActionScaleIn
+	}
+	return policy.ActionScaleOut
+}
@@ -0,0 +1,54 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+	stateMemory "github.com/jrasell/sherpa/pkg/state/scale/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_autoscaleEvaluation_applyActionCooldowns(t *testing.T) {
+	now := time.Now().UTC()
+
+	stateBackend := stateMemory.NewStateBackend()
+	for _, group := range []string{"cache", "web", "worker"} {
+		assert.Nil(t, stateBackend.PutScalingEvent("example", &state.ScalingEventMessage{
+			GroupName: group,
+			Status:    state.StatusCompleted,
+			Time:      now.Add(-5 * time.Minute).UnixNano(),
+			Direction: "out",
+		}))
+	}
+
+	ae := autoscaleEvaluation{
+		log:    zerolog.Nop(),
+		jobID:  "example",
+		time:   now.UnixNano(),
+		scaler: scale.NewScaler(nil, zerolog.Nop(), stateBackend, false, nil, nil, nil),
+		policies: map[string]*policy.GroupScalingPolicy{
+			"cache":  {Cooldown: 60, ScaleInCooldown: 600},
+			"web":    {Cooldown: 60, ScaleInCooldown: 600},
+			"worker": {Cooldown: 600},
+		},
+		explain: newExplanation("example", now),
+	}
+
+	decisions := map[string]*scalingDecision{
+		"cache":  {direction: scale.DirectionIn},
+		"web":    {direction: scale.DirectionOut},
+		"worker": {direction: scale.DirectionIn},
+	}
+	ae.applyActionCooldowns(decisions)
+
+	// The cache group is within its scale in cooldown, whereas the web group scale out cooldown
+	// has passed. The worker group does not have action cooldowns, so is not checked again.
+	assert.NotContains(t, decisions, "cache")
+	assert.Contains(t, decisions, "web")
+	assert.Contains(t, decisions, "worker")
+	assert.Equal(t, "scale-in", ae.explain.Groups["cache"].ActionCooldown)
+}
@@ -1,8 +1,12 @@
 package autoscale
 
 import (
+	"math"
+
+	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
 
@@ -10,6 +14,15 @@ type scalingDecision struct {
 	direction scale.Direction
 	count     int
 	metrics   map[string]*scalingMetricDecision
+
+	// absolute indicates the count is the desired job group count, rather than the number to
+	// change the count by. This is set by target tracking decisions.
+	absolute bool
+
+	// percentage indicates the count is a percentage of the current job group count, rather than
+	// the number to change the count by. This is set when the policy uses the percent adjustment
+	// type.
+	percentage bool
 }
 
 // scalingMetricDecision describes the metric value and threshold which resulted in the decision to
@@ -20,9 +33,19 @@ type scalingMetricDecision struct {
 	threshold float64
 }
 
+// breachPercentage returns the percentage by which the metric value broke its threshold. A zero
+// threshold is always considered to be broken by the largest amount.
+func (smd *scalingMetricDecision) breachPercentage() float64 {
+	if smd.threshold == 0 {
+		return math.Inf(1)
+	}
+	return math.Abs(smd.value-smd.threshold) / math.Abs(smd.threshold) * 100
+}
+
 // MarshalZerologObject is used to marshal a scaling decision for logging with zerolog.
 func (sd *scalingDecision) MarshalZerologObject(e *zerolog.Event) {
-	e.Str("direction", sd.direction.String()).Int("count", sd.count)
+	e.Str("direction", sd.direction.String()).Int("count", sd.count).Bool("absolute", sd.absolute).
+		Bool("percentage", sd.percentage)
 
 	dict := zerolog.Dict()
 
@@ -38,11 +61,22 @@ func (sd *scalingDecision) MarshalZerologObject(e *zerolog.Event) {
 // configured Nomad metric checks.
 func (ae *autoscaleEvaluation) calculateNomadScalingDecision(group string, use *nomadResources, pol *policy.GroupScalingPolicy) *scalingDecision {
 	decisions := make(map[scale.Direction]*scalingDecision)
+	ae.performNomadThresholdChecks(group, use, pol, decisions)
+	return ae.choseCorrectDecision(group, decisions)
+}
+
+// performNomadThresholdChecks runs the configured Nomad resource threshold checks, adding the
+// results to the decisions map.
+func (ae *autoscaleEvaluation) performNomadThresholdChecks(group string, use *nomadResources, pol *policy.GroupScalingPolicy, decisions map[scale.Direction]*scalingDecision) {
 
 	// If the policy has a CPU scale out threshold, run this check.
 	if pol.ScaleOutCPUPercentageThreshold != nil {
 		cpuOutDec := performGreaterThanCheck(use.cpu, *pol.ScaleOutCPUPercentageThreshold,
 			nomadCPUMetricName, policy.ActionScaleOut)
+		ae.explain.thresholdCheck(group, nomadCheckName(nomadCPUMetricName, policy.ActionScaleOut), CheckTypeNomad,
+			use.cpu, *pol.ScaleOutCPUPercentageThreshold, policy.ComparisonGreaterThan,
+			policy.ActionScaleOut, cpuOutDec)
+		cpuOutDec = ae.applyBreachPeriods(group, nomadCheckName(nomadCPUMetricName, policy.ActionScaleOut), pol.NomadBreachPeriods, cpuOutDec)
 		updateDecisionMap(cpuOutDec, nomadCPUMetricName, decisions)
 	}
 
@@ -50,6 +84,10 @@ func (ae *autoscaleEvaluation) calculateNomadScalingDecision(group string, use *
 	if pol.ScaleInCPUPercentageThreshold != nil {
 		cpuInDec := performLessThanCheck(use.cpu, *pol.ScaleInCPUPercentageThreshold,
 			nomadCPUMetricName, policy.ActionScaleIn)
+		ae.explain.thresholdCheck(group, nomadCheckName(nomadCPUMetricName, policy.ActionScaleIn), CheckTypeNomad,
+			use.cpu, *pol.ScaleInCPUPercentageThreshold, policy.ComparisonLessThan,
+			policy.ActionScaleIn, cpuInDec)
+		cpuInDec = ae.applyBreachPeriods(group, nomadCheckName(nomadCPUMetricName, policy.ActionScaleIn), pol.NomadBreachPeriods, cpuInDec)
 		updateDecisionMap(cpuInDec, nomadCPUMetricName, decisions)
 	}
 
@@ -57,6 +95,10 @@ func (ae *autoscaleEvaluation) calculateNomadScalingDecision(group string, use *
 	if pol.ScaleOutMemoryPercentageThreshold != nil {
 		memOutDec := performGreaterThanCheck(use.mem, *pol.ScaleOutMemoryPercentageThreshold,
 			nomadMemoryMetricName, policy.ActionScaleOut)
+		ae.explain.thresholdCheck(group, nomadCheckName(nomadMemoryMetricName, policy.ActionScaleOut), CheckTypeNomad,
+			use.mem, *pol.ScaleOutMemoryPercentageThreshold, policy.ComparisonGreaterThan,
+			policy.ActionScaleOut, memOutDec)
+		memOutDec = ae.applyBreachPeriods(group, nomadCheckName(nomadMemoryMetricName, policy.ActionScaleOut), pol.NomadBreachPeriods, memOutDec)
 		updateDecisionMap(memOutDec, nomadMemoryMetricName, decisions)
 	}
 
@@ -64,16 +106,52 @@ func (ae *autoscaleEvaluation) calculateNomadScalingDecision(group string, use *
 	if pol.ScaleInMemoryPercentageThreshold != nil {
 		memInDec := performLessThanCheck(use.mem, *pol.ScaleInMemoryPercentageThreshold,
 			nomadMemoryMetricName, policy.ActionScaleIn)
+		ae.explain.thresholdCheck(group, nomadCheckName(nomadMemoryMetricName, policy.ActionScaleIn), CheckTypeNomad,
+			use.mem, *pol.ScaleInMemoryPercentageThreshold, policy.ComparisonLessThan,
+			policy.ActionScaleIn, memInDec)
+		memInDec = ae.applyBreachPeriods(group, nomadCheckName(nomadMemoryMetricName, policy.ActionScaleIn), pol.NomadBreachPeriods, memInDec)
 		updateDecisionMap(memInDec, nomadMemoryMetricName, decisions)
 	}
+}
 
-	return ae.choseCorrectDecision(group, decisions)
+// calculateTargetDecision is used to figure out the desired count of the group based on all the
+// configured Nomad resource and external check targets. The targets of both sources are used
+// within a single calculation, so that one source cannot scale the group in while the other
+// requires the group to remain at its current count.
+func (ae *autoscaleEvaluation) calculateTargetDecision(group string, pol *policy.GroupScalingPolicy, resources *nomadGatheredMetrics) *scalingDecision {
+	targets := make(map[string]*scalingMetricDecision)
+
+	if pol.NomadChecksEnabled() && resources != nil {
+		if use := ae.groupNomadResources(group, resources); use != nil {
+			addNomadTargets(use, pol, targets)
+		}
+	}
+	ae.addExternalTargets(group, pol, targets)
+
+	return ae.calculateTargetTrackingDecision(group, pol, targets)
+}
+
+// addNomadTargets adds the configured Nomad resource targets to the targets map.
+func addNomadTargets(use *nomadResources, pol *policy.GroupScalingPolicy, targets map[string]*scalingMetricDecision) {
+	if pol.TargetCPUPercentage != nil {
+		targets[nomadCPUMetricName] = &scalingMetricDecision{value: use.cpu, threshold: *pol.TargetCPUPercentage}
+	}
+	if pol.TargetMemoryPercentage != nil {
+		targets[nomadMemoryMetricName] = &scalingMetricDecision{value: use.mem, threshold: *pol.TargetMemoryPercentage}
+	}
 }
 
 // calculateExternalScalingDecision is used to perform the scaling decision for the group based on
 // configured external metric checks.
 func (ae *autoscaleEvaluation) calculateExternalScalingDecision(group string, pol *policy.GroupScalingPolicy) *scalingDecision {
 	decisions := make(map[scale.Direction]*scalingDecision)
+	ae.performExternalThresholdChecks(group, pol, decisions)
+	return ae.choseCorrectDecision(group, decisions)
+}
+
+// performExternalThresholdChecks runs the enabled external threshold checks, adding the results
+// to the decisions map.
+func (ae *autoscaleEvaluation) performExternalThresholdChecks(group string, pol *policy.GroupScalingPolicy, decisions map[scale.Direction]*scalingDecision) {
 
 	// Iterate each external check configured within the job group scaling policy.
 	for name, check := range pol.ExternalChecks {
@@ -83,16 +161,115 @@ func (ae *autoscaleEvaluation) calculateExternalScalingDecision(group string, po
 			continue
 		}
 
-		if checkDecision := ae.evaluateExternalMetric(name, check); checkDecision != nil {
+		if checkDecision := ae.evaluateExternalMetric(group, name, check); checkDecision != nil {
 			updateDecisionMap(checkDecision, name, decisions)
 		}
 	}
-	return ae.choseCorrectDecision(group, decisions)
+}
+
+// addExternalTargets queries the enabled external checks which have a target value configured,
+// adding the results to the targets map.
+func (ae *autoscaleEvaluation) addExternalTargets(group string, pol *policy.GroupScalingPolicy, targets map[string]*scalingMetricDecision) {
+	for name, check := range pol.ExternalChecks {
+		if !check.Enabled || check.TargetValue == nil {
+			continue
+		}
+
+		if value := ae.getExternalMetricValue(group, name, check); value != nil {
+			targets[name] = &scalingMetricDecision{value: *value, threshold: *check.TargetValue}
+		}
+	}
+}
+
+// calculateTargetTrackingDecision takes the metric values and their targets, calculating the
+// desired count of the group. When multiple targets are configured, the largest desired count
+// across all of them is used to ensure the group can handle its load. The desired count is bounded by the policy
+// minimum and maximum.
+func (ae *autoscaleEvaluation) calculateTargetTrackingDecision(group string, pol *policy.GroupScalingPolicy, targets map[string]*scalingMetricDecision) *scalingDecision {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	current, ok := ae.groupCounts[group]
+	if !ok {
+		ae.log.Warn().Str("group", group).Msg("job group count not found, skipping target tracking")
+		for name := range targets {
+			ae.explain.checkError(group, name, CheckTypeTarget, "job group count not found")
+		}
+		return nil
+	}
+
+	desired := 0
+	for name, target := range targets {
+		count := calculateTargetCount(current, target.value, target.threshold)
+		if count > desired {
+			desired = count
+		}
+		ae.explain.targetCheck(group, name, target, current, count)
+	}
+
+	if desired < pol.MinCount {
+		desired = pol.MinCount
+	}
+	if desired > pol.MaxCount {
+		desired = pol.MaxCount
+	}
+
+	ae.log.Info().
+		Str("group", group).
+		Int("current-count", current).
+		Int("desired-count", desired).
+		Msg("target tracking desired count calculation")
+
+	dec := scalingDecision{count: desired, absolute: true, metrics: targets}
+
+	switch {
+	case desired > current:
+		dec.direction = scale.DirectionOut
+	case desired < current:
+		dec.direction = scale.DirectionIn
+	default:
+		return nil
+	}
+	return &dec
+}
+
+// calculateTargetCount calculates the group count required to bring the metric value to the
+// target, assuming the value scales linearly with the group count.
+func calculateTargetCount(current int, value, target float64) int {
+	if target <= 0 {
+		return current
+	}
+	return int(math.Ceil(float64(current) * value / target))
 }
 
 // evaluateExternalMetric is used to trigger the evaluation on a named external check. The function
 // handles getting the metric value, and comparing it against the configured policy check params.
-func (ae *autoscaleEvaluation) evaluateExternalMetric(name string, check *policy.ExternalCheck) *scalingDecision {
+func (ae *autoscaleEvaluation) evaluateExternalMetric(group, name string, check *policy.ExternalCheck) *scalingDecision {
+	value := ae.getExternalMetricValue(group, name, check)
+	if value == nil {
+		return nil
+	}
+
+	var dec *scalingDecision
+
+	switch check.ComparisonOperator {
+	case policy.ComparisonGreaterThan:
+		dec = performGreaterThanCheck(*value, check.ComparisonValue, name, check.Action)
+	case policy.ComparisonLessThan:
+		dec = performLessThanCheck(*value, check.ComparisonValue, name, check.Action)
+	default:
+		return nil
+	}
+
+	ae.explain.thresholdCheck(group, name, CheckTypeExternal, *value, check.ComparisonValue,
+		check.ComparisonOperator, check.Action, dec)
+	return ae.applyBreachPeriods(group, name, check.BreachPeriods, dec)
+}
+
+// getExternalMetricValue handles getting the metric value of the named external check, logging
+// any errors encountered. A nil return indicates the value could not be obtained.
+func (ae *autoscaleEvaluation) getExternalMetricValue(group, name string, check *policy.ExternalCheck) *float64 {
 
 	// Check that the provider is available and properly configured for use.
 	if _, ok := ae.metricProvider[check.Provider]; !ok {
@@ -100,17 +277,21 @@ func (ae *autoscaleEvaluation) evaluateExternalMetric(name string, check *policy
 			Str("metric-query", check.Query).
 			Str("metric-provider", check.Provider.String()).
 			Msg("provider not found configured within autoscaler")
+		ae.explain.checkError(group, name, externalCheckType(check),
+			"provider "+check.Provider.String()+" not found configured within autoscaler")
 		return nil
 	}
 
 	// Perform the query to gather the metric value.
-	value, err := ae.metricProvider[check.Provider].GetValue(check.Query)
+	value, err := ae.queryExternalMetric(check)
 	if err != nil {
 		ae.log.Error().
 			Err(err).
 			Str("metric-provider", check.Provider.String()).
 			Str("metric-query", check.Query).
 			Msg("failed to query external provider for metric value")
+		ae.explain.checkError(group, name, externalCheckType(check),
+			"failed to query external provider for metric value: "+err.Error())
 		return nil
 	}
 	ae.log.Info().
@@ -120,14 +301,23 @@ func (ae *autoscaleEvaluation) evaluateExternalMetric(name string, check *policy
 		Float64("metric-value", *value).
 		Msg("successfully queried external provider for metric value")
 
-	switch check.ComparisonOperator {
-	case policy.ComparisonGreaterThan:
-		return performGreaterThanCheck(*value, check.ComparisonValue, name, check.Action)
-	case policy.ComparisonLessThan:
-		return performLessThanCheck(*value, check.ComparisonValue, name, check.Action)
-	default:
-		return nil
+	return value
+}
+
+// queryExternalMetric queries the check provider for the metric value. If the check has been
+// configured with query options, the provider must support these.
+func (ae *autoscaleEvaluation) queryExternalMetric(check *policy.ExternalCheck) (*float64, error) {
+	provider := ae.metricProvider[check.Provider]
+
+	if !check.HasQueryOptions() {
+		return provider.GetValue(check.Query)
 	}
+
+	optsProvider, ok := provider.(metrics.OptionsProvider)
+	if !ok {
+		return nil, errors.Errorf("provider %s does not support query types or reducers", check.Provider.String())
+	}
+	return optsProvider.GetValueWithOptions(check.Query, metrics.NewQueryOptions(check))
 }
 
 // choseCorrectDecision takes a set of decisions made about the scaling direction of the group,
@@ -138,22 +328,37 @@ func (ae *autoscaleEvaluation) choseCorrectDecision(group string, dec map[scale.
 	// Always perform this check first to ensure out takes precedent over in.
 	if dec[scale.DirectionIn] != nil && dec[scale.DirectionOut] != nil {
 		ae.log.Info().Str("group", group).Msg("both scale in and scale out actions desired, using out action")
-		dec[scale.DirectionOut].count = ae.policies[group].ScaleOutCount
-		return dec[scale.DirectionOut]
+		return ae.setDecisionCount(group, dec[scale.DirectionOut], policy.ActionScaleOut)
 	}
 
 	if dec[scale.DirectionOut] != nil {
-		dec[scale.DirectionOut].count = ae.policies[group].ScaleOutCount
-		return dec[scale.DirectionOut]
+		return ae.setDecisionCount(group, dec[scale.DirectionOut], policy.ActionScaleOut)
 	}
 
 	if dec[scale.DirectionIn] != nil {
-		dec[scale.DirectionIn].count = ae.policies[group].ScaleInCount
-		return dec[scale.DirectionIn]
+		return ae.setDecisionCount(group, dec[scale.DirectionIn], policy.ActionScaleIn)
 	}
 	return nil
 }
 
+// setDecisionCount sets the count of the threshold decision using the group policy. If the policy
+// has step adjustments, the step is selected using the metric which broke its threshold by the
+// largest percentage.
+func (ae *autoscaleEvaluation) setDecisionCount(group string, dec *scalingDecision, action policy.ComparisonAction) *scalingDecision {
+	pol := ae.policies[group]
+
+	var breach float64
+	for _, metric := range dec.metrics {
+		if b := metric.breachPercentage(); b > breach {
+			breach = b
+		}
+	}
+
+	dec.count = pol.ActionCount(action, breach)
+	dec.percentage = pol.PercentageAdjustment()
+	return dec
+}
+
 // updateDecisionMap is used to safely update a decision mapping based on the new decision.
 func updateDecisionMap(new *scalingDecision, name string, cur map[scale.Direction]*scalingDecision) {
 	if _, ok := cur[new.direction]; !ok {
@@ -3,9 +3,11 @@ package autoscale
 import (
 	"testing"
 
+	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
 	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,6 +50,196 @@ func Test_autoscaleEvaluation_calculateNomadScalingDecision(t *testing.T) {
 	}
 }
 
+func Test_autoscaleEvaluation_calculateNomadTargetDecision(t *testing.T) {
+	testCases := []struct {
+		inputPolicy    *policy.GroupScalingPolicy
+		inputResource  *nomadResources
+		inputCount     int
+		expectedOutput *scalingDecision
+		name           string
+	}{
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				MinCount:            1,
+				MaxCount:            20,
+				ScalingMode:         policy.ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(50),
+			},
+			inputResource: &nomadResources{cpu: 150},
+			inputCount:    3,
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     9,
+				absolute:  true,
+				metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 150, threshold: 50}},
+			},
+			name: "CPU target scale out by ratio",
+		},
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				MinCount:               1,
+				MaxCount:               20,
+				ScalingMode:            policy.ScalingModeTargetTracking,
+				TargetCPUPercentage:    helper.Float64ToPointer(50),
+				TargetMemoryPercentage: helper.Float64ToPointer(50),
+			},
+			inputResource: &nomadResources{cpu: 10, mem: 30},
+			inputCount:    10,
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     6,
+				absolute:  true,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu":    {value: 10, threshold: 50},
+					"nomad-memory": {value: 30, threshold: 50},
+				},
+			},
+			name: "largest desired count used for scale in",
+		},
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				MinCount:            1,
+				MaxCount:            5,
+				ScalingMode:         policy.ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(50),
+			},
+			inputResource: &nomadResources{cpu: 300},
+			inputCount:    2,
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     5,
+				absolute:  true,
+				metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 300, threshold: 50}},
+			},
+			name: "desired count clamped to max",
+		},
+		{
+			inputPolicy: &policy.GroupScalingPolicy{
+				MinCount:            2,
+				MaxCount:            5,
+				ScalingMode:         policy.ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(50),
+			},
+			inputResource:  &nomadResources{cpu: 1},
+			inputCount:     2,
+			expectedOutput: nil,
+			name:           "desired count clamped to min equals current",
+		},
+	}
+
+	for _, tc := range testCases {
+		ae := autoscaleEvaluation{
+			policies:    map[string]*policy.GroupScalingPolicy{"test-group": tc.inputPolicy},
+			groupCounts: map[string]int{"test-group": tc.inputCount},
+		}
+		actualOutput := ae.calculateTargetDecision("test-group", tc.inputPolicy, testGatheredMetrics("test-group", tc.inputResource))
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func Test_autoscaleEvaluation_calculateTargetDecision(t *testing.T) {
+	testCases := []struct {
+		inputResource  *nomadResources
+		inputValue     float64
+		expectedOutput *scalingDecision
+		name           string
+	}{
+		{
+			inputResource:  &nomadResources{cpu: 50},
+			inputValue:     60,
+			expectedOutput: nil,
+			name:           "Nomad target holds while external target wants to scale in",
+		},
+		{
+			inputResource: &nomadResources{cpu: 30},
+			inputValue:    100,
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionIn,
+				count:     4,
+				absolute:  true,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu": {value: 30, threshold: 50},
+					"queue":     {value: 100, threshold: 125},
+				},
+			},
+			name: "both targets scale in to the largest desired count",
+		},
+		{
+			inputResource: &nomadResources{cpu: 20},
+			inputValue:    200,
+			expectedOutput: &scalingDecision{
+				direction: scale.DirectionOut,
+				count:     8,
+				absolute:  true,
+				metrics: map[string]*scalingMetricDecision{
+					"nomad-cpu": {value: 20, threshold: 50},
+					"queue":     {value: 200, threshold: 125},
+				},
+			},
+			name: "external target scale out wins over Nomad target scale in",
+		},
+	}
+
+	for _, tc := range testCases {
+		pol := &policy.GroupScalingPolicy{
+			MinCount:            1,
+			MaxCount:            20,
+			ScalingMode:         policy.ScalingModeTargetTracking,
+			TargetCPUPercentage: helper.Float64ToPointer(50),
+			ExternalChecks: map[string]*policy.ExternalCheck{
+				"queue": {Enabled: true, Provider: policy.ProviderPrometheus, Query: "queue", TargetValue: helper.Float64ToPointer(125)},
+			},
+		}
+
+		ae := autoscaleEvaluation{
+			policies:       map[string]*policy.GroupScalingPolicy{"test-group": pol},
+			groupCounts:    map[string]int{"test-group": 5},
+			metricProvider: map[policy.MetricsProvider]metrics.Provider{policy.ProviderPrometheus: fakeMetricProvider{"queue": tc.inputValue}},
+		}
+		actualOutput := ae.calculateTargetDecision("test-group", pol, testGatheredMetrics("test-group", tc.inputResource))
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+// fakeMetricProvider is an external metrics provider which returns fixed values for each query.
+type fakeMetricProvider map[string]float64
+
+func (f fakeMetricProvider) GetValue(query string) (*float64, error) {
+	value, ok := f[query]
+	if !ok {
+		return nil, errors.Errorf("query %s not found", query)
+	}
+	return &value, nil
+}
+
+// testGatheredMetrics returns gathered Nomad metrics which result in the group utilisation
+// percentages of the passed resources.
+func testGatheredMetrics(group string, use *nomadResources) *nomadGatheredMetrics {
+	return &nomadGatheredMetrics{
+		resourceInfo:  map[string]*nomadResources{group: {cpu: 100, mem: 100}},
+		resourceUsage: map[string]*nomadResources{group: use},
+	}
+}
+
+func Test_calculateTargetCount(t *testing.T) {
+	testCases := []struct {
+		inputCurrent   int
+		inputValue     float64
+		inputTarget    float64
+		expectedOutput int
+	}{
+		{inputCurrent: 4, inputValue: 75, inputTarget: 50, expectedOutput: 6},
+		{inputCurrent: 4, inputValue: 51, inputTarget: 50, expectedOutput: 5},
+		{inputCurrent: 4, inputValue: 50, inputTarget: 50, expectedOutput: 4},
+		{inputCurrent: 4, inputValue: 0, inputTarget: 50, expectedOutput: 0},
+		{inputCurrent: 4, inputValue: 75, inputTarget: 0, expectedOutput: 4},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, calculateTargetCount(tc.inputCurrent, tc.inputValue, tc.inputTarget))
+	}
+}
+
 func Test_autoscaleEvaluation_choseCorrectDecision(t *testing.T) {
 	testCases := []struct {
 		inputGroup     string
@@ -118,6 +310,53 @@ func Test_autoscaleEvaluation_choseCorrectDecision(t *testing.T) {
 	}
 }
 
+func Test_autoscaleEvaluation_setDecisionCount(t *testing.T) {
+	ae := autoscaleEvaluation{
+		policies: map[string]*policy.GroupScalingPolicy{
+			"test-group": {
+				ScaleOutCount:  10,
+				AdjustmentType: policy.AdjustmentTypePercent,
+				ScaleOutSteps: []*policy.StepAdjustment{
+					{LowerBound: 10, Count: 25},
+					{LowerBound: 50, Count: 50},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		inputMetrics  map[string]*scalingMetricDecision
+		expectedCount int
+		name          string
+	}{
+		{
+			inputMetrics:  map[string]*scalingMetricDecision{"nomad-cpu": {value: 84, threshold: 80}},
+			expectedCount: 10,
+			name:          "below all steps",
+		},
+		{
+			inputMetrics: map[string]*scalingMetricDecision{
+				"nomad-cpu": {value: 84, threshold: 80},
+				"queue":     {value: 130, threshold: 100},
+			},
+			expectedCount: 25,
+			name:          "largest breach selects step",
+		},
+		{
+			inputMetrics:  map[string]*scalingMetricDecision{"queue": {value: 200, threshold: 100}},
+			expectedCount: 50,
+			name:          "largest step",
+		},
+	}
+
+	for _, tc := range testCases {
+		dec := &scalingDecision{direction: scale.DirectionOut, metrics: tc.inputMetrics}
+		actual := ae.setDecisionCount("test-group", dec, policy.ActionScaleOut)
+		assert.Equal(t, tc.expectedCount, actual.count, tc.name)
+		assert.True(t, actual.percentage, tc.name)
+	}
+}
+
 func Test_updateDecisionMap(t *testing.T) {
 	testCases := []struct {
 		inputNew       *scalingDecision
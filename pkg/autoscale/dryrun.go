@@ -0,0 +1,38 @@
+package autoscale
+
+import (
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// triggerDryRun is used to record the scaling of a job which would have taken place as a result
+// of the scaling evaluation, if the job groups were not in dry-run mode.
+func (ae *autoscaleEvaluation) triggerDryRun(req []*scale.GroupReq) {
+	resp, _, err := ae.scaler.DryRun(ae.jobID, req, state.SourceInternalAutoscaler)
+	if err != nil {
+		ae.log.Error().Err(err).Msg("failed to record dry-run scaling of job")
+		return
+	}
+
+	if resp != nil {
+		ae.log.Info().
+			Str("id", resp.ID.String()).
+			Msg("successfully recorded dry-run autoscaling of job")
+		sendDryRunMetrics(ae.jobID)
+	}
+}
+
+// splitDryRunReqs splits the group scaling requests into those which should be triggered, and
+// those which should only be recorded as the group policy, or the autoscaler, is in dry-run mode.
+func splitDryRunReqs(reqs []*scale.GroupReq, dryRun bool) ([]*scale.GroupReq, []*scale.GroupReq) {
+	var live, dry []*scale.GroupReq // nolint:prealloc
+
+	for i := range reqs {
+		if dryRun || (reqs[i].GroupScalingPolicy != nil && reqs[i].GroupScalingPolicy.DryRun) {
+			dry = append(dry, reqs[i])
+			continue
+		}
+		live = append(live, reqs[i])
+	}
+	return live, dry
+}
@@ -0,0 +1,23 @@
+package autoscale
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitDryRunReqs(t *testing.T) {
+	live := &scale.GroupReq{GroupName: "web", GroupScalingPolicy: &policy.GroupScalingPolicy{}}
+	dry := &scale.GroupReq{GroupName: "cache", GroupScalingPolicy: &policy.GroupScalingPolicy{DryRun: true}}
+	reqs := []*scale.GroupReq{live, dry}
+
+	actualLive, actualDry := splitDryRunReqs(reqs, false)
+	assert.Equal(t, []*scale.GroupReq{live}, actualLive)
+	assert.Equal(t, []*scale.GroupReq{dry}, actualDry)
+
+	actualLive, actualDry = splitDryRunReqs(reqs, true)
+	assert.Nil(t, actualLive)
+	assert.Equal(t, reqs, actualDry)
+}
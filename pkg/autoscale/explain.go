@@ -0,0 +1,383 @@
+package autoscale
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// Explanation details the checks performed and decisions made during an autoscaling evaluation of
+// a job. It is produced by evaluating the job without triggering any scaling, allowing operators
+// to understand why job groups are, or are not, scaling.
+type Explanation struct {
+	Job string
+
+	// Time is a UnixNano timestamp declaring when the evaluation took place.
+	Time int64
+
+	// Groups holds the explanation for each job group with a scaling policy, keyed by group name.
+	Groups map[string]*GroupExplanation
+}
+
+// GroupExplanation details the evaluation of a single job group.
+type GroupExplanation struct {
+
+	// Evaluated indicates whether the group passed the initial checks and was evaluated by the
+	// autoscaler.
+	Evaluated bool
+
+	// SkipReason details why the group was not evaluated.
+	SkipReason string `json:",omitempty"`
+
+	// Checks holds the result of each check performed on the group, keyed by check name.
+	Checks map[string]*CheckExplanation `json:",omitempty"`
+
+	// Conditions holds whether the configured check condition of each scaling action was met,
+	// keyed by action.
+	Conditions map[string]bool `json:",omitempty"`
+
+	// ActionCooldown is the scaling action decided upon which was not taken, as the cooldown of
+	// the action is still active.
+	ActionCooldown string `json:",omitempty"`
+
+	// Decision is the final scaling decision for the group. This is nil if no scaling is
+	// required.
+	Decision *DecisionExplanation `json:",omitempty"`
+}
+
+// CheckExplanation details the result of a single check performed on a job group.
+type CheckExplanation struct {
+
+	// Type is the type of check which was performed.
+	Type CheckType
+
+	// Value is the metric value the check obtained. This is nil if the value could not be
+	// obtained.
+	Value *float64 `json:",omitempty"`
+
+	// Threshold is the value which the metric value was compared against. For target tracking
+	// checks, this is the target value.
+	Threshold *float64 `json:",omitempty"`
+
+	ComparisonOperator string `json:",omitempty"`
+	Action             string `json:",omitempty"`
+
+	// Result is the scaling direction requested by the check.
+	Result string
+
+	// Error details any error which prevented the check from being performed.
+	Error string `json:",omitempty"`
+
+	// Breaches details the recent evaluations of the check. This is nil unless the check is
+	// configured with breach periods.
+	Breaches *BreachExplanation `json:",omitempty"`
+}
+
+// BreachExplanation details the recent evaluations of a check configured with breach periods.
+type BreachExplanation struct {
+
+	// Breaching is the number of evaluations within the evaluation periods, including this one,
+	// in which the check broke its threshold.
+	Breaching int
+
+	Datapoints        int
+	EvaluationPeriods int
+}
+
+// DecisionExplanation details the final scaling decision for a job group.
+type DecisionExplanation struct {
+	Direction string
+	Count     int
+
+	// Absolute indicates the count is the desired job group count, rather than the number to
+	// change the count by.
+	Absolute bool
+
+	// Percentage indicates the count is a percentage of the current job group count, rather than
+	// the number to change the count by.
+	Percentage bool `json:",omitempty"`
+
+	// DryRun indicates the decision would be recorded as a dry-run event, rather than acted upon.
+	DryRun bool
+
+	// Source is the source which would trigger the scaling event.
+	Source state.Source
+
+	Meta map[string]string `json:",omitempty"`
+}
+
+// CheckType identifies the type of check performed on a job group.
+type CheckType string
+
+const (
+	// CheckTypeNomad is a threshold check using Nomad resource metrics.
+	CheckTypeNomad CheckType = "nomad"
+
+	// CheckTypeExternal is a threshold check using an external metrics provider.
+	CheckTypeExternal CheckType = "external"
+
+	// CheckTypeTarget is a target tracking check, using either Nomad or external metrics.
+	CheckTypeTarget CheckType = "target"
+
+	// CheckTypePredictive is a predictive scaling check, using the job group baseline.
+	CheckTypePredictive CheckType = "predictive"
+)
+
+func (ct CheckType) String() string { return string(ct) }
+
+// Reasons used to explain why a job group was not evaluated.
+const (
+	skipReasonDisabled   = "job group scaling policy is disabled"
+	skipReasonDeploying  = "job group is currently in deployment"
+	skipReasonCooldown   = "job group is currently in scaling cooldown"
+	skipReasonCooldownFn = "failed to determine if job group is in cooldown: "
+	skipReasonSchedule   = "job group count is being set by the open schedule window "
+)
+
+// externalCheckType returns the explanation check type of the external check.
+func externalCheckType(check *policy.ExternalCheck) CheckType {
+	if check.TargetValue != nil {
+		return CheckTypeTarget
+	}
+	return CheckTypeExternal
+}
+
+// nomadCheckName returns the explanation check name of the Nomad resource threshold check. Both
+// scale in and scale out checks use the same resource metric, so the action is used to
+// distinguish them.
+func nomadCheckName(metric string, action policy.ComparisonAction) string {
+	return metric + "-" + action.String()
+}
+
+// decisionResult returns the direction of the scaling decision, which is none if the decision is
+// nil.
+func decisionResult(dec *scalingDecision) string {
+	if dec == nil {
+		return string(scale.DirectionNone)
+	}
+	return dec.direction.String()
+}
+
+// Explain performs an autoscaling evaluation of the job without triggering any scaling, returning
+// an explanation of the checks performed and decisions made for each job group. A nil explanation
+// and error indicates the job does not have a scaling policy.
+func (a *AutoScale) Explain(job string) (*Explanation, error) {
+	policies, err := a.policyBackend.GetJobPolicy(job)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get job scaling policy")
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	t := time.Now().UTC()
+	exp := newExplanation(job, t)
+
+	safeScale, scheduled := a.filterEvaluationGroups(job, policies, t, exp)
+
+	// Groups with an open schedule window outside of the schedule bounds would be scaled by the
+	// schedule enforcement, and therefore not evaluated by the autoscaler.
+	if len(scheduled) > 0 {
+		nomadClient, err := a.nomadClient(job)
+		if err != nil {
+			return nil, err
+		}
+
+		info, _, err := nomadClient.Jobs().Info(nomadJobQuery(job))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to call Nomad API for job information")
+		}
+
+		counts := make(map[string]int)
+		for _, tg := range info.TaskGroups {
+			if tg.Name != nil && tg.Count != nil {
+				counts[*tg.Name] = *tg.Count
+			}
+		}
+
+		for _, req := range buildScheduleReqs(counts, scheduled, t) {
+			exp.skip(req.GroupName, skipReasonSchedule+scheduled[req.GroupName].name)
+			exp.decision(req, a.cfg.DryRun, state.SourceScheduler)
+			delete(safeScale, req.GroupName)
+		}
+	}
+
+	if len(safeScale) == 0 {
+		return exp, nil
+	}
+
+	ae, err := a.newEvaluation(job, safeScale, t)
+	if err != nil {
+		return nil, err
+	}
+	ae.explain, ae.explainOnly = exp, true
+
+	if dec := ae.buildFinalDecision(ae.calculateJobDecisions()); len(dec) > 0 {
+		for _, req := range ae.buildScalingReq(dec) {
+			exp.decision(req, ae.dryRun, state.SourceInternalAutoscaler)
+		}
+	}
+	return exp, nil
+}
+
+func newExplanation(job string, t time.Time) *Explanation {
+	return &Explanation{Job: job, Time: t.UnixNano(), Groups: make(map[string]*GroupExplanation)}
+}
+
+// group returns the explanation for the job group, creating it if needed.
+func (e *Explanation) group(group string) *GroupExplanation {
+	if _, ok := e.Groups[group]; !ok {
+		e.Groups[group] = &GroupExplanation{Checks: make(map[string]*CheckExplanation)}
+	}
+	return e.Groups[group]
+}
+
+// All the Explanation recording functions are safe to call on a nil Explanation, which is the case
+// when the autoscaler is performing a normal evaluation.
+
+// skip records the reason the job group was not evaluated.
+func (e *Explanation) skip(group, reason string) {
+	if e == nil {
+		return
+	}
+	g := e.group(group)
+	g.Evaluated, g.SkipReason = false, reason
+}
+
+// evaluated records that the job group passed the initial checks and will be evaluated.
+func (e *Explanation) evaluated(group string) {
+	if e == nil {
+		return
+	}
+	e.group(group).Evaluated = true
+}
+
+// check records the result of a check performed on the job group.
+func (e *Explanation) check(group, name string, check *CheckExplanation) {
+	if e == nil {
+		return
+	}
+	e.group(group).Checks[name] = check
+}
+
+// checkError records a check on the job group which could not be performed.
+func (e *Explanation) checkError(group, name string, checkType CheckType, err string) {
+	e.check(group, name, &CheckExplanation{Type: checkType, Result: string(scale.DirectionNone), Error: err})
+}
+
+// thresholdCheck records the result of a threshold check performed on the job group.
+func (e *Explanation) thresholdCheck(group, name string, checkType CheckType, value, threshold float64,
+	op policy.ComparisonOperator, action policy.ComparisonAction, dec *scalingDecision) {
+	if e == nil {
+		return
+	}
+	e.check(group, name, &CheckExplanation{
+		Type:               checkType,
+		Value:              &value,
+		Threshold:          &threshold,
+		ComparisonOperator: op.String(),
+		Action:             action.String(),
+		Result:             decisionResult(dec),
+	})
+}
+
+// breaches records the recent evaluations of a check configured with breach periods, updating the
+// check result with the decision made once the breach periods have been applied.
+func (e *Explanation) breaches(group, name string, breaching int, periods *policy.BreachPeriods, dec *scalingDecision) {
+	if e == nil {
+		return
+	}
+
+	check, ok := e.group(group).Checks[name]
+	if !ok {
+		return
+	}
+	check.Result = decisionResult(dec)
+	check.Breaches = &BreachExplanation{
+		Breaching:         breaching,
+		Datapoints:        periods.Datapoints,
+		EvaluationPeriods: periods.EvaluationPeriods,
+	}
+}
+
+// targetCheck records the result of a target tracking check performed on the job group, using the
+// current and desired counts to determine the direction requested.
+func (e *Explanation) targetCheck(group, name string, target *scalingMetricDecision, current, desired int) {
+	if e == nil {
+		return
+	}
+
+	result := scale.DirectionNone
+	switch {
+	case desired > current:
+		result = scale.DirectionOut
+	case desired < current:
+		result = scale.DirectionIn
+	}
+
+	value, threshold := target.value, target.threshold
+	e.check(group, name, &CheckExplanation{
+		Type:      CheckTypeTarget,
+		Value:     &value,
+		Threshold: &threshold,
+		Result:    string(result),
+	})
+}
+
+// predictiveCheck records the result of the predictive check performed on the job group. The value
+// is the predicted count, and the threshold the current count.
+func (e *Explanation) predictiveCheck(group string, predicted, current int, dec *scalingDecision) {
+	if e == nil {
+		return
+	}
+
+	value, threshold := float64(predicted), float64(current)
+	e.check(group, predictiveMetricName, &CheckExplanation{
+		Type:      CheckTypePredictive,
+		Value:     &value,
+		Threshold: &threshold,
+		Result:    decisionResult(dec),
+	})
+}
+
+// condition records whether the check condition of the scaling action was met for the job group.
+func (e *Explanation) condition(group string, action policy.ComparisonAction, met bool) {
+	if e == nil {
+		return
+	}
+
+	g := e.group(group)
+	if g.Conditions == nil {
+		g.Conditions = make(map[string]bool)
+	}
+	g.Conditions[action.String()] = met
+}
+
+// actionCooldown records that the scaling action decided upon for the job group was not taken, as
+// the cooldown of the action is still active.
+func (e *Explanation) actionCooldown(group string, action policy.ComparisonAction) {
+	if e == nil {
+		return
+	}
+	e.group(group).ActionCooldown = action.String()
+}
+
+// decision records the final scaling decision for the job group.
+func (e *Explanation) decision(req *scale.GroupReq, dryRun bool, source state.Source) {
+	if e == nil {
+		return
+	}
+	e.group(req.GroupName).Decision = &DecisionExplanation{
+		Direction:  req.Direction.String(),
+		Count:      req.Count,
+		Absolute:   req.Absolute,
+		Percentage: req.Percentage,
+		DryRun:     dryRun || (req.GroupScalingPolicy != nil && req.GroupScalingPolicy.DryRun),
+		Source:     source,
+		Meta:       req.Meta,
+	}
+}
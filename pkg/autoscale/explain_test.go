@@ -0,0 +1,119 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplanation_nilSafe(t *testing.T) {
+	var exp *Explanation
+
+	assert.NotPanics(t, func() {
+		exp.skip("cache", skipReasonDisabled)
+		exp.evaluated("cache")
+		exp.checkError("cache", nomadMetricsCheckName, CheckTypeNomad, "error")
+		exp.thresholdCheck("cache", "test", CheckTypeExternal, 10, 20,
+			policy.ComparisonGreaterThan, policy.ActionScaleOut, nil)
+		exp.targetCheck("cache", "test", &scalingMetricDecision{value: 10, threshold: 20}, 2, 1)
+		exp.predictiveCheck("cache", 2, 1, nil)
+		exp.decision(&scale.GroupReq{GroupName: "cache"}, false, state.SourceInternalAutoscaler)
+	})
+}
+
+func TestExplanation_skip(t *testing.T) {
+	exp := newExplanation("example", time.Unix(0, 1000))
+	assert.Equal(t, int64(1000), exp.Time)
+
+	exp.evaluated("cache")
+	assert.True(t, exp.Groups["cache"].Evaluated)
+
+	exp.skip("cache", skipReasonSchedule+"weekday")
+	assert.False(t, exp.Groups["cache"].Evaluated)
+	assert.Equal(t, "job group count is being set by the open schedule window weekday", exp.Groups["cache"].SkipReason)
+}
+
+func Test_autoscaleEvaluation_calculateNomadScalingDecisionExplain(t *testing.T) {
+	pol := &policy.GroupScalingPolicy{
+		ScaleOutCPUPercentageThreshold: helper.Float64ToPointer(80),
+		ScaleInCPUPercentageThreshold:  helper.Float64ToPointer(20),
+	}
+
+	ae := autoscaleEvaluation{
+		policies: map[string]*policy.GroupScalingPolicy{"cache": pol},
+		explain:  newExplanation("example", time.Now()),
+	}
+	ae.calculateNomadScalingDecision("cache", &nomadResources{cpu: 90}, pol)
+
+	expected := map[string]*CheckExplanation{
+		"nomad-cpu-scale-out": {
+			Type:               CheckTypeNomad,
+			Value:              helper.Float64ToPointer(90),
+			Threshold:          helper.Float64ToPointer(80),
+			ComparisonOperator: "greater-than",
+			Action:             "scale-out",
+			Result:             "out",
+		},
+		"nomad-cpu-scale-in": {
+			Type:               CheckTypeNomad,
+			Value:              helper.Float64ToPointer(90),
+			Threshold:          helper.Float64ToPointer(20),
+			ComparisonOperator: "less-than",
+			Action:             "scale-in",
+			Result:             "none",
+		},
+	}
+	assert.Equal(t, expected, ae.explain.Groups["cache"].Checks)
+}
+
+func Test_autoscaleEvaluation_calculateNomadTargetDecisionExplain(t *testing.T) {
+	pol := &policy.GroupScalingPolicy{
+		MinCount:            1,
+		MaxCount:            10,
+		ScalingMode:         policy.ScalingModeTargetTracking,
+		TargetCPUPercentage: helper.Float64ToPointer(50),
+	}
+
+	ae := autoscaleEvaluation{
+		policies:    map[string]*policy.GroupScalingPolicy{"cache": pol},
+		groupCounts: map[string]int{"cache": 4},
+		explain:     newExplanation("example", time.Now()),
+	}
+	ae.calculateTargetDecision("cache", pol, testGatheredMetrics("cache", &nomadResources{cpu: 25}))
+
+	expected := map[string]*CheckExplanation{
+		"nomad-cpu": {
+			Type:      CheckTypeTarget,
+			Value:     helper.Float64ToPointer(25),
+			Threshold: helper.Float64ToPointer(50),
+			Result:    "in",
+		},
+	}
+	assert.Equal(t, expected, ae.explain.Groups["cache"].Checks)
+}
+
+func TestExplanation_decision(t *testing.T) {
+	exp := newExplanation("example", time.Now())
+
+	exp.decision(&scale.GroupReq{
+		Direction:          scale.DirectionOut,
+		Count:              2,
+		GroupName:          "cache",
+		GroupScalingPolicy: &policy.GroupScalingPolicy{DryRun: true},
+		Meta:               map[string]string{"nomad-cpu-value": "90"},
+	}, false, state.SourceInternalAutoscaler)
+
+	expected := &DecisionExplanation{
+		Direction: "out",
+		Count:     2,
+		DryRun:    true,
+		Source:    state.SourceInternalAutoscaler,
+		Meta:      map[string]string{"nomad-cpu-value": "90"},
+	}
+	assert.Equal(t, expected, exp.Groups["cache"].Decision)
+}
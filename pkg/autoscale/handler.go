@@ -5,12 +5,21 @@ import (
 
 	"github.com/jrasell/sherpa/pkg/helper"
 
-	nomad "github.com/hashicorp/nomad/api"
 	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
-	"github.com/jrasell/sherpa/pkg/autoscale/metrics/prometheus"
+	"github.com/jrasell/sherpa/pkg/autoscale/predictive"
+	"github.com/jrasell/sherpa/pkg/client"
+
+	// Import the metric providers so that they register themselves with the metrics provider
+	// registry.
+	_ "github.com/jrasell/sherpa/pkg/autoscale/metrics/graphite"
+	_ "github.com/jrasell/sherpa/pkg/autoscale/metrics/influxdb"
+	_ "github.com/jrasell/sherpa/pkg/autoscale/metrics/prometheus"
 	"github.com/jrasell/sherpa/pkg/policy"
 	policyBackend "github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/breach"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
 	ants "github.com/panjf2000/ants/v2"
 	"github.com/rs/zerolog"
 )
@@ -18,7 +27,7 @@ import (
 type AutoScale struct {
 	cfg    *Config
 	logger zerolog.Logger
-	nomad  *nomad.Client
+	nomad  *client.NomadTargets
 	scaler scale.Scale
 
 	policyBackend policyBackend.PolicyBackend
@@ -27,6 +36,15 @@ type AutoScale struct {
 	// metricProvider
 	metricProvider map[policy.MetricsProvider]metrics.Provider
 
+	// predictor is used to record job group baselines and make predictive scaling decisions.
+	predictor *predictive.Predictor
+
+	// history stores the evaluation history of each job group.
+	history evaluation.Backend
+
+	// breach stores the recent threshold check results of each job group.
+	breach breach.Backend
+
 	// isRunning is used to track whether the autoscaler loop is being run. This helps determine
 	// whether stop should be called.
 	isRunning bool
@@ -50,12 +68,16 @@ func NewAutoScaleServer(cfg *SetupConfig) (*AutoScale, error) {
 			ScalingInterval:   cfg.ScalingInterval,
 			ScalingThreads:    cfg.ScalingThreads,
 			StrictChecking:    cfg.StrictChecking,
+			DryRun:            cfg.DryRun,
 			MetricProviderCfg: cfg.MetricProviderCfg,
 		},
 		logger:        cfg.Logger,
 		nomad:         cfg.Nomad,
 		policyBackend: cfg.PolicyBackend,
 		scaler:        cfg.Scale,
+		predictor:     cfg.Predictor,
+		history:       cfg.History,
+		breach:        cfg.Breach,
 		doneChan:      make(chan struct{}),
 	}
 
@@ -70,21 +92,10 @@ func NewAutoScaleServer(cfg *SetupConfig) (*AutoScale, error) {
 	return &as, nil
 }
 
-// setupMetricProviders setups up the metric providers.
+// setupMetricProviders setups up the metric providers which have been registered and have
+// configuration available.
 func (a *AutoScale) setupMetricProviders() {
-
-	// Initialise the metric provider map within AutoScale.
-	a.metricProvider = make(map[policy.MetricsProvider]metrics.Provider)
-
-	// If there is available Prometheus config, setup the provider.
-	if a.cfg.MetricProviderCfg.Prometheus != nil {
-		promClient, err := prometheus.NewClient(a.cfg.MetricProviderCfg.Prometheus.Addr, a.logger)
-		if err != nil {
-			a.logger.Error().Err(err).Msg("failed to setup Prometheus metric provider client")
-		} else {
-			a.metricProvider[policy.ProviderPrometheus] = promClient
-		}
-	}
+	a.metricProvider = metrics.SetupProviders(a.cfg.MetricProviderCfg, a.logger)
 }
 
 // IsRunning is used to determine if the autoscaler loop is running.
@@ -133,57 +144,22 @@ func (a *AutoScale) Run() {
 				// Generate a timestamp for the occurrence of this autoscaling attempt.
 				t := time.Now().UTC()
 
-				// Create a new policy object to track groups that are not considered to be in
-				// deployment or in cooldown.
-				safeScale := make(map[string]*policy.GroupScalingPolicy)
-
-				// Iterate the group policies, and check whether they are in deployment or in
-				// cooldown.
-				for group := range allPolicies[job] {
-
-					// If the group policy is disabled, continue with the loop and ignore the
-					// policy.
-					if !allPolicies[job][group].Enabled {
-						continue
-					}
-
-					// Deployment check.
-					if a.scaler.JobGroupIsDeploying(job, group) {
-						a.logger.Debug().
-							Str("job", job).
-							Str("group", group).
-							Msg("job group is currently in deployment, skipping autoscaler evaluation")
-						continue
-					}
+				// Filter the groups to those which are not considered to be in deployment or in
+				// cooldown, tracking those with an open schedule window.
+				safeScale, scheduled := a.filterEvaluationGroups(job, allPolicies[job], t, nil)
 
-					// Cooldown check.
-					cool, err := a.scaler.JobGroupIsInCooldown(job, group, allPolicies[job][group].Cooldown, t.UnixNano())
-					if err != nil {
-						a.logger.Error().
-							Err(err).
-							Str("job", job).
-							Str("group", group).
-							Msg("failed to determine if job group is in cooldown")
-						continue
-					}
-					if cool {
-						a.logger.Debug().
-							Err(err).
-							Str("job", job).
-							Str("group", group).
-							Msg("job group is currently in scaling cooldown, skipping autoscaler evaluation")
-						continue
+				// Enforce any open schedule windows. Groups which are scaled as a result are
+				// removed from the autoscaler evaluation as their count is already changing.
+				if len(scheduled) > 0 {
+					for group := range a.enforceSchedules(job, scheduled, t) {
+						delete(safeScale, group)
 					}
-
-					// At this point the initial checks have passed, therefore we can add the group
-					// to the map indicating we can continue within the evaluation.
-					safeScale[group] = allPolicies[job][group]
 				}
 
 				// If we have groups within the job that are not deploying, we can trigger a
 				// scaling event.
 				if len(safeScale) > 0 {
-					if err := a.pool.Invoke(&workerPayload{jobID: job, policy: allPolicies[job], time: t}); err != nil {
+					if err := a.pool.Invoke(&workerPayload{jobID: job, policy: safeScale, time: t}); err != nil {
 						a.logger.Error().Err(err).Msg("failed to invoke autoscaling worker thread")
 					}
 				}
@@ -197,6 +173,108 @@ func (a *AutoScale) Run() {
 	}
 }
 
+// filterEvaluationGroups iterates the group policies of the job, returning the groups which are
+// not considered to be in deployment or in cooldown, and therefore can be evaluated. Groups with an
+// open schedule window are returned separately so that the schedule can be enforced. The reason a
+// group is skipped is recorded within the explanation, if not nil.
+func (a *AutoScale) filterEvaluationGroups(job string, policies map[string]*policy.GroupScalingPolicy, t time.Time,
+	exp *Explanation) (map[string]*policy.GroupScalingPolicy, map[string]*scheduledGroup) {
+
+	// Create a new policy object to track groups that are not considered to be in deployment or
+	// in cooldown.
+	safeScale := make(map[string]*policy.GroupScalingPolicy)
+
+	// Track the groups which have an open schedule window, so that the schedule can be enforced.
+	scheduled := make(map[string]*scheduledGroup)
+
+	// Iterate the group policies, and check whether they are in deployment or in cooldown.
+	for group := range policies {
+
+		// If the group policy is disabled, continue with the loop and ignore the policy.
+		if !policies[group].Enabled {
+			exp.skip(group, skipReasonDisabled)
+			continue
+		}
+
+		// Deployment check.
+		if a.scaler.JobGroupIsDeploying(job, group) {
+			a.logger.Debug().
+				Str("job", job).
+				Str("group", group).
+				Msg("job group is currently in deployment, skipping autoscaler evaluation")
+			exp.skip(group, skipReasonDeploying)
+			continue
+		}
+
+		// If the group has an open schedule window, the schedule overrides are applied to the
+		// policy used for the remainder of this run.
+		groupPolicy := policies[group]
+		if name, schedule := groupPolicy.ActiveSchedule(t); schedule != nil {
+			groupPolicy = groupPolicy.ApplySchedule(schedule)
+			scheduled[group] = &scheduledGroup{name: name, policy: groupPolicy}
+		}
+
+		// Cooldown check. The shortest action cooldown is used, as the action is not known until
+		// the group has been evaluated.
+		cool, err := a.scaler.JobGroupIsInCooldown(job, group, groupPolicy.MinCooldown(), t.UnixNano())
+		if err != nil {
+			a.logger.Error().
+				Err(err).
+				Str("job", job).
+				Str("group", group).
+				Msg("failed to determine if job group is in cooldown")
+			exp.skip(group, skipReasonCooldownFn+err.Error())
+			continue
+		}
+		if cool {
+			a.logger.Debug().
+				Err(err).
+				Str("job", job).
+				Str("group", group).
+				Msg("job group is currently in scaling cooldown, skipping autoscaler evaluation")
+			exp.skip(group, skipReasonCooldown)
+			continue
+		}
+
+		// At this point the initial checks have passed, therefore we can add the group to the map
+		// indicating we can continue within the evaluation.
+		safeScale[group] = groupPolicy
+		exp.evaluated(group)
+	}
+	return safeScale, scheduled
+}
+
+// newEvaluation builds the autoscaling evaluation of the job groups. If evaluation history is
+// enabled, the evaluation records its checks and decisions so they can be stored. An error is
+// returned if the Nomad target of the job is not configured.
+func (a *AutoScale) newEvaluation(jobID string, policies map[string]*policy.GroupScalingPolicy, t time.Time) (*autoscaleEvaluation, error) {
+	nomadClient, err := a.nomadClient(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	ae := &autoscaleEvaluation{
+		nomad:          nomadClient,
+		metricProvider: a.metricProvider,
+		scaler:         a.scaler,
+		predictor:      a.predictor,
+		history:        a.history,
+		breach:         a.breach,
+		breaches:       make(map[string]*state.BreachHistory),
+		interval:       a.cfg.ScalingInterval,
+		dryRun:         a.cfg.DryRun,
+		log:            helper.LoggerWithJobContext(a.logger, jobID),
+		jobID:          jobID,
+		policies:       policies,
+		time:           t.UnixNano(),
+	}
+
+	if a.history != nil {
+		ae.explain = newExplanation(jobID, t)
+	}
+	return ae, nil
+}
+
 // Stop is used to gracefully stop the autoscaling workers.
 func (a *AutoScale) Stop() {
 
@@ -246,14 +324,10 @@ func (a *AutoScale) workerPoolFunc() func(payload interface{}) {
 			return
 		}
 
-		newEval := autoscaleEvaluation{
-			nomad:          a.nomad,
-			metricProvider: a.metricProvider,
-			scaler:         a.scaler,
-			log:            helper.LoggerWithJobContext(a.logger, req.jobID),
-			jobID:          req.jobID,
-			policies:       req.policy,
-			time:           req.time.UnixNano(),
+		newEval, err := a.newEvaluation(req.jobID, req.policy, req.time)
+		if err != nil {
+			a.logger.Error().Err(err).Str("job", req.jobID).Msg("failed to setup autoscaling evaluation")
+			return
 		}
 		newEval.evaluateJob()
 	}
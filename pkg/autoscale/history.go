@@ -0,0 +1,55 @@
+package autoscale
+
+import (
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// recordHistory stores the checks and decision of each evaluated job group within the evaluation
+// history backend. Evaluations are recorded whether or not scaling was required.
+func (ae *autoscaleEvaluation) recordHistory() {
+	if ae.history == nil || ae.explain == nil {
+		return
+	}
+
+	for group := range ae.policies {
+		eval := ae.explain.Groups[group].evaluation(ae.time)
+
+		if err := ae.history.PutEvaluation(ae.jobID, group, eval); err != nil {
+			ae.log.Error().Err(err).Str("group", group).Msg("failed to record job group evaluation history")
+		}
+	}
+}
+
+// evaluation converts the group explanation to the evaluation stored within the history backend.
+// A nil explanation indicates no checks were recorded for the group.
+func (g *GroupExplanation) evaluation(t int64) *state.Evaluation {
+	eval := &state.Evaluation{Time: t}
+
+	if g == nil {
+		return eval
+	}
+
+	if len(g.Checks) > 0 {
+		eval.Checks = make(map[string]*state.EvaluationCheck, len(g.Checks))
+	}
+
+	for name, check := range g.Checks {
+		eval.Checks[name] = &state.EvaluationCheck{
+			Type:      check.Type.String(),
+			Value:     check.Value,
+			Threshold: check.Threshold,
+			Result:    check.Result,
+			Error:     check.Error,
+		}
+	}
+
+	if g.Decision != nil {
+		eval.Decision = &state.EvaluationDecision{
+			Direction: g.Decision.Direction,
+			Count:     g.Decision.Count,
+			Absolute:  g.Decision.Absolute,
+			DryRun:    g.Decision.DryRun,
+		}
+	}
+	return eval
+}
@@ -0,0 +1,56 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/evaluation/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_autoscaleEvaluation_recordHistory(t *testing.T) {
+	now := time.Now().UTC()
+	history := memory.NewStateBackend(10)
+
+	ae := autoscaleEvaluation{
+		jobID:    "example",
+		time:     now.UnixNano(),
+		log:      zerolog.Nop(),
+		history:  history,
+		explain:  newExplanation("example", now),
+		policies: map[string]*policy.GroupScalingPolicy{"cache": {}, "web": {}},
+	}
+
+	ae.explain.thresholdCheck("cache", "nomad-cpu-scale-out", CheckTypeNomad, 90, 80,
+		policy.ComparisonGreaterThan, policy.ActionScaleOut, &scalingDecision{direction: scale.DirectionOut})
+	ae.explain.decision(&scale.GroupReq{GroupName: "cache", Direction: scale.DirectionOut, Count: 1},
+		false, state.SourceInternalAutoscaler)
+	ae.recordHistory()
+
+	expected := map[string][]*state.Evaluation{
+		"cache": {
+			{
+				Time: now.UnixNano(),
+				Checks: map[string]*state.EvaluationCheck{
+					"nomad-cpu-scale-out": {
+						Type:      "nomad",
+						Value:     helper.Float64ToPointer(90),
+						Threshold: helper.Float64ToPointer(80),
+						Result:    "out",
+					},
+				},
+				Decision: &state.EvaluationDecision{Direction: "out", Count: 1},
+			},
+		},
+		"web": {{Time: now.UnixNano()}},
+	}
+
+	actual, err := history.GetJobEvaluations("example")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, actual)
+}
@@ -15,3 +15,10 @@ func sendTriggerSuccessMetrics(job string) {
 	metrics.IncrCounter([]string{"autoscale", "trigger", "success"}, 1)
 	metrics.IncrCounter([]string{"autoscale", job, "trigger", "success"}, 1)
 }
+
+// sendDryRunMetrics is a helper to track autoscaling scaling decisions recorded in dry-run mode.
+// This is done by tracking both overall, and job specific counters.
+func sendDryRunMetrics(job string) {
+	metrics.IncrCounter([]string{"autoscale", "dry_run"}, 1)
+	metrics.IncrCounter([]string{"autoscale", job, "dry_run"}, 1)
+}
@@ -0,0 +1,148 @@
+package graphite
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sendMetrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// renderEndpoint is the Graphite render API endpoint used for querying metric values.
+	renderEndpoint = "/render"
+
+	// defaultFrom is the relative time window used if the operator has not configured one.
+	defaultFrom = "-5min"
+)
+
+// renderSeries is a single series entry within the Graphite render API JSON response. Each
+// datapoint is a two element array containing the value, which can be null, and the timestamp.
+type renderSeries struct {
+	Target     string        `json:"target"`
+	Datapoints [][2]*float64 `json:"datapoints"`
+}
+
+// Client is a Graphite metrics backend wrapper.
+type Client struct {
+	cfg        *server.MetricProviderGraphiteConfig
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+func init() {
+	metrics.Register(policy.ProviderGraphite, newFromConfig)
+}
+
+// newFromConfig satisfies the metrics.Factory func, building the Graphite client if the server
+// has been configured with a Graphite address.
+func newFromConfig(cfg *server.MetricProviderConfig, log zerolog.Logger) (metrics.Provider, error) {
+	if cfg.Graphite == nil {
+		return nil, nil
+	}
+	return NewClient(cfg.Graphite, log)
+}
+
+// NewClient takes the Graphite provider config and builds the client for use in retrieving metric
+// values.
+func NewClient(cfg *server.MetricProviderGraphiteConfig, log zerolog.Logger) (metrics.Provider, error) {
+	if _, err := url.Parse(cfg.Addr); err != nil {
+		return nil, errors.Wrap(err, "failed to parse Graphite address")
+	}
+
+	clientCfg := *cfg
+	if clientCfg.From == "" {
+		clientCfg.From = defaultFrom
+	}
+
+	return &Client{
+		cfg:        &clientCfg,
+		httpClient: cleanhttp.DefaultClient(),
+		logger:     log.With().Str("metric-provider", policy.ProviderGraphite.String()).Logger(),
+	}, nil
+}
+
+// GetValue satisfies the GetValue function of the metrics.Provider interface.
+func (c *Client) GetValue(query string) (*float64, error) {
+	defer sendMetrics.MeasureSince([]string{"autoscale", "graphite", "get_value"}, time.Now())
+
+	value, err := c.getValue(query)
+	if err != nil {
+		sendMetrics.IncrCounter([]string{"autoscale", "graphite", "error"}, 1)
+	} else {
+		sendMetrics.IncrCounter([]string{"autoscale", "graphite", "success"}, 1)
+	}
+	return value, err
+}
+
+// getValue performs the Graphite render API query work, allowing the interface implementation to
+// handle end state activities.
+func (c *Client) getValue(query string) (*float64, error) {
+	params := url.Values{}
+	params.Set("target", query)
+	params.Set("from", c.cfg.From)
+	params.Set("format", "json")
+
+	req, err := http.NewRequest(http.MethodGet, c.cfg.Addr+renderEndpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	c.logger.Debug().Str("url", req.URL.String()).Msg("successfully built query URL")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code %d from Graphite: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var series []renderSeries
+	if err := json.Unmarshal(body, &series); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Graphite response")
+	}
+	return getValueFromResp(series)
+}
+
+// getValueFromResp is used to get the most recent non-null value from the single series returned
+// by Graphite.
+func getValueFromResp(series []renderSeries) (*float64, error) {
+
+	// If we do not have the correct number of results, do not guess, inform the client this is an
+	// error so they can fix the query.
+	if len(series) != 1 {
+		return nil, errors.New("received incorrect length series list from Graphite")
+	}
+
+	// Graphite returns null values for intervals which have not yet received data, so work
+	// backwards to find the latest populated datapoint.
+	points := series[0].Datapoints
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i][0] != nil {
+			return helper.Float64ToPointer(*points[i][0]), nil
+		}
+	}
+	return nil, errors.New("no non-null datapoints found in Graphite series")
+}
@@ -0,0 +1,78 @@
+package graphite
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetValue(t *testing.T) {
+	testCases := []struct {
+		respBody       string
+		respCode       int
+		expectedOutput *float64
+		expectedError  bool
+		name           string
+	}{
+		{
+			respBody:       `[{"target":"queue.depth","datapoints":[[10,1580000000],[12.5,1580000060],[null,1580000120]]}]`,
+			respCode:       http.StatusOK,
+			expectedOutput: helper.Float64ToPointer(12.5),
+			name:           "single series returns latest non-null value",
+		},
+		{
+			respBody:      `[{"target":"a","datapoints":[[1,1]]},{"target":"b","datapoints":[[2,1]]}]`,
+			respCode:      http.StatusOK,
+			expectedError: true,
+			name:          "multiple series returns error",
+		},
+		{
+			respBody:      `[{"target":"queue.depth","datapoints":[[null,1580000000]]}]`,
+			respCode:      http.StatusOK,
+			expectedError: true,
+			name:          "only null datapoints returns error",
+		},
+		{
+			respBody:      `[]`,
+			respCode:      http.StatusOK,
+			expectedError: true,
+			name:          "no series returns error",
+		},
+		{
+			respBody:      "internal error",
+			respCode:      http.StatusInternalServerError,
+			expectedError: true,
+			name:          "non 200 response returns error",
+		},
+	}
+
+	for _, tc := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, renderEndpoint, r.URL.Path, tc.name)
+			assert.Equal(t, "queue.depth", r.URL.Query().Get("target"), tc.name)
+			assert.Equal(t, "json", r.URL.Query().Get("format"), tc.name)
+			assert.Equal(t, defaultFrom, r.URL.Query().Get("from"), tc.name)
+
+			w.WriteHeader(tc.respCode)
+			_, _ = fmt.Fprint(w, tc.respBody)
+		}))
+
+		client, err := NewClient(&server.MetricProviderGraphiteConfig{Addr: srv.URL}, zerolog.Logger{})
+		assert.Nil(t, err, tc.name)
+
+		actualOutput, err := client.GetValue("queue.depth")
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,317 @@
+package influxdb
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	sendMetrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// influxQLEndpoint is the InfluxDB 1.x compatible API endpoint used for InfluxQL queries.
+	influxQLEndpoint = "/query"
+
+	// fluxEndpoint is the InfluxDB 2.x API endpoint used for Flux queries.
+	fluxEndpoint = "/api/v2/query"
+
+	// fluxValueColumn is the annotated CSV column name which holds the record value.
+	fluxValueColumn = "_value"
+
+	// fluxTableColumn is the annotated CSV column name which identifies the table the record
+	// belongs to.
+	fluxTableColumn = "table"
+)
+
+type influxQLResp struct {
+	Results []influxQLResult `json:"results"`
+	Error   string           `json:"error"`
+}
+
+type influxQLResult struct {
+	Series []influxQLSeries `json:"series"`
+	Error  string           `json:"error"`
+}
+
+type influxQLSeries struct {
+	Name    string          `json:"name"`
+	Columns []string        `json:"columns"`
+	Values  [][]interface{} `json:"values"`
+}
+
+// Client is an InfluxDB metrics backend wrapper.
+type Client struct {
+	cfg        *server.MetricProviderInfluxDBConfig
+	httpClient *http.Client
+	logger     zerolog.Logger
+}
+
+func init() {
+	metrics.Register(policy.ProviderInfluxDB, newFromConfig)
+}
+
+// newFromConfig satisfies the metrics.Factory func, building the InfluxDB client if the server
+// has been configured with an InfluxDB address.
+func newFromConfig(cfg *server.MetricProviderConfig, log zerolog.Logger) (metrics.Provider, error) {
+	if cfg.InfluxDB == nil {
+		return nil, nil
+	}
+	return NewClient(cfg.InfluxDB, log)
+}
+
+// NewClient takes the InfluxDB provider config and builds the client for use in retrieving metric
+// values.
+func NewClient(cfg *server.MetricProviderInfluxDBConfig, log zerolog.Logger) (metrics.Provider, error) {
+	if _, err := url.Parse(cfg.Addr); err != nil {
+		return nil, errors.Wrap(err, "failed to parse InfluxDB address")
+	}
+
+	// Take a copy of the config so that defaulting the query language does not alter the server
+	// config.
+	clientCfg := *cfg
+
+	switch clientCfg.QueryLanguage {
+	case "":
+		clientCfg.QueryLanguage = server.InfluxDBQueryLanguageInfluxQL
+	case server.InfluxDBQueryLanguageInfluxQL, server.InfluxDBQueryLanguageFlux:
+	default:
+		return nil, errors.Errorf("InfluxDB query language %s is not a valid option", clientCfg.QueryLanguage)
+	}
+
+	return &Client{
+		cfg:        &clientCfg,
+		httpClient: cleanhttp.DefaultClient(),
+		logger:     log.With().Str("metric-provider", policy.ProviderInfluxDB.String()).Logger(),
+	}, nil
+}
+
+// GetValue satisfies the GetValue function of the metrics.Provider interface.
+func (c *Client) GetValue(query string) (*float64, error) {
+	defer sendMetrics.MeasureSince([]string{"autoscale", "influxdb", "get_value"}, time.Now())
+
+	var (
+		value *float64
+		err   error
+	)
+
+	switch c.cfg.QueryLanguage {
+	case server.InfluxDBQueryLanguageFlux:
+		value, err = c.getFluxValue(query)
+	default:
+		value, err = c.getInfluxQLValue(query)
+	}
+
+	if err != nil {
+		sendMetrics.IncrCounter([]string{"autoscale", "influxdb", "error"}, 1)
+	} else {
+		sendMetrics.IncrCounter([]string{"autoscale", "influxdb", "success"}, 1)
+	}
+	return value, err
+}
+
+// getInfluxQLValue performs the InfluxQL query, returning the most recent value of the single
+// series returned.
+func (c *Client) getInfluxQLValue(query string) (*float64, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("epoch", "s")
+	if c.cfg.Database != "" {
+		params.Set("db", c.cfg.Database)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.cfg.Addr+influxQLEndpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setAuth(req)
+	c.logger.Debug().Str("url", req.URL.String()).Msg("successfully built query URL")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp influxQLResp
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal InfluxDB response")
+	}
+	return getValueFromInfluxQLResp(&resp)
+}
+
+// getFluxValue performs the Flux query, returning the most recent value of the single table
+// returned.
+func (c *Client) getFluxValue(query string) (*float64, error) {
+	params := url.Values{}
+	if c.cfg.Organization != "" {
+		params.Set("org", c.cfg.Organization)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.cfg.Addr+fluxEndpoint+"?"+params.Encode(), bytes.NewBufferString(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.flux")
+	req.Header.Set("Accept", "application/csv")
+	c.setAuth(req)
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return getValueFromFluxResp(bytes.NewReader(body))
+}
+
+// setAuth adds the configured authentication details to the request. A token takes precedence
+// over username and password.
+func (c *Client) setAuth(req *http.Request) {
+	switch {
+	case c.cfg.Token != "":
+		req.Header.Set("Authorization", "Token "+c.cfg.Token)
+	case c.cfg.Username != "":
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+}
+
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response code %d from InfluxDB: %s",
+			resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// getValueFromInfluxQLResp is used to get the single metric value from the InfluxQL response.
+func getValueFromInfluxQLResp(resp *influxQLResp) (*float64, error) {
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	// If we do not have the correct number of results or series, do not guess, inform the client
+	// this is an error so they can fix the query.
+	if len(resp.Results) != 1 {
+		return nil, errors.New("received incorrect length result list from InfluxDB")
+	}
+	if resp.Results[0].Error != "" {
+		return nil, errors.New(resp.Results[0].Error)
+	}
+	if len(resp.Results[0].Series) != 1 {
+		return nil, errors.New("received incorrect length series list from InfluxDB")
+	}
+
+	series := resp.Results[0].Series[0]
+	if len(series.Values) < 1 {
+		return nil, errors.New("received empty series from InfluxDB")
+	}
+
+	// The most recent row is used, and the first non-time column is taken as the value.
+	row := series.Values[len(series.Values)-1]
+	for i := range series.Columns {
+		if series.Columns[i] == "time" || i >= len(row) {
+			continue
+		}
+
+		floatVal, ok := row[i].(float64)
+		if !ok {
+			return nil, errors.Errorf("InfluxDB column %s value is not numeric", series.Columns[i])
+		}
+		return helper.Float64ToPointer(floatVal), nil
+	}
+	return nil, errors.New("no value column found in InfluxDB series")
+}
+
+// getValueFromFluxResp is used to get the single metric value from the Flux annotated CSV response.
+func getValueFromFluxResp(body io.Reader) (*float64, error) {
+	r := csv.NewReader(body)
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+
+	var (
+		header            []string
+		valueIdx, tableID = -1, -1
+		tables            = make(map[string]struct{})
+		lastValue         string
+	)
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read InfluxDB CSV response")
+		}
+
+		// Each table within the response is preceded by its own header row, which is identified
+		// by containing the value column name. The CSV reader skips the empty lines which
+		// separate tables, so the header must be detected by content.
+		if isFluxHeader(record) {
+			header = record
+			valueIdx, tableID = -1, -1
+			for i := range header {
+				switch header[i] {
+				case fluxValueColumn:
+					valueIdx = i
+				case fluxTableColumn:
+					tableID = i
+				}
+			}
+			continue
+		}
+
+		if header == nil || valueIdx < 0 || valueIdx >= len(record) {
+			return nil, errors.New("no _value column found in InfluxDB response")
+		}
+		if tableID >= 0 && tableID < len(record) {
+			tables[record[tableID]] = struct{}{}
+		}
+		lastValue = record[valueIdx]
+	}
+
+	// If we do not have the correct number of tables, do not guess, inform the client this is an
+	// error so they can fix the query.
+	if lastValue == "" || len(tables) > 1 {
+		return nil, errors.New("received incorrect length table list from InfluxDB")
+	}
+
+	floatVal, err := strconv.ParseFloat(lastValue, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert InfluxDB metric value to float64")
+	}
+	return helper.Float64ToPointer(floatVal), nil
+}
+
+// isFluxHeader identifies whether the CSV record is a table header row.
+func isFluxHeader(record []string) bool {
+	for i := range record {
+		if record[i] == fluxValueColumn {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package influxdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetValue_InfluxQL(t *testing.T) {
+	testCases := []struct {
+		respBody       string
+		respCode       int
+		expectedOutput *float64
+		expectedError  bool
+		name           string
+	}{
+		{
+			respBody:       `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[[1,10.5],[2,42.5]]}]}]}`,
+			respCode:       http.StatusOK,
+			expectedOutput: helper.Float64ToPointer(42.5),
+			name:           "single series returns latest value",
+		},
+		{
+			respBody:      `{"results":[{"statement_id":0,"series":[{"name":"a","columns":["time","mean"],"values":[[1,1]]},{"name":"b","columns":["time","mean"],"values":[[1,2]]}]}]}`,
+			respCode:      http.StatusOK,
+			expectedError: true,
+			name:          "multiple series returns error",
+		},
+		{
+			respBody:      `{"results":[{"statement_id":0,"error":"database not found: sherpa"}]}`,
+			respCode:      http.StatusOK,
+			expectedError: true,
+			name:          "statement error returns error",
+		},
+		{
+			respBody:      `{"error":"authorization failed"}`,
+			respCode:      http.StatusUnauthorized,
+			expectedError: true,
+			name:          "non 200 response returns error",
+		},
+	}
+
+	for _, tc := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, influxQLEndpoint, r.URL.Path, tc.name)
+			assert.Equal(t, "sherpa", r.URL.Query().Get("db"), tc.name)
+			assert.Equal(t, "SELECT mean(usage) FROM cpu", r.URL.Query().Get("q"), tc.name)
+
+			user, pass, ok := r.BasicAuth()
+			assert.True(t, ok, tc.name)
+			assert.Equal(t, "jrasell", user, tc.name)
+			assert.Equal(t, "secret", pass, tc.name)
+
+			w.WriteHeader(tc.respCode)
+			_, _ = fmt.Fprint(w, tc.respBody)
+		}))
+
+		client, err := NewClient(&server.MetricProviderInfluxDBConfig{
+			Addr:     srv.URL,
+			Database: "sherpa",
+			Username: "jrasell",
+			Password: "secret",
+		}, zerolog.Logger{})
+		assert.Nil(t, err, tc.name)
+
+		actualOutput, err := client.GetValue("SELECT mean(usage) FROM cpu")
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+		}
+		srv.Close()
+	}
+}
+
+func TestClient_GetValue_Flux(t *testing.T) {
+	testCases := []struct {
+		respBody       string
+		expectedOutput *float64
+		expectedError  bool
+		name           string
+	}{
+		{
+			respBody: ",result,table,_time,_value\r\n" +
+				",_result,0,2020-01-01T00:00:00Z,13.2\r\n" +
+				",_result,0,2020-01-01T00:01:00Z,14.7\r\n\r\n",
+			expectedOutput: helper.Float64ToPointer(14.7),
+			name:           "single table returns latest value",
+		},
+		{
+			respBody: "#datatype,string,long,dateTime:RFC3339,double\r\n" +
+				"#group,false,false,false,false\r\n" +
+				"#default,_result,,,\r\n" +
+				",result,table,_time,_value\r\n" +
+				",,0,2020-01-01T00:00:00Z,7\r\n\r\n",
+			expectedOutput: helper.Float64ToPointer(7),
+			name:           "annotated single table returns value",
+		},
+		{
+			respBody: ",result,table,_time,_value\r\n" +
+				",_result,0,2020-01-01T00:00:00Z,1\r\n" +
+				",_result,1,2020-01-01T00:00:00Z,2\r\n\r\n",
+			expectedError: true,
+			name:          "multiple tables returns error",
+		},
+		{
+			respBody:      "\r\n",
+			expectedError: true,
+			name:          "empty response returns error",
+		},
+	}
+
+	for _, tc := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, fluxEndpoint, r.URL.Path, tc.name)
+			assert.Equal(t, http.MethodPost, r.Method, tc.name)
+			assert.Equal(t, "sherpa-org", r.URL.Query().Get("org"), tc.name)
+			assert.Equal(t, "Token abc123", r.Header.Get("Authorization"), tc.name)
+
+			body, err := ioutil.ReadAll(r.Body)
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, `from(bucket: "sherpa") |> range(start: -5m)`, string(body), tc.name)
+
+			_, _ = fmt.Fprint(w, tc.respBody)
+		}))
+
+		client, err := NewClient(&server.MetricProviderInfluxDBConfig{
+			Addr:          srv.URL,
+			Token:         "abc123",
+			Organization:  "sherpa-org",
+			QueryLanguage: server.InfluxDBQueryLanguageFlux,
+		}, zerolog.Logger{})
+		assert.Nil(t, err, tc.name)
+
+		actualOutput, err := client.GetValue(`from(bucket: "sherpa") |> range(start: -5m)`)
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+		}
+		srv.Close()
+	}
+}
+
+func TestNewClient_InvalidQueryLanguage(t *testing.T) {
+	_, err := NewClient(&server.MetricProviderInfluxDBConfig{Addr: "http://127.0.0.1:8086", QueryLanguage: "sql"}, zerolog.Logger{})
+	assert.EqualError(t, err, "InfluxDB query language sql is not a valid option")
+}
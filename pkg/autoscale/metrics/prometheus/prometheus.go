@@ -3,13 +3,16 @@ package prometheus
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"time"
 
 	sendMetrics "github.com/armon/go-metrics"
 	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
+	"github.com/jrasell/sherpa/pkg/config/server"
 	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/pkg/errors"
@@ -18,9 +21,15 @@ import (
 )
 
 const (
-	// queryEndpoint is the Prometheus API endpoint used and currently supported for querying
-	// metric values.
-	queryEndpoint = "/api/v1/query?query="
+	// queryEndpoint is the Prometheus API endpoint used for instant queries of metric values.
+	queryEndpoint = "/api/v1/query"
+
+	// queryRangeEndpoint is the Prometheus API endpoint used for range queries of metric values.
+	queryRangeEndpoint = "/api/v1/query_range"
+
+	// Result types which can be returned by the Prometheus query API.
+	resultTypeVector = "vector"
+	resultTypeMatrix = "matrix"
 )
 
 type queryResp struct {
@@ -33,38 +42,73 @@ type queryRespData struct {
 	Result     []queryRespResult `json:"result"`
 }
 
+// queryRespResult is a single series within the query response. Instant vector results populate
+// Value with a single sample, whereas range matrix results populate Values with multiple samples.
 type queryRespResult struct {
-	Value []interface{} `json:"value"`
+	Value  []interface{}   `json:"value"`
+	Values [][]interface{} `json:"values"`
+}
+
+// sample is a single parsed Prometheus sample.
+type sample struct {
+	time  float64
+	value float64
 }
 
 // Client is a Prometheus metrics backend wrapper.
 type Client struct {
 	logger           zerolog.Logger
 	prometheusClient api.Client
-	queryAddr        string
+	addr             string
+}
+
+var _ metrics.OptionsProvider = (*Client)(nil)
+
+func init() {
+	metrics.Register(policy.ProviderPrometheus, newFromConfig)
+}
+
+// newFromConfig satisfies the metrics.Factory func, building the Prometheus client if the server
+// has been configured with a Prometheus address.
+func newFromConfig(cfg *server.MetricProviderConfig, log zerolog.Logger) (metrics.Provider, error) {
+	if cfg.Prometheus == nil {
+		return nil, nil
+	}
+	return NewClient(cfg.Prometheus, log)
 }
 
-// NewClient takes the base Prometheus API address and build the client for use in retrieving
+// NewClient takes the Prometheus provider config and builds the client for use in retrieving
 // metric values.
-func NewClient(addr string, log zerolog.Logger) (metrics.Provider, error) {
-	client, err := api.NewClient(api.Config{Address: addr})
+func NewClient(cfg *server.MetricProviderPrometheusConfig, log zerolog.Logger) (metrics.Provider, error) {
+	rt, err := newRoundTripper(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := api.NewClient(api.Config{Address: cfg.Addr, RoundTripper: rt})
 	if err != nil {
 		return nil, err
 	}
 	return &Client{
 		logger:           log.With().Str("metric-provider", policy.ProviderPrometheus.String()).Logger(),
 		prometheusClient: client,
-		queryAddr:        addr + queryEndpoint,
+		addr:             cfg.Addr,
 	}, nil
 }
 
 // GetValue satisfies the GetValue function of the metrics.Provider interface.
 func (c *Client) GetValue(query string) (*float64, error) {
+	return c.GetValueWithOptions(query, &metrics.QueryOptions{Type: policy.QueryTypeInstant})
+}
+
+// GetValueWithOptions satisfies the GetValueWithOptions function of the metrics.OptionsProvider
+// interface.
+func (c *Client) GetValueWithOptions(query string, opts *metrics.QueryOptions) (*float64, error) {
 	defer sendMetrics.MeasureSince([]string{"autoscale", "prometheus", "get_value"}, time.Now())
 
 	// Gather the value and any error returned from attempting to call Prometheus; handling the
 	// error result via Sherpa telemetry.
-	value, err := c.getValue(query)
+	value, err := c.getValue(query, opts)
 	if err != nil {
 		sendMetrics.IncrCounter([]string{"autoscale", "prometheus", "error"}, 1)
 	} else {
@@ -75,10 +119,10 @@ func (c *Client) GetValue(query string) (*float64, error) {
 
 // getValue performs the Prometheus query work, allowing the interface implementation to handle end
 // state activities.
-func (c *Client) getValue(query string) (*float64, error) {
+func (c *Client) getValue(query string, opts *metrics.QueryOptions) (*float64, error) {
 	ctx := context.Background()
 
-	parsedURL, err := url.Parse(c.queryAddr + url.QueryEscape(query))
+	parsedURL, err := c.buildQueryURL(query, opts, time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -99,21 +143,111 @@ func (c *Client) getValue(query string) (*float64, error) {
 	if err := json.Unmarshal(bytes, &unmarshalResp); err != nil {
 		return nil, err
 	}
-	return c.getValueFromResp(&unmarshalResp)
+	return c.getValueFromResp(&unmarshalResp, opts.Reducer)
 }
 
-// getValueFromResp is used to get the single metric value from the Prometheus response.
-func (c *Client) getValueFromResp(resp *queryResp) (*float64, error) {
+// buildQueryURL builds the Prometheus API URL for the query, using the instant or range endpoint
+// depending on the query options.
+func (c *Client) buildQueryURL(query string, opts *metrics.QueryOptions, now time.Time) (*url.URL, error) {
+	params := url.Values{}
+	params.Set("query", query)
+
+	endpoint := queryEndpoint
+
+	if opts.Type == policy.QueryTypeRange {
+		endpoint = queryRangeEndpoint
+		params.Set("start", formatTime(now.Add(-opts.Window)))
+		params.Set("end", formatTime(now))
+		params.Set("step", strconv.FormatFloat(opts.Step.Seconds(), 'f', -1, 64))
+	}
+	return url.Parse(c.addr + endpoint + "?" + params.Encode())
+}
+
+// getValueFromResp is used to get the single metric value from the Prometheus response. If the
+// response contains more than one sample, the reducer is used to collapse them.
+func (c *Client) getValueFromResp(resp *queryResp, reducer policy.Reducer) (*float64, error) {
+	samples, err := samplesFromResp(resp)
+	if err != nil {
+		return nil, err
+	}
 
 	// If we do not have the correct number of results, do not guess, inform the client this is an
-	// error so they can fix the query.
-	if len(resp.Data.Result) < 1 || len(resp.Data.Result) > 1 {
+	// error so they can fix the query or configure a reducer.
+	if len(samples) < 1 || (reducer == "" && len(samples) > 1) {
 		return nil, errors.New("received incorrect length result list from Prometheus")
 	}
 
-	floatVal, err := strconv.ParseFloat(resp.Data.Result[0].Value[1].(string), 64)
+	// Order the samples oldest to newest so that reducers which depend on ordering, such as last,
+	// use the most recent sample across all series.
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].time < samples[j].time })
+
+	values := make([]float64, len(samples))
+	for i := range samples {
+		values[i] = samples[i].value
+	}
+
+	floatVal, err := metrics.Reduce(values, reducer)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to convert Prometheus metric value to float64")
+		return nil, err
 	}
 	return helper.Float64ToPointer(floatVal), nil
 }
+
+// samplesFromResp flattens the vector or matrix result into a list of samples. Samples with a NaN
+// value are dropped, as these cannot be meaningfully compared or reduced.
+func samplesFromResp(resp *queryResp) ([]sample, error) {
+	var raw [][]interface{}
+
+	for i := range resp.Data.Result {
+		switch resp.Data.ResultType {
+		case resultTypeMatrix:
+			raw = append(raw, resp.Data.Result[i].Values...)
+		case resultTypeVector, "":
+			raw = append(raw, resp.Data.Result[i].Value)
+		default:
+			return nil, errors.Errorf("unsupported Prometheus result type %s", resp.Data.ResultType)
+		}
+	}
+
+	out := make([]sample, 0, len(raw))
+
+	for i := range raw {
+		s, err := parseSample(raw[i])
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(s.value) {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// parseSample converts the raw Prometheus [<time>, "<value>"] pair into a sample.
+func parseSample(raw []interface{}) (sample, error) {
+	if len(raw) != 2 {
+		return sample{}, errors.New("received malformed sample from Prometheus")
+	}
+
+	ts, ok := raw[0].(float64)
+	if !ok {
+		return sample{}, errors.New("received malformed sample timestamp from Prometheus")
+	}
+
+	strVal, ok := raw[1].(string)
+	if !ok {
+		return sample{}, errors.New("received malformed sample value from Prometheus")
+	}
+
+	floatVal, err := strconv.ParseFloat(strVal, 64)
+	if err != nil {
+		return sample{}, errors.Wrap(err, "failed to convert Prometheus metric value to float64")
+	}
+	return sample{time: ts, value: floatVal}, nil
+}
+
+// formatTime formats the time as a Unix timestamp, as accepted by the Prometheus API.
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
@@ -0,0 +1,115 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/autoscale/metrics"
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetValueWithOptions(t *testing.T) {
+	testCases := []struct {
+		inputOpts        *metrics.QueryOptions
+		respBody         string
+		expectedEndpoint string
+		expectedOutput   *float64
+		expectedError    bool
+		name             string
+	}{
+		{
+			inputOpts:        &metrics.QueryOptions{Type: policy.QueryTypeInstant},
+			respBody:         `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1580000000,"42"]}]}}`,
+			expectedEndpoint: queryEndpoint,
+			expectedOutput:   helper.Float64ToPointer(42),
+			name:             "instant single series",
+		},
+		{
+			inputOpts:        &metrics.QueryOptions{Type: policy.QueryTypeInstant},
+			respBody:         `{"status":"success","data":{"resultType":"vector","result":[{"value":[1580000000,"1"]},{"value":[1580000000,"2"]}]}}`,
+			expectedEndpoint: queryEndpoint,
+			expectedError:    true,
+			name:             "instant multi series without reducer",
+		},
+		{
+			inputOpts:        &metrics.QueryOptions{Type: policy.QueryTypeInstant, Reducer: policy.ReducerSum},
+			respBody:         `{"status":"success","data":{"resultType":"vector","result":[{"value":[1580000000,"1"]},{"value":[1580000000,"2"]}]}}`,
+			expectedEndpoint: queryEndpoint,
+			expectedOutput:   helper.Float64ToPointer(3),
+			name:             "instant multi series with sum reducer",
+		},
+		{
+			inputOpts: &metrics.QueryOptions{
+				Type:    policy.QueryTypeRange,
+				Window:  5 * time.Minute,
+				Step:    time.Minute,
+				Reducer: policy.ReducerMax,
+			},
+			respBody: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"values":[[1580000000,"1"],[1580000060,"7"]]},{"values":[[1580000000,"3"],[1580000060,"NaN"]]}]}}`,
+			expectedEndpoint: queryRangeEndpoint,
+			expectedOutput:   helper.Float64ToPointer(7),
+			name:             "range multi series with max reducer",
+		},
+		{
+			inputOpts: &metrics.QueryOptions{
+				Type:    policy.QueryTypeRange,
+				Window:  5 * time.Minute,
+				Step:    time.Minute,
+				Reducer: policy.ReducerLast,
+			},
+			respBody: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"values":[[1580000060,"9"],[1580000120,"4"]]},{"values":[[1580000000,"3"]]}]}}`,
+			expectedEndpoint: queryRangeEndpoint,
+			expectedOutput:   helper.Float64ToPointer(4),
+			name:             "range with last reducer uses newest sample",
+		},
+		{
+			inputOpts: &metrics.QueryOptions{
+				Type:    policy.QueryTypeRange,
+				Window:  5 * time.Minute,
+				Step:    time.Minute,
+				Reducer: policy.ReducerAvg,
+			},
+			respBody:         `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			expectedEndpoint: queryRangeEndpoint,
+			expectedError:    true,
+			name:             "range with no results",
+		},
+	}
+
+	for _, tc := range testCases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, tc.expectedEndpoint, r.URL.Path, tc.name)
+			assert.Equal(t, "sum(rate(http_requests_total[1m]))", r.URL.Query().Get("query"), tc.name)
+
+			if tc.inputOpts.Type == policy.QueryTypeRange {
+				assert.NotEmpty(t, r.URL.Query().Get("start"), tc.name)
+				assert.NotEmpty(t, r.URL.Query().Get("end"), tc.name)
+				assert.Equal(t, "60", r.URL.Query().Get("step"), tc.name)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, tc.respBody)
+		}))
+
+		client, err := NewClient(&server.MetricProviderPrometheusConfig{Addr: srv.URL}, zerolog.Logger{})
+		assert.Nil(t, err, tc.name)
+
+		actualOutput, err := client.(metrics.OptionsProvider).GetValueWithOptions("sum(rate(http_requests_total[1m]))", tc.inputOpts)
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+		}
+		srv.Close()
+	}
+}
@@ -0,0 +1,89 @@
+package prometheus
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-rootcerts"
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/pkg/errors"
+)
+
+// authRoundTripper wraps a http.RoundTripper, decorating each request with the configured
+// authentication details and custom headers.
+type authRoundTripper struct {
+	cfg  *server.MetricProviderPrometheusConfig
+	next http.RoundTripper
+}
+
+// newRoundTripper builds the http.RoundTripper used by the Prometheus client, configuring TLS and
+// authentication from the provider config.
+func newRoundTripper(cfg *server.MetricProviderPrometheusConfig) (http.RoundTripper, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+
+	tlsCfg, err := buildTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsCfg
+
+	return &authRoundTripper{cfg: cfg, next: transport}, nil
+}
+
+// buildTLSConfig creates the TLS config used to communicate with Prometheus, loading the CA and
+// client certificate if configured.
+func buildTLSConfig(cfg *server.MetricProviderPrometheusConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{InsecureSkipVerify: cfg.TLSSkipVerify}
+
+	if cfg.CACertPath != "" {
+		if err := rootcerts.ConfigureTLS(tlsCfg, &rootcerts.Config{CAFile: cfg.CACertPath}); err != nil {
+			return nil, errors.Wrap(err, "failed to load Prometheus CA cert")
+		}
+	}
+
+	switch {
+	case cfg.ClientCertPath != "" && cfg.ClientCertKeyPath != "":
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientCertKeyPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load Prometheus client cert")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	case cfg.ClientCertPath != "" || cfg.ClientCertKeyPath != "":
+		return nil, errors.New("both Prometheus client cert and key must be provided")
+	}
+	return tlsCfg, nil
+}
+
+// RoundTrip satisfies the http.RoundTripper interface.
+func (a *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	// The RoundTripper should not modify the original request, so take a copy including a copy of
+	// the headers before decorating.
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+
+	for k, v := range a.cfg.Headers {
+		r.Header.Set(k, v)
+	}
+
+	// The bearer token is read from file on every request so that rotated tokens are picked up
+	// without requiring a Sherpa restart. It takes precedence over basic auth.
+	switch {
+	case a.cfg.BearerTokenFile != "":
+		token, err := ioutil.ReadFile(a.cfg.BearerTokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read Prometheus bearer token file")
+		}
+		r.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	case a.cfg.BasicAuthUsername != "":
+		r.SetBasicAuth(a.cfg.BasicAuthUsername, a.cfg.BasicAuthPassword)
+	}
+	return a.next.RoundTrip(r)
+}
@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/pkg/errors"
+)
+
+// QueryOptions controls how a query is run against a provider, and how the returned values are
+// collapsed into the single value used for comparison.
+type QueryOptions struct {
+
+	// Type is the query type to run; either an instant or range query.
+	Type policy.QueryType
+
+	// Window is the lookback period used when running a range query.
+	Window time.Duration
+
+	// Step is the resolution step used when running a range query.
+	Step time.Duration
+
+	// Reducer is the function used to collapse multiple values into one. If this is empty, the
+	// query must return exactly one value.
+	Reducer policy.Reducer
+}
+
+// OptionsProvider is an optional interface which a Provider can implement if it supports running
+// queries with QueryOptions.
+type OptionsProvider interface {
+	Provider
+
+	// GetValueWithOptions takes a query string and options, returning the resulting metric value
+	// as a float64 along with an error if one was encountered.
+	GetValueWithOptions(query string, opts *QueryOptions) (*float64, error)
+}
+
+// NewQueryOptions builds the QueryOptions from an external check, applying defaults where the
+// check does not specify a value.
+func NewQueryOptions(check *policy.ExternalCheck) *QueryOptions {
+	opts := QueryOptions{
+		Type:    check.QueryType,
+		Reducer: check.Reducer,
+	}
+
+	if opts.Type == "" {
+		opts.Type = policy.QueryTypeInstant
+	}
+
+	if opts.Type == policy.QueryTypeRange {
+		opts.Window = time.Duration(check.QueryWindow) * time.Second
+
+		step := check.QueryStep
+		if step == 0 {
+			step = policy.DefaultQueryStep
+		}
+		opts.Step = time.Duration(step) * time.Second
+
+		// The step cannot be larger than the window, otherwise the query would return at most a
+		// single value and likely none.
+		if opts.Step > opts.Window {
+			opts.Step = opts.Window
+		}
+
+		if opts.Reducer == "" {
+			opts.Reducer = policy.DefaultReducer
+		}
+	}
+	return &opts
+}
+
+// Reduce collapses the values into a single value using the reducer. The values are expected to be
+// ordered oldest to newest, which is important for the last reducer. If the reducer is empty,
+// exactly one value must be passed.
+func Reduce(values []float64, reducer policy.Reducer) (float64, error) {
+	if len(values) < 1 {
+		return 0, errors.New("no values available to reduce")
+	}
+
+	switch reducer {
+	case "":
+		if len(values) != 1 {
+			return 0, errors.Errorf("received %v values but no reducer is configured", len(values))
+		}
+		return values[0], nil
+	case policy.ReducerLast:
+		return values[len(values)-1], nil
+	case policy.ReducerSum, policy.ReducerAvg:
+		var sum float64
+		for i := range values {
+			sum += values[i]
+		}
+		if reducer == policy.ReducerAvg {
+			return sum / float64(len(values)), nil
+		}
+		return sum, nil
+	case policy.ReducerMax:
+		max := values[0]
+		for i := range values {
+			max = math.Max(max, values[i])
+		}
+		return max, nil
+	case policy.ReducerMin:
+		min := values[0]
+		for i := range values {
+			min = math.Min(min, values[i])
+		}
+		return min, nil
+	case policy.ReducerP95:
+		return percentile(values, 95), nil
+	default:
+		return 0, errors.Errorf("Reducer %s is not a valid option", reducer.String())
+	}
+}
+
+// percentile calculates the nearest-rank percentile of the values. The input slice is not
+// modified.
+func percentile(values []float64, p float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
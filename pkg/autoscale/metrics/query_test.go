@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReduce(t *testing.T) {
+	testCases := []struct {
+		inputValues    []float64
+		inputReducer   policy.Reducer
+		expectedOutput float64
+		expectedError  bool
+		name           string
+	}{
+		{inputValues: []float64{7}, inputReducer: "", expectedOutput: 7, name: "single value no reducer"},
+		{inputValues: []float64{7, 8}, inputReducer: "", expectedError: true, name: "multiple values no reducer"},
+		{inputValues: []float64{}, inputReducer: policy.ReducerAvg, expectedError: true, name: "no values"},
+		{inputValues: []float64{1, 2, 3, 6}, inputReducer: policy.ReducerAvg, expectedOutput: 3, name: "avg"},
+		{inputValues: []float64{1, 9, 3}, inputReducer: policy.ReducerMax, expectedOutput: 9, name: "max"},
+		{inputValues: []float64{4, 2, 3}, inputReducer: policy.ReducerMin, expectedOutput: 2, name: "min"},
+		{inputValues: []float64{1, 2, 3}, inputReducer: policy.ReducerSum, expectedOutput: 6, name: "sum"},
+		{inputValues: []float64{1, 2, 3}, inputReducer: policy.ReducerLast, expectedOutput: 3, name: "last"},
+		{
+			inputValues:    []float64{20, 19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			inputReducer:   policy.ReducerP95,
+			expectedOutput: 19,
+			name:           "p95",
+		},
+		{inputValues: []float64{1}, inputReducer: "median", expectedError: true, name: "invalid reducer"},
+	}
+
+	for _, tc := range testCases {
+		actualOutput, err := Reduce(tc.inputValues, tc.inputReducer)
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.name)
+	}
+}
+
+func TestNewQueryOptions(t *testing.T) {
+	testCases := []struct {
+		inputCheck     *policy.ExternalCheck
+		expectedOutput *QueryOptions
+		name           string
+	}{
+		{
+			inputCheck:     &policy.ExternalCheck{},
+			expectedOutput: &QueryOptions{Type: policy.QueryTypeInstant},
+			name:           "empty check defaults to instant",
+		},
+		{
+			inputCheck:     &policy.ExternalCheck{Reducer: policy.ReducerMax},
+			expectedOutput: &QueryOptions{Type: policy.QueryTypeInstant, Reducer: policy.ReducerMax},
+			name:           "instant query with reducer",
+		},
+		{
+			inputCheck: &policy.ExternalCheck{QueryType: policy.QueryTypeRange, QueryWindow: 600},
+			expectedOutput: &QueryOptions{
+				Type:    policy.QueryTypeRange,
+				Window:  10 * time.Minute,
+				Step:    time.Minute,
+				Reducer: policy.DefaultReducer,
+			},
+			name: "range query with defaults",
+		},
+		{
+			inputCheck: &policy.ExternalCheck{QueryType: policy.QueryTypeRange, QueryWindow: 30, Reducer: policy.ReducerP95},
+			expectedOutput: &QueryOptions{
+				Type:    policy.QueryTypeRange,
+				Window:  30 * time.Second,
+				Step:    30 * time.Second,
+				Reducer: policy.ReducerP95,
+			},
+			name: "range query default step clamped to window",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, NewQueryOptions(tc.inputCheck), tc.name)
+	}
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/rs/zerolog"
+)
+
+// Factory is the function used to build a Provider from the Sherpa server metric provider config.
+// If the config does not contain the block required by the Provider, the Factory should return a
+// nil Provider and nil error, indicating the Provider is not configured for use.
+type Factory func(cfg *server.MetricProviderConfig, logger zerolog.Logger) (Provider, error)
+
+var (
+	factories     = make(map[policy.MetricsProvider]Factory)
+	factoriesLock sync.RWMutex
+)
+
+// Register adds the named Provider Factory to the registry. The name is also registered as a valid
+// policy.MetricsProvider so that external checks using it pass policy validation. Registering the
+// same name twice will panic, as this is always a programming error.
+func Register(name policy.MetricsProvider, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if factory == nil {
+		panic("metrics: register factory is nil for provider " + name.String())
+	}
+	if _, ok := factories[name]; ok {
+		panic("metrics: register called twice for provider " + name.String())
+	}
+	factories[name] = factory
+	policy.RegisterMetricsProvider(name)
+}
+
+// SetupProviders iterates the registered Provider factories, building each Provider which has
+// configuration available. Providers which fail to build are logged and skipped, so that a single
+// misconfigured provider does not prevent the others from being used.
+func SetupProviders(cfg *server.MetricProviderConfig, logger zerolog.Logger) map[policy.MetricsProvider]Provider {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	out := make(map[policy.MetricsProvider]Provider)
+
+	if cfg == nil {
+		return out
+	}
+
+	for name, factory := range factories {
+		p, err := factory(cfg, logger)
+		if err != nil {
+			logger.Error().Err(err).Str("metric-provider", name.String()).Msg("failed to setup metric provider client")
+			continue
+		}
+		if p != nil {
+			out[name] = p
+		}
+	}
+	return out
+}
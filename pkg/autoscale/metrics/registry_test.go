@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProvider struct{}
+
+func (fakeProvider) GetValue(string) (*float64, error) { return nil, nil }
+
+func TestRegister(t *testing.T) {
+	const (
+		configuredProvider   policy.MetricsProvider = "test-configured"
+		unconfiguredProvider policy.MetricsProvider = "test-unconfigured"
+		failingProvider      policy.MetricsProvider = "test-failing"
+	)
+
+	Register(configuredProvider, func(*server.MetricProviderConfig, zerolog.Logger) (Provider, error) {
+		return fakeProvider{}, nil
+	})
+	Register(unconfiguredProvider, func(*server.MetricProviderConfig, zerolog.Logger) (Provider, error) {
+		return nil, nil
+	})
+	Register(failingProvider, func(*server.MetricProviderConfig, zerolog.Logger) (Provider, error) {
+		return nil, errors.New("failed")
+	})
+
+	// Registered names should be considered valid by the policy validation.
+	assert.Nil(t, configuredProvider.Validate())
+	assert.Nil(t, unconfiguredProvider.Validate())
+
+	providers := SetupProviders(&server.MetricProviderConfig{}, zerolog.Nop())
+	assert.Contains(t, providers, configuredProvider)
+	assert.NotContains(t, providers, unconfiguredProvider)
+	assert.NotContains(t, providers, failingProvider)
+
+	assert.Panics(t, func() {
+		Register(configuredProvider, func(*server.MetricProviderConfig, zerolog.Logger) (Provider, error) {
+			return nil, nil
+		})
+	})
+}
@@ -2,6 +2,7 @@ package autoscale
 
 import (
 	nomad "github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/pkg/errors"
 )
@@ -17,8 +18,12 @@ type nomadResources struct {
 }
 
 const (
-	nomadCPUMetricName    = "nomad-cpu"
-	nomadMemoryMetricName = "nomad-memory"
+	nomadCPUMetricName    = policy.NomadCPUCheckName
+	nomadMemoryMetricName = policy.NomadMemoryCheckName
+
+	// nomadMetricsCheckName is the check name used to explain failures to gather Nomad metrics for
+	// a job group.
+	nomadMetricsCheckName = "nomad"
 )
 
 // gatherNomadMetrics queries Nomad to produce Nomad resource allocation metrics for the job
@@ -49,12 +54,23 @@ func (ae *autoscaleEvaluation) gatherNomadMetrics() (*nomadGatheredMetrics, erro
 }
 
 func (ae *autoscaleEvaluation) evaluateNomadJobMetrics(group string, pol *policy.GroupScalingPolicy, resources *nomadGatheredMetrics) *scalingDecision {
+	use := ae.groupNomadResources(group, resources)
+	if use == nil {
+		return nil
+	}
+	return ae.calculateNomadScalingDecision(group, use, pol)
+}
+
+// groupNomadResources calculates the CPU and memory utilisation percentages of the job group from
+// the gathered Nomad metrics. A nil return indicates the group was not found within the job.
+func (ae *autoscaleEvaluation) groupNomadResources(group string, resources *nomadGatheredMetrics) *nomadResources {
 
 	// It is possible a scaling policy is configured for a job group, but the actual running
 	// Nomad job doesn't have this job group configured. If this is the case, we should warn
 	// the user in the logs and break the current loop.
 	if _, ok := resources.resourceUsage[group]; !ok {
 		ae.log.Warn().Str("group", group).Msg("job group found in policy but not found in Nomad job")
+		ae.explain.checkError(group, nomadMetricsCheckName, CheckTypeNomad, "job group found in policy but not found in Nomad job")
 		return nil
 	}
 
@@ -69,14 +85,16 @@ func (ae *autoscaleEvaluation) evaluateNomadJobMetrics(group string, pol *policy
 		Float64("cpu-value-percentage", cpuUsage).
 		Msg("Nomad resource utilisation calculation")
 
-	return ae.calculateNomadScalingDecision(group, &nomadResources{cpu: cpuUsage, mem: memUsage}, pol)
+	return &nomadResources{cpu: cpuUsage, mem: memUsage}
 }
 
 func (ae *autoscaleEvaluation) getJobAllocations() (map[string]*nomadResources, []*nomad.Allocation, error) {
 	out := make(map[string]*nomadResources)
 	var allocList []*nomad.Allocation // nolint:prealloc
 
-	allocs, _, err := ae.nomad.Jobs().Allocations(ae.jobID, false, nil)
+	jobID, q := nomadJobQuery(ae.jobID)
+
+	allocs, _, err := ae.nomad.Jobs().Allocations(jobID, false, q)
 	if err != nil {
 		return out, nil, err
 	}
@@ -97,7 +115,7 @@ func (ae *autoscaleEvaluation) getJobAllocations() (map[string]*nomadResources,
 			continue
 		}
 
-		allocInfo, _, err := ae.nomad.Allocations().Info(allocs[i].ID, nil)
+		allocInfo, _, err := ae.nomad.Allocations().Info(allocs[i].ID, q)
 		if err != nil {
 			return out, nil, err
 		}
@@ -135,3 +153,35 @@ func updateResourceTracker(group string, cpu, mem float64, tracker map[string]*n
 	}
 	tracker[group] = &nomadResources{cpu: cpu, mem: mem}
 }
+
+// getJobGroupCounts queries Nomad for the job under evaluation, returning the current count of each
+// job group.
+func (ae *autoscaleEvaluation) getJobGroupCounts() (map[string]int, error) {
+	job, _, err := ae.nomad.Jobs().Info(nomadJobQuery(ae.jobID))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]int)
+	for _, tg := range job.TaskGroups {
+		if tg.Name != nil && tg.Count != nil {
+			out[*tg.Name] = *tg.Count
+		}
+	}
+	return out, nil
+}
+
+// nomadJobQuery splits the Sherpa job identifier into the Nomad job ID and the query options which
+// target the namespace of the job. The region of the job is used to select the Nomad client, and
+// is therefore not included.
+func nomadJobQuery(job string) (string, *nomad.QueryOptions) {
+	_, namespaced := helper.SplitRegionalJobID(job)
+	ns, id := helper.SplitNamespacedJobID(namespaced)
+	return id, &nomad.QueryOptions{Namespace: ns}
+}
+
+// nomadClient returns the Nomad API client of the target which runs the job.
+func (a *AutoScale) nomadClient(job string) (*nomad.Client, error) {
+	region, _ := helper.SplitRegionalJobID(job)
+	return a.nomad.Client(region)
+}
@@ -0,0 +1,116 @@
+package autoscale
+
+import (
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+)
+
+// predictiveMetricName is the decision metric name used to identify predictive scaling decisions
+// within the scaling event meta.
+const predictiveMetricName = "predicted-count"
+
+// predictiveConfigured identifies whether the group policy has predictive config, and the
+// autoscaler is able to act upon it.
+func (ae *autoscaleEvaluation) predictiveConfigured(p *policy.GroupScalingPolicy) bool {
+	return ae.predictor != nil && p.Predictive != nil
+}
+
+// evaluatePredictive records the current state of the job group within its baseline, and if
+// predictive scaling is enabled, uses the baseline to decide whether the group should scale out
+// ahead of predicted load.
+func (ae *autoscaleEvaluation) evaluatePredictive(group string, pol *policy.GroupScalingPolicy) *scalingDecision {
+	current, ok := ae.groupCounts[group]
+	if !ok {
+		ae.log.Warn().Str("group", group).Msg("job group count not found, skipping predictive check")
+		ae.explain.checkError(group, predictiveMetricName, CheckTypePredictive, "job group count not found")
+		return nil
+	}
+
+	// Gather the check value if the baseline is built using an external check. A failure to get
+	// the value is logged by the call, and the count is still recorded.
+	var value *float64
+	if check, ok := pol.ExternalChecks[pol.Predictive.Check]; ok {
+		value = ae.getExternalMetricValue(group, pol.Predictive.Check, check)
+	}
+
+	t := time.Unix(0, ae.time)
+
+	// Explaining an evaluation must not have side effects, so the baseline is only recorded
+	// during normal evaluations.
+	if !ae.explainOnly {
+		if err := ae.predictor.Record(ae.jobID, group, pol, t, value, current); err != nil {
+			ae.log.Error().Err(err).Str("group", group).Msg("failed to record job group predictive baseline")
+		}
+	}
+
+	// The baseline is always recorded so operators can review predictions before enabling
+	// predictive scaling actions.
+	if !pol.Predictive.Enabled {
+		return nil
+	}
+
+	prediction, err := ae.predictor.Predict(ae.jobID, group, pol, current, t)
+	if err != nil {
+		ae.log.Error().Err(err).Str("group", group).Msg("failed to predict job group count")
+		ae.explain.checkError(group, predictiveMetricName, CheckTypePredictive,
+			"failed to predict job group count: "+err.Error())
+		return nil
+	}
+
+	if !prediction.ScaleOut {
+		ae.explain.predictiveCheck(group, prediction.DesiredCount, current, nil)
+		return nil
+	}
+
+	ae.log.Info().
+		Str("group", group).
+		Int("current-count", current).
+		Int("predicted-count", prediction.DesiredCount).
+		Msg("predictive scale out required ahead of predicted load")
+
+	dec := &scalingDecision{
+		direction: scale.DirectionOut,
+		count:     prediction.DesiredCount,
+		absolute:  true,
+		metrics: map[string]*scalingMetricDecision{
+			predictiveMetricName: {value: float64(prediction.DesiredCount), threshold: float64(current)},
+		},
+	}
+	ae.explain.predictiveCheck(group, prediction.DesiredCount, current, dec)
+	return dec
+}
+
+// mergePredictiveDecisions merges the predictive decisions into the reactive decisions. The
+// prediction is used unless the reactive decision is to scale out to a larger count.
+func (ae *autoscaleEvaluation) mergePredictiveDecisions(reactive, predictive map[string]*scalingDecision) map[string]*scalingDecision {
+	if reactive == nil {
+		reactive = make(map[string]*scalingDecision)
+	}
+
+	for group, predDec := range predictive {
+		dec, ok := reactive[group]
+		if !ok || dec.direction != scale.DirectionOut {
+			reactive[group] = predDec
+			continue
+		}
+
+		target := dec.count
+		if dec.percentage {
+			target = ae.policies[group].AdjustmentCount(ae.groupCounts[group], dec.count)
+		}
+		if !dec.absolute {
+			target += ae.groupCounts[group]
+		}
+		if target >= predDec.count {
+			continue
+		}
+
+		for key, metric := range dec.metrics {
+			predDec.metrics[key] = metric
+		}
+		reactive[group] = predDec
+	}
+	return reactive
+}
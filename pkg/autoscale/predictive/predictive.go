@@ -0,0 +1,269 @@
+package predictive
+
+import (
+	"math"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/baseline"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// secondsInWeek is the length of the seasonal baseline period.
+const secondsInWeek = 7 * 24 * 60 * 60
+
+// Predictor builds and stores weekly seasonal baselines for job groups, and uses these to predict
+// the job group count required in the near future.
+type Predictor struct {
+	logger  zerolog.Logger
+	backend baseline.Backend
+}
+
+// Prediction is the result of a job group prediction, detailing the predicted count along with the
+// baseline data points used to calculate it.
+type Prediction struct {
+	CurrentCount int
+	DesiredCount int
+
+	// ScaleOut indicates whether the prediction requires the job group to scale out.
+	ScaleOut bool
+
+	// Points are the baseline data points within the look ahead period, which are used to
+	// calculate the desired count.
+	Points []*PredictionPoint
+}
+
+// PredictionPoint is a single baseline bucket within the prediction look ahead period.
+type PredictionPoint struct {
+
+	// Time is a UnixNano timestamp of the point within the look ahead period.
+	Time int64
+
+	// Value is the predicted job group load, if the baseline is built using an external check.
+	Value *float64 `json:",omitempty"`
+
+	// Count is the predicted job group count based on the historic counts.
+	Count *float64 `json:",omitempty"`
+
+	// Weeks is the number of completed weeks the bucket is built from which were used for the
+	// prediction.
+	Weeks int
+
+	// DesiredCount is the job group count required to handle the predicted load. This is only
+	// set if the bucket is built from enough weeks to be trusted.
+	DesiredCount *int `json:",omitempty"`
+}
+
+// NewPredictor returns a new Predictor which stores baselines using the passed backend.
+func NewPredictor(log zerolog.Logger, backend baseline.Backend) *Predictor {
+	return &Predictor{
+		logger:  log,
+		backend: backend,
+	}
+}
+
+// Record adds the job group check value and count observed at the passed time to the job group
+// baseline. Samples are averaged within the week they were recorded, and the weekly average is
+// added to the baseline once the week has passed. A nil value indicates the check value could not
+// be obtained, or the baseline is built from counts alone.
+func (p *Predictor) Record(job, group string, pol *policy.GroupScalingPolicy, t time.Time, value *float64, count int) error {
+	if pol.Predictive == nil {
+		return errors.New("group policy does not have predictive config")
+	}
+	cfg := pol.Predictive.MergeWithDefaults()
+
+	b, err := p.backend.GetBaseline(job, group)
+	if err != nil {
+		return errors.Wrap(err, "failed to get baseline from backend")
+	}
+
+	// If the bucket size has changed, the existing baseline cannot be mapped onto the new buckets
+	// and so the baseline is reset.
+	if b == nil || b.BucketSize != cfg.BucketSize {
+		b = &state.Baseline{BucketSize: cfg.BucketSize, Buckets: make(map[int]*state.BaselineBucket)}
+	}
+
+	week := weekIndex(t)
+
+	idx := bucketIndex(t, cfg.BucketSize)
+	bucket, ok := b.Buckets[idx]
+	if !ok {
+		bucket = &state.BaselineBucket{Week: week}
+		b.Buckets[idx] = bucket
+	}
+
+	// Samples older than the week already being recorded cannot be added without altering the
+	// completed weeks, and so are discarded.
+	if week < bucket.Week {
+		return nil
+	}
+	completeWeek(bucket, week)
+
+	bucket.WeekCountSamples++
+	bucket.WeekCount += (float64(count) - bucket.WeekCount) / float64(bucket.WeekCountSamples)
+
+	if value != nil {
+		load := *value
+		if !cfg.Aggregate {
+			load *= float64(count)
+		}
+		bucket.WeekValueSamples++
+		bucket.WeekValue += (load - bucket.WeekValue) / float64(bucket.WeekValueSamples)
+	}
+	b.Updated = t.UnixNano()
+
+	return p.backend.PutBaseline(job, group, b)
+}
+
+// Predict uses the job group baseline to calculate the job group count required to handle the
+// load predicted within the look ahead period starting at the passed time.
+func (p *Predictor) Predict(job, group string, pol *policy.GroupScalingPolicy, current int, t time.Time) (*Prediction, error) {
+	if pol.Predictive == nil {
+		return nil, errors.New("group policy does not have predictive config")
+	}
+	cfg := pol.Predictive.MergeWithDefaults()
+	target := cfg.Target(pol.ExternalChecks)
+
+	b, err := p.backend.GetBaseline(job, group)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get baseline from backend")
+	}
+
+	out := Prediction{CurrentCount: current, DesiredCount: current}
+
+	if b == nil || b.BucketSize != cfg.BucketSize {
+		return &out, nil
+	}
+
+	desired := -1
+
+	for offset := 0; offset <= cfg.LookAhead; offset += cfg.BucketSize {
+		pointTime := t.Add(time.Duration(offset) * time.Second)
+
+		bucket, ok := b.Buckets[bucketIndex(pointTime, cfg.BucketSize)]
+		if !ok {
+			continue
+		}
+
+		// The bucket is copied so that the samples recorded within a prior week, but not yet added
+		// to the baseline, are included without modifying the stored baseline. Samples recorded
+		// within the same week as the point are excluded.
+		completed := *bucket
+		completeWeek(&completed, weekIndex(pointTime))
+
+		point := buildPoint(&completed, pointTime, target, cfg.MinSamples)
+		out.Points = append(out.Points, point)
+
+		if point.DesiredCount != nil && *point.DesiredCount > desired {
+			desired = *point.DesiredCount
+		}
+	}
+
+	// If none of the buckets held enough samples, the baseline cannot be trusted and so no change
+	// is predicted.
+	if desired < 0 {
+		return &out, nil
+	}
+
+	if desired < pol.MinCount {
+		desired = pol.MinCount
+	}
+	if desired > pol.MaxCount {
+		desired = pol.MaxCount
+	}
+
+	out.DesiredCount = desired
+	out.ScaleOut = desired > current
+
+	p.logger.Debug().
+		Str("job", job).
+		Str("group", group).
+		Int("current-count", current).
+		Int("predicted-count", desired).
+		Msg("predictive desired count calculation")
+
+	return &out, nil
+}
+
+// buildPoint converts the completed weeks of a baseline bucket into a prediction point. The check
+// value is preferred when available, falling back to the count history.
+func buildPoint(bucket *state.BaselineBucket, t time.Time, target *float64, minWeeks int) *PredictionPoint {
+	point := PredictionPoint{Time: t.UnixNano()}
+
+	if bucket.CountWeeks > 0 {
+		count := bucket.Count
+		point.Count = &count
+		point.Weeks = bucket.CountWeeks
+	}
+
+	if target != nil && bucket.ValueWeeks > 0 {
+		value := bucket.Value
+		point.Value = &value
+		point.Weeks = bucket.ValueWeeks
+
+		if bucket.ValueWeeks >= minWeeks {
+			desired := int(math.Ceil(value / *target))
+			point.DesiredCount = &desired
+			return &point
+		}
+	}
+
+	if bucket.CountWeeks >= minWeeks {
+		desired := int(math.Ceil(bucket.Count))
+		point.Weeks = bucket.CountWeeks
+		point.DesiredCount = &desired
+	}
+	return &point
+}
+
+// completeWeek adds the averages of the samples recorded within the bucket week to the baseline if
+// the week is prior to the passed week, and moves the bucket on to the passed week.
+func completeWeek(bucket *state.BaselineBucket, week int64) {
+	if bucket.Week >= week {
+		return
+	}
+
+	if bucket.WeekCountSamples > 0 {
+		bucket.CountWeeks++
+		bucket.Count = smooth(bucket.Count, bucket.WeekCount, bucket.CountWeeks)
+	}
+	if bucket.WeekValueSamples > 0 {
+		bucket.ValueWeeks++
+		bucket.Value = smooth(bucket.Value, bucket.WeekValue, bucket.ValueWeeks)
+	}
+
+	bucket.Week = week
+	bucket.WeekCount, bucket.WeekCountSamples = 0, 0
+	bucket.WeekValue, bucket.WeekValueSamples = 0, 0
+}
+
+// weekIndex returns the number of weeks between the Unix epoch and the week which the time falls
+// within, using the same Sunday 00:00 UTC week start as the bucket index. The Unix epoch was a
+// Thursday.
+func weekIndex(t time.Time) int64 {
+	return (t.Unix() + 4*86400) / secondsInWeek
+}
+
+// bucketIndex returns the index of the baseline bucket which the time falls within. The week
+// starts Sunday 00:00 UTC.
+func bucketIndex(t time.Time, bucketSize int) int {
+	u := t.UTC()
+	secs := int(u.Weekday())*86400 + u.Hour()*3600 + u.Minute()*60 + u.Second()
+	return (secs % secondsInWeek) / bucketSize
+}
+
+// smooth updates the running value with the value of a new week. Early weeks are averaged, after
+// which an exponentially weighted moving average is used so the baseline follows changes in load
+// over the weeks.
+func smooth(cur, sample float64, weeks int) float64 {
+	alpha := 1 / float64(weeks)
+	if alpha < minSmoothingFactor {
+		alpha = minSmoothingFactor
+	}
+	return cur + alpha*(sample-cur)
+}
+
+// minSmoothingFactor is the minimum weight given to a new week when updating a baseline bucket.
+const minSmoothingFactor = 0.2
@@ -0,0 +1,170 @@
+package predictive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state/baseline/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bucketIndex(t *testing.T) {
+	testCases := []struct {
+		time           time.Time
+		bucketSize     int
+		expectedOutput int
+	}{
+		{
+			time:           time.Date(2019, 11, 3, 0, 0, 0, 0, time.UTC),
+			bucketSize:     900,
+			expectedOutput: 0,
+		},
+		{
+			time:           time.Date(2019, 11, 4, 9, 14, 59, 0, time.UTC),
+			bucketSize:     900,
+			expectedOutput: 132,
+		},
+		{
+			time:           time.Date(2019, 11, 9, 23, 59, 59, 0, time.UTC),
+			bucketSize:     3600,
+			expectedOutput: 167,
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, bucketIndex(tc.time, tc.bucketSize))
+	}
+}
+
+func Test_smooth(t *testing.T) {
+	assert.Equal(t, float64(10), smooth(0, 10, 1))
+	assert.Equal(t, float64(15), smooth(10, 20, 2))
+	assert.Equal(t, float64(12), smooth(10, 20, 10))
+}
+
+func TestPredictor_RecordPredict(t *testing.T) {
+	p := NewPredictor(zerolog.Nop(), memory.NewStateBackend())
+
+	pol := &policy.GroupScalingPolicy{
+		MinCount: 2,
+		MaxCount: 10,
+		ExternalChecks: map[string]*policy.ExternalCheck{
+			"requests": {Enabled: true, TargetValue: helper.Float64ToPointer(100)},
+		},
+		Predictive: &policy.Predictive{Enabled: true, Check: "requests", MinSamples: 2},
+	}
+
+	// Monday 09:00 is the start of the predicted ramp, which needs 6 allocations at 100 requests
+	// per allocation.
+	now := time.Date(2019, 11, 4, 8, 50, 0, 0, time.UTC)
+	ramp := time.Date(2019, 11, 4, 9, 0, 0, 0, time.UTC)
+
+	// With no baseline, no change is predicted.
+	prediction, err := p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, &Prediction{CurrentCount: 3, DesiredCount: 3}, prediction)
+
+	for week := 2; week >= 1; week-- {
+		past := ramp.AddDate(0, 0, -7*week)
+		assert.Nil(t, p.Record("example", "cache", pol, past, helper.Float64ToPointer(150), 4))
+	}
+
+	prediction, err = p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, prediction.DesiredCount)
+	assert.True(t, prediction.ScaleOut)
+	assert.Len(t, prediction.Points, 1)
+	assert.Equal(t, float64(600), *prediction.Points[0].Value)
+	assert.Equal(t, 2, prediction.Points[0].Weeks)
+
+	// The desired count is bounded by the policy maximum.
+	pol.MaxCount = 5
+	prediction, err = p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, prediction.DesiredCount)
+
+	// A change in bucket size resets the baseline.
+	pol.Predictive.BucketSize = 3600
+	assert.Nil(t, p.Record("example", "cache", pol, ramp.AddDate(0, 0, -7), nil, 4))
+
+	prediction, err = p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, prediction.DesiredCount)
+	assert.False(t, prediction.ScaleOut)
+}
+
+func Test_weekIndex(t *testing.T) {
+	sunday := time.Date(2019, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, weekIndex(sunday), weekIndex(time.Date(2019, 11, 9, 23, 59, 59, 0, time.UTC)))
+	assert.Equal(t, weekIndex(sunday)-1, weekIndex(sunday.Add(-time.Second)))
+	assert.Equal(t, weekIndex(sunday)+1, weekIndex(sunday.AddDate(0, 0, 7)))
+}
+
+func TestPredictor_weeklyBaseline(t *testing.T) {
+	p := NewPredictor(zerolog.Nop(), memory.NewStateBackend())
+
+	pol := &policy.GroupScalingPolicy{
+		MinCount:   1,
+		MaxCount:   100,
+		Predictive: &policy.Predictive{Enabled: true, MinSamples: 2},
+	}
+
+	now := time.Date(2019, 11, 4, 8, 50, 0, 0, time.UTC)
+	bucketTime := now.Add(5 * time.Minute)
+
+	// Many samples within a single week count as one week, and so do not satisfy MinSamples.
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, p.Record("example", "cache", pol, bucketTime.AddDate(0, 0, -14).Add(time.Duration(i)*time.Second), nil, 10))
+	}
+
+	prediction, err := p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, prediction.DesiredCount)
+	assert.Equal(t, 1, prediction.Points[0].Weeks)
+
+	// A second prior week at the same count satisfies MinSamples, regardless of the number of
+	// samples each week held.
+	assert.Nil(t, p.Record("example", "cache", pol, bucketTime.AddDate(0, 0, -7), nil, 10))
+
+	prediction, err = p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, prediction.DesiredCount)
+	assert.Equal(t, 2, prediction.Points[0].Weeks)
+
+	// A noisy sample recorded within the current week does not move the prediction.
+	assert.Nil(t, p.Record("example", "cache", pol, bucketTime.Add(-time.Minute), nil, 90))
+
+	prediction, err = p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, prediction.DesiredCount)
+	assert.Equal(t, float64(10), *prediction.Points[0].Count)
+	assert.Equal(t, 2, prediction.Points[0].Weeks)
+
+	// Once the week has passed, the current week is added to the baseline as a single week.
+	prediction, err = p.Predict("example", "cache", pol, 3, now.AddDate(0, 0, 7))
+	assert.Nil(t, err)
+	assert.Equal(t, 37, prediction.DesiredCount)
+	assert.Equal(t, 3, prediction.Points[0].Weeks)
+}
+
+func TestPredictor_PredictCountOnly(t *testing.T) {
+	p := NewPredictor(zerolog.Nop(), memory.NewStateBackend())
+
+	pol := &policy.GroupScalingPolicy{
+		MinCount:   1,
+		MaxCount:   10,
+		Predictive: &policy.Predictive{Enabled: true, MinSamples: 1},
+	}
+
+	now := time.Date(2019, 11, 4, 8, 50, 0, 0, time.UTC)
+	assert.Nil(t, p.Record("example", "cache", pol, now.AddDate(0, 0, -7).Add(10*time.Minute), nil, 7))
+
+	prediction, err := p.Predict("example", "cache", pol, 3, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 7, prediction.DesiredCount)
+	assert.True(t, prediction.ScaleOut)
+}
@@ -0,0 +1,70 @@
+package autoscale
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergePredictiveDecisions(t *testing.T) {
+	ae := autoscaleEvaluation{groupCounts: map[string]int{"web": 3, "cache": 3, "worker": 3, "db": 3}}
+
+	newPrediction := func(count int) *scalingDecision {
+		return &scalingDecision{
+			direction: scale.DirectionOut,
+			count:     count,
+			absolute:  true,
+			metrics: map[string]*scalingMetricDecision{
+				predictiveMetricName: {value: float64(count), threshold: 3},
+			},
+		}
+	}
+
+	reactive := map[string]*scalingDecision{
+		"web": {
+			direction: scale.DirectionIn,
+			count:     1,
+			metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 10, threshold: 20}},
+		},
+		"cache": {
+			direction: scale.DirectionOut,
+			count:     1,
+			metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 90, threshold: 80}},
+		},
+		"worker": {
+			direction: scale.DirectionOut,
+			count:     8,
+			absolute:  true,
+			metrics:   map[string]*scalingMetricDecision{"nomad-cpu": {value: 160, threshold: 60}},
+		},
+	}
+	predictive := map[string]*scalingDecision{
+		"web":    newPrediction(5),
+		"cache":  newPrediction(6),
+		"worker": newPrediction(6),
+		"db":     newPrediction(4),
+	}
+
+	actual := ae.mergePredictiveDecisions(reactive, predictive)
+
+	// The prediction replaces a reactive scale in.
+	assert.Equal(t, newPrediction(5), actual["web"])
+
+	// The prediction is larger than the reactive scale out, and so is used along with the
+	// reactive metrics.
+	assert.Equal(t, 6, actual["cache"].count)
+	assert.True(t, actual["cache"].absolute)
+	assert.Len(t, actual["cache"].metrics, 2)
+
+	// The reactive target tracking decision is larger than the prediction.
+	assert.Equal(t, 8, actual["worker"].count)
+	assert.Len(t, actual["worker"].metrics, 1)
+
+	// Groups without a reactive decision use the prediction.
+	assert.Equal(t, newPrediction(4), actual["db"])
+
+	// A nil reactive decision map is handled.
+	assert.Equal(t, map[string]*scalingDecision{"db": newPrediction(4)},
+		ae.mergePredictiveDecisions(nil, map[string]*scalingDecision{"db": newPrediction(4)}))
+}
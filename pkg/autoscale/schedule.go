@@ -0,0 +1,141 @@
+package autoscale
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// scheduleMetaKey is the scaling event meta key used to identify the schedule which triggered the
+// scaling event.
+const scheduleMetaKey = "schedule"
+
+// scheduledGroup tracks a job group which has an open schedule window during an autoscaling run.
+type scheduledGroup struct {
+
+	// name is the name of the open schedule within the group policy.
+	name string
+
+	// policy is the group policy with the schedule overrides applied.
+	policy *policy.GroupScalingPolicy
+}
+
+// enforceSchedules ensures the job groups with open schedule windows are running at a count within
+// the schedule bounds, triggering scaling where they are not. The returned map contains the groups
+// which were scaled, and therefore should not be evaluated by the autoscaler during this run.
+func (a *AutoScale) enforceSchedules(job string, scheduled map[string]*scheduledGroup, t time.Time) map[string]struct{} {
+	out := make(map[string]struct{})
+
+	nomadClient, err := a.nomadClient(job)
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to find Nomad target of job")
+		return out
+	}
+
+	info, _, err := nomadClient.Jobs().Info(nomadJobQuery(job))
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to call Nomad API for job information")
+		return out
+	}
+
+	counts := make(map[string]int)
+	for _, tg := range info.TaskGroups {
+		if tg.Name != nil && tg.Count != nil {
+			counts[*tg.Name] = *tg.Count
+		}
+	}
+
+	reqs, dryRunReqs := splitDryRunReqs(buildScheduleReqs(counts, scheduled, t), a.cfg.DryRun)
+
+	// Groups in dry-run mode only have their schedule enforcement recorded. They are not added to
+	// the returned map as their count has not changed.
+	if len(dryRunReqs) > 0 {
+		if resp, _, err := a.scaler.DryRun(job, dryRunReqs, state.SourceScheduler); err != nil {
+			a.logger.Error().Err(err).Str("job", job).Msg("failed to record dry-run scheduled scaling of job")
+		} else if resp != nil {
+			a.logger.Info().
+				Str("job", job).
+				Str("id", resp.ID.String()).
+				Msg("successfully recorded dry-run scheduled scaling of job")
+			sendDryRunMetrics(job)
+		}
+	}
+
+	if len(reqs) == 0 {
+		return out
+	}
+
+	// Schedule enforcement is not subject to cooldown, as the window opening is an explicit
+	// request from the operator to change capacity at this time.
+	resp, code, err := a.scaler.Trigger(job, reqs, state.SourceScheduler)
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to trigger scheduled scaling of job")
+		sendTriggerErrorMetrics(job)
+		return out
+	}
+
+	if resp != nil {
+		a.logger.Info().
+			Str("job", job).
+			Str("id", resp.ID.String()).
+			Str("evaluation-id", resp.EvaluationID).
+			Msg("successfully triggered scheduled scaling of job")
+		sendTriggerSuccessMetrics(job)
+	}
+
+	if code == http.StatusOK {
+		for i := range reqs {
+			out[reqs[i].GroupName] = struct{}{}
+		}
+	}
+	return out
+}
+
+// buildScheduleReqs compares the current job group counts against the bounds of the open schedule
+// windows, creating a scaling request for each group which is outside of these bounds.
+func buildScheduleReqs(counts map[string]int, scheduled map[string]*scheduledGroup, t time.Time) []*scale.GroupReq {
+	var reqs []*scale.GroupReq // nolint:prealloc
+
+	// Iterate the groups in a consistent order so that the scaling request is deterministic.
+	groups := make([]string, 0, len(scheduled))
+	for group := range scheduled {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		current, ok := counts[group]
+		if !ok {
+			continue
+		}
+
+		pol := scheduled[group].policy
+
+		desired := current
+		if desired < pol.MinCount {
+			desired = pol.MinCount
+		}
+		if desired > pol.MaxCount {
+			desired = pol.MaxCount
+		}
+
+		if desired == current {
+			continue
+		}
+
+		reqs = append(reqs, &scale.GroupReq{
+			Direction:          scale.DirectionSet,
+			Count:              desired,
+			Absolute:           true,
+			GroupName:          group,
+			GroupScalingPolicy: pol,
+			Time:               t.UnixNano(),
+			Meta:               map[string]string{scheduleMetaKey: scheduled[group].name},
+		})
+	}
+	return reqs
+}
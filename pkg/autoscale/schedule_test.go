@@ -0,0 +1,49 @@
+package autoscale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/scale"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildScheduleReqs(t *testing.T) {
+	now := time.Now()
+
+	raised := &policy.GroupScalingPolicy{MinCount: 6, MaxCount: 10}
+	lowered := &policy.GroupScalingPolicy{MinCount: 1, MaxCount: 2}
+	within := &policy.GroupScalingPolicy{MinCount: 1, MaxCount: 10}
+
+	scheduled := map[string]*scheduledGroup{
+		"web":    {name: "morning", policy: raised},
+		"worker": {name: "night", policy: lowered},
+		"cache":  {name: "always", policy: within},
+		"db":     {name: "missing", policy: within},
+	}
+	counts := map[string]int{"web": 3, "worker": 5, "cache": 4}
+
+	expectedOutput := []*scale.GroupReq{
+		{
+			Direction:          scale.DirectionSet,
+			Count:              6,
+			Absolute:           true,
+			GroupName:          "web",
+			GroupScalingPolicy: raised,
+			Time:               now.UnixNano(),
+			Meta:               map[string]string{"schedule": "morning"},
+		},
+		{
+			Direction:          scale.DirectionSet,
+			Count:              2,
+			Absolute:           true,
+			GroupName:          "worker",
+			GroupScalingPolicy: lowered,
+			Time:               now.UnixNano(),
+			Meta:               map[string]string{"schedule": "night"},
+		},
+	}
+
+	assert.Equal(t, expectedOutput, buildScheduleReqs(counts, scheduled, now))
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	nomad "github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/autoscale"
+	"github.com/jrasell/sherpa/pkg/autoscale/predictive"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy/backend"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	headerKeyContentType       = "Content-Type"
+	headerValueContentTypeJSON = "application/json; charset=utf-8"
+	marshalRespFailureMsg      = "failed to marshall HTTP response"
+	noPredictiveConfigMsg      = "job group policy does not have predictive config"
+	groupNotFoundMsg           = "job group not found within Nomad job"
+)
+
+type AutoScale struct {
+	logger     zerolog.Logger
+	nomad      *client.NomadTargets
+	policy     backend.PolicyBackend
+	predictor  *predictive.Predictor
+	autoscaler *autoscale.AutoScale
+	history    evaluation.Backend
+}
+
+type AutoScaleConfig struct {
+	Logger     zerolog.Logger
+	Nomad      *client.NomadTargets
+	Policy     backend.PolicyBackend
+	Predictor  *predictive.Predictor
+	AutoScaler *autoscale.AutoScale
+	History    evaluation.Backend
+}
+
+func NewAutoScaleServer(cfg *AutoScaleConfig) *AutoScale {
+	return &AutoScale{
+		logger:     cfg.Logger,
+		nomad:      cfg.Nomad,
+		policy:     cfg.Policy,
+		predictor:  cfg.Predictor,
+		autoscaler: cfg.AutoScaler,
+		history:    cfg.History,
+	}
+}
+
+// EvaluateJob performs an autoscaling evaluation of the job without triggering any scaling action,
+// returning an explanation of the checks performed and the decision made for each job group.
+func (a *AutoScale) EvaluateJob(w http.ResponseWriter, r *http.Request) {
+	job := helper.JobIDFromRequest(r)
+
+	exp, err := a.autoscaler.Explain(job)
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to explain autoscaling evaluation of job")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if exp == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	out, err := json.Marshal(exp)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, marshalRespFailureMsg, http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, out, http.StatusOK)
+}
+
+// PredictJobGroup returns the prediction for the job group based on its current baseline, without
+// triggering any scaling action. This allows operators to review predictions before enabling
+// predictive scaling.
+func (a *AutoScale) PredictJobGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	job := helper.JobIDFromRequest(r)
+	group := vars["group"]
+
+	pol, err := a.policy.GetJobGroupPolicy(job, group)
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to call policy backend")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if pol == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if pol.Predictive == nil {
+		http.Error(w, noPredictiveConfigMsg, http.StatusBadRequest)
+		return
+	}
+
+	nomadClient, ns, jobID, err := a.nomad.Job(job)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	info, _, err := nomadClient.Jobs().Info(jobID, &nomad.QueryOptions{Namespace: ns})
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to call Nomad API for job information")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	current := -1
+	for _, tg := range info.TaskGroups {
+		if tg.Name != nil && *tg.Name == group && tg.Count != nil {
+			current = *tg.Count
+		}
+	}
+
+	if current < 0 {
+		http.Error(w, groupNotFoundMsg, http.StatusNotFound)
+		return
+	}
+
+	// Use the policy bounds which the autoscaler would use, taking into account any open schedule
+	// windows.
+	t := time.Now().UTC()
+	if _, schedule := pol.ActiveSchedule(t); schedule != nil {
+		pol = pol.ApplySchedule(schedule)
+	}
+
+	prediction, err := a.predictor.Predict(job, group, pol, current, t)
+	if err != nil {
+		a.logger.Error().Err(err).Msg("failed to predict job group count")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	out, err := json.Marshal(prediction)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, marshalRespFailureMsg, http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, out, http.StatusOK)
+}
+
+func writeJSONResponse(w http.ResponseWriter, bytes []byte, statusCode int) { // nolint:unparam
+	w.Header().Set(headerKeyContentType, headerValueContentTypeJSON)
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(bytes); err != nil {
+		log.Error().Err(err).Msg("failed to write JSON response")
+	}
+}
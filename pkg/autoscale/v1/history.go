@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/helper"
+)
+
+// JobHistory returns the stored autoscaler evaluation history of each group within the job.
+func (a *AutoScale) JobHistory(w http.ResponseWriter, r *http.Request) {
+	job := helper.JobIDFromRequest(r)
+
+	history, err := a.history.GetJobEvaluations(job)
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to get job evaluation history from state")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(history) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	out, err := json.Marshal(history)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, marshalRespFailureMsg, http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, out, http.StatusOK)
+}
+
+// JobGroupHistory returns the stored autoscaler evaluation history of the job group, ordered
+// oldest first.
+func (a *AutoScale) JobGroupHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	job := helper.JobIDFromRequest(r)
+	group := vars["group"]
+
+	history, err := a.history.GetEvaluations(job, group)
+	if err != nil {
+		a.logger.Error().Err(err).Str("job", job).Msg("failed to get job group evaluation history from state")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(history) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	out, err := json.Marshal(history)
+	if err != nil {
+		a.logger.Error().Err(err).Msg(marshalRespFailureMsg)
+		http.Error(w, marshalRespFailureMsg, http.StatusInternalServerError)
+		return
+	}
+	writeJSONResponse(w, out, http.StatusOK)
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/evaluation/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoScale_JobGroupHistory(t *testing.T) {
+	history := memory.NewStateBackend(10)
+	eval := &state.Evaluation{Time: 1572857400000000000, Decision: &state.EvaluationDecision{Direction: "out", Count: 1}}
+	assert.Nil(t, history.PutEvaluation("example", "cache", eval))
+
+	a := NewAutoScaleServer(&AutoScaleConfig{Logger: zerolog.Nop(), History: history})
+
+	testCases := []struct {
+		vars         map[string]string
+		expectedCode int
+		expectedBody []*state.Evaluation
+		name         string
+	}{
+		{
+			vars:         map[string]string{"job_id": "example", "group": "cache"},
+			expectedCode: http.StatusOK,
+			expectedBody: []*state.Evaluation{eval},
+			name:         "job group with history",
+		},
+		{
+			vars:         map[string]string{"job_id": "example", "group": "web"},
+			expectedCode: http.StatusNotFound,
+			name:         "job group without history",
+		},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		a.JobGroupHistory(w, mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/", nil), tc.vars))
+		assert.Equal(t, tc.expectedCode, w.Code, tc.name)
+
+		if tc.expectedBody != nil {
+			var actual []*state.Evaluation
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &actual), tc.name)
+			assert.Equal(t, tc.expectedBody, actual, tc.name)
+		}
+	}
+}
@@ -6,7 +6,13 @@ import (
 
 // NewNomadClient is responsible for generating a reusable Nomad client using the HashiCorp Nomad
 // SDK and the default config. This default config pulls Nomad client configuration from env vars
-// which can therefore be customized by the user.
-func NewNomadClient() (*nomadAPI.Client, error) {
-	return nomadAPI.NewClient(nomadAPI.DefaultConfig())
+// which can therefore be customized by the user. If the passed token is not empty, it is used as
+// the Nomad ACL token in place of any configured via the NOMAD_TOKEN env var.
+func NewNomadClient(token string) (*nomadAPI.Client, error) {
+	cfg := nomadAPI.DefaultConfig()
+
+	if token != "" {
+		cfg.SecretID = token
+	}
+	return nomadAPI.NewClient(cfg)
 }
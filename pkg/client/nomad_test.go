@@ -1,6 +1,8 @@
 package client
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -13,9 +15,41 @@ func Test_NewNomadClient(t *testing.T) {
 	err := os.Setenv("NOMAD_ADDR", addr)
 	assert.Nil(t, err)
 
-	client, err := NewNomadClient()
+	client, err := NewNomadClient("")
 	assert.Nil(t, err)
 
 	nAddr := client.Address()
 	assert.Equal(t, addr, nAddr)
 }
+
+func Test_NewNomadClientToken(t *testing.T) {
+	var token string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Nomad-Token")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	assert.Nil(t, os.Setenv("NOMAD_ADDR", srv.URL))
+	assert.Nil(t, os.Setenv("NOMAD_TOKEN", "env-token"))
+	defer os.Unsetenv("NOMAD_TOKEN")
+
+	testCases := []struct {
+		inputToken    string
+		expectedToken string
+	}{
+		{inputToken: "", expectedToken: "env-token"},
+		{inputToken: "flag-token", expectedToken: "flag-token"},
+	}
+
+	for _, tc := range testCases {
+		client, err := NewNomadClient(tc.inputToken)
+		assert.Nil(t, err)
+
+		var out map[string]interface{}
+		_, err = client.Raw().Query("/v1/agent/self", &out, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expectedToken, token)
+	}
+}
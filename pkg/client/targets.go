@@ -0,0 +1,141 @@
+package client
+
+import (
+	"sort"
+	"strings"
+
+	nomadAPI "github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/pkg/errors"
+)
+
+// NomadTargets holds the Nomad API clients of each Nomad cluster managed by Sherpa. The primary
+// target is configured using the standard Nomad env vars, and additional targets are identified
+// by their region name.
+type NomadTargets struct {
+	primary *nomadAPI.Client
+	regions map[string]*nomadAPI.Client
+}
+
+// NewNomadTargets builds the Nomad targets from the primary client and the clients of any
+// additional regions.
+func NewNomadTargets(primary *nomadAPI.Client, regions map[string]*nomadAPI.Client) *NomadTargets {
+	if regions == nil {
+		regions = make(map[string]*nomadAPI.Client)
+	}
+	return &NomadTargets{primary: primary, regions: regions}
+}
+
+// NewNomadRegionClients builds a Nomad API client for each additional region. The addrs and tokens
+// are in the form <region>=<value>; regions without a token use the token of the primary target.
+// All other client configuration is taken from the standard Nomad env vars.
+func NewNomadRegionClients(addrs, tokens []string, primaryToken string) (map[string]*nomadAPI.Client, error) {
+	regionAddrs, err := parseRegionValues(addrs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Nomad region addresses")
+	}
+
+	regionTokens, err := parseRegionValues(tokens)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Nomad region tokens")
+	}
+
+	out := make(map[string]*nomadAPI.Client)
+
+	for region, addr := range regionAddrs {
+		cfg := nomadAPI.DefaultConfig()
+		cfg.Address = addr
+		cfg.Region = region
+
+		if token, ok := regionTokens[region]; ok {
+			cfg.SecretID = token
+		} else if primaryToken != "" {
+			cfg.SecretID = primaryToken
+		}
+
+		c, err := nomadAPI.NewClient(cfg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create Nomad client for region %s", region)
+		}
+		out[region] = c
+	}
+
+	for region := range regionTokens {
+		if _, ok := regionAddrs[region]; !ok {
+			return nil, errors.Errorf("Nomad token configured for region %s without an address", region)
+		}
+	}
+	return out, nil
+}
+
+// Primary returns the Nomad API client of the primary target.
+func (t *NomadTargets) Primary() *nomadAPI.Client { return t.primary }
+
+// Regions returns the sorted names of all Nomad targets. The primary target is identified by an
+// empty region and is always first.
+func (t *NomadTargets) Regions() []string {
+	out := make([]string, 0, len(t.regions))
+	for region := range t.regions {
+		out = append(out, region)
+	}
+	sort.Strings(out)
+	return append([]string{""}, out...)
+}
+
+// RegionAddresses returns the Nomad address of each additional region, or nil if there are none.
+func (t *NomadTargets) RegionAddresses() map[string]string {
+	if len(t.regions) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(t.regions))
+	for region, c := range t.regions {
+		out[region] = c.Address()
+	}
+	return out
+}
+
+// Client returns the Nomad API client of the region, where an empty region is the primary target.
+func (t *NomadTargets) Client(region string) (*nomadAPI.Client, error) {
+	if region == "" {
+		return t.primary, nil
+	}
+
+	c, ok := t.regions[region]
+	if !ok {
+		return nil, errors.Errorf("Nomad region %s is not configured", region)
+	}
+	return c, nil
+}
+
+// Job returns the Nomad API client of the target which runs the job, along with the Nomad namespace
+// and job ID parsed from the Sherpa job identifier.
+func (t *NomadTargets) Job(id string) (*nomadAPI.Client, string, string, error) {
+	region, namespaced := helper.SplitRegionalJobID(id)
+
+	c, err := t.Client(region)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	ns, jobID := helper.SplitNamespacedJobID(namespaced)
+	return c, ns, jobID, nil
+}
+
+// parseRegionValues parses values in the form <region>=<value>.
+func parseRegionValues(values []string) (map[string]string, error) {
+	out := make(map[string]string)
+
+	for _, v := range values {
+		split := strings.SplitN(v, "=", 2)
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, errors.Errorf("invalid value %q, expected <region>=<value>", v)
+		}
+
+		if strings.ContainsAny(split[0], "@/") {
+			return nil, errors.Errorf("invalid region name %q", split[0])
+		}
+		out[split[0]] = split[1]
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewNomadRegionClients(t *testing.T) {
+	testCases := []struct {
+		addrs         []string
+		tokens        []string
+		expectedAddrs map[string]string
+		expectError   bool
+		name          string
+	}{
+		{
+			addrs:         nil,
+			tokens:        nil,
+			expectedAddrs: map[string]string{},
+			name:          "no additional regions",
+		},
+		{
+			addrs:         []string{"eu-west-1=http://10.0.0.1:4646", "us-east-1=http://10.0.1.1:4646"},
+			tokens:        []string{"eu-west-1=secret"},
+			expectedAddrs: map[string]string{"eu-west-1": "http://10.0.0.1:4646", "us-east-1": "http://10.0.1.1:4646"},
+			name:          "multiple regions",
+		},
+		{
+			addrs:       []string{"eu-west-1"},
+			expectError: true,
+			name:        "address without region",
+		},
+		{
+			addrs:       []string{"eu@west=http://10.0.0.1:4646"},
+			expectError: true,
+			name:        "invalid region name",
+		},
+		{
+			addrs:       []string{"eu-west-1=http://10.0.0.1:4646"},
+			tokens:      []string{"us-east-1=secret"},
+			expectError: true,
+			name:        "token without address",
+		},
+	}
+
+	for _, tc := range testCases {
+		regions, err := NewNomadRegionClients(tc.addrs, tc.tokens, "")
+		if tc.expectError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+
+		actualAddrs := make(map[string]string)
+		for region, c := range regions {
+			actualAddrs[region] = c.Address()
+		}
+		assert.Equal(t, tc.expectedAddrs, actualAddrs, tc.name)
+	}
+}
+
+func TestNomadTargets_Job(t *testing.T) {
+	primary, err := NewNomadClient("")
+	assert.Nil(t, err)
+
+	regions, err := NewNomadRegionClients([]string{"eu-west-1=http://10.0.0.1:4646"}, nil, "")
+	assert.Nil(t, err)
+
+	targets := NewNomadTargets(primary, regions)
+	assert.Equal(t, []string{"", "eu-west-1"}, targets.Regions())
+
+	testCases := []struct {
+		inputID       string
+		expectedAddr  string
+		expectedNS    string
+		expectedJobID string
+		expectError   bool
+	}{
+		{inputID: "example", expectedAddr: primary.Address(), expectedNS: "default", expectedJobID: "example"},
+		{inputID: "platform/example", expectedAddr: primary.Address(), expectedNS: "platform", expectedJobID: "example"},
+		{inputID: "eu-west-1@example", expectedAddr: "http://10.0.0.1:4646", expectedNS: "default", expectedJobID: "example"},
+		{inputID: "eu-west-1@platform/example", expectedAddr: "http://10.0.0.1:4646", expectedNS: "platform", expectedJobID: "example"},
+		{inputID: "ap-south-1@example", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		c, ns, jobID, err := targets.Job(tc.inputID)
+		if tc.expectError {
+			assert.NotNil(t, err, tc.inputID)
+			continue
+		}
+		assert.Nil(t, err, tc.inputID)
+		assert.Equal(t, tc.expectedAddr, c.Address(), tc.inputID)
+		assert.Equal(t, tc.expectedNS, ns, tc.inputID)
+		assert.Equal(t, tc.expectedJobID, jobID, tc.inputID)
+	}
+}
@@ -0,0 +1,67 @@
+package acl
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyACLTokenName       = "acl-token-name"
+	configKeyACLTokenManagement = "acl-token-management"
+	configKeyACLTokenRules      = "acl-token-rules"
+)
+
+type Config struct {
+	Name       string
+	Management bool
+	Rules      []string
+}
+
+func GetConfig() Config {
+	return Config{
+		Name:       viper.GetString(configKeyACLTokenName),
+		Management: viper.GetBool(configKeyACLTokenManagement),
+		Rules:      viper.GetStringSlice(configKeyACLTokenRules),
+	}
+}
+
+func RegisterConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyACLTokenName
+			longOpt      = "acl-token-name"
+			defaultValue = ""
+			description  = "A human readable name for the ACL token"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyACLTokenManagement
+			longOpt      = "acl-token-management"
+			defaultValue = false
+			description  = "Create a management token, permitted to perform all actions"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key         = configKeyACLTokenRules
+			longOpt     = "acl-token-rules"
+			description = "The capabilities granted by the token, in the form <job-prefix>=<capability>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+}
@@ -0,0 +1,18 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ACLConfig(t *testing.T) {
+	fakeCMD := &cobra.Command{}
+	RegisterConfig(fakeCMD)
+
+	cfg := GetConfig()
+	assert.Equal(t, "", cfg.Name)
+	assert.Equal(t, false, cfg.Management)
+	assert.Empty(t, cfg.Rules)
+}
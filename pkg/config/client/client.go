@@ -12,6 +12,11 @@ const (
 	configKeySherpaClientCertPath    = "client-cert-path"
 	configKeySherpaClientCertKeyPath = "client-cert-key-path"
 	configKeySherpaCAPath            = "client-ca-path"
+
+	configKeySherpaNamespace = "namespace"
+	configKeySherpaRegion    = "region"
+
+	configKeySherpaToken = "token"
 )
 
 type Config struct {
@@ -19,6 +24,9 @@ type Config struct {
 	CertPath    string
 	CertKeyPath string
 	CAPath      string
+	Namespace   string
+	Region      string
+	Token       string
 }
 
 func GetConfig() Config {
@@ -27,6 +35,9 @@ func GetConfig() Config {
 		CertPath:    viper.GetString(configKeySherpaClientCertPath),
 		CertKeyPath: viper.GetString(configKeySherpaClientCertKeyPath),
 		CAPath:      viper.GetString(configKeySherpaCAPath),
+		Namespace:   viper.GetString(configKeySherpaNamespace),
+		Region:      viper.GetString(configKeySherpaRegion),
+		Token:       viper.GetString(configKeySherpaToken),
 	}
 }
 
@@ -84,4 +95,43 @@ func RegisterConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeySherpaNamespace
+			longOpt      = "namespace"
+			defaultValue = ""
+			description  = "The Nomad namespace of the job, if not the default namespace"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeySherpaRegion
+			longOpt      = "region"
+			defaultValue = ""
+			description  = "The Nomad region of the job, if not managed by the primary Nomad target"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeySherpaToken
+			longOpt      = "token"
+			defaultValue = ""
+			description  = "The ACL token secret ID used to authenticate requests to the Sherpa server"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
 }
@@ -11,4 +11,7 @@ func Test_SherpaConfig(t *testing.T) {
 	fakeCMD := &cobra.Command{}
 	RegisterConfig(fakeCMD)
 	assert.Equal(t, configKeySherpaAddrDefault, GetConfig().Addr)
+	assert.Equal(t, "", GetConfig().Namespace)
+	assert.Equal(t, "", GetConfig().Region)
+	assert.Equal(t, "", GetConfig().Token)
 }
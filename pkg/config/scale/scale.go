@@ -53,7 +53,7 @@ func RegisterScaleConfig(cmd *cobra.Command) {
 			key          = configKeyScaleCount
 			longOpt      = "count"
 			defaultValue = 0
-			description  = "The number by which to increment or decrement the job group"
+			description  = "The number by which to increment or decrement the job group, or the count to set it to"
 		)
 
 		flags.Int(longOpt, defaultValue, description)
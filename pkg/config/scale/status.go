@@ -7,15 +7,36 @@ import (
 
 const (
 	configKeyScaleStatusLatest = "latest"
+	configKeyScaleStatusStatus = "status"
+	configKeyScaleStatusFollow = "status-follow"
+	configKeyScaleStatusJob    = "status-job"
+	configKeyScaleStatusGroup  = "status-group"
+	configKeyScaleStatusSource = "status-source"
+	configKeyScaleStatusTopics = "status-topics"
 )
 
 type StatusConfig struct {
 	Latest bool
+	Status string
+
+	// Follow streams new events rather than listing the stored scaling events. The Job, Group,
+	// Source and Topics filter the streamed events.
+	Follow bool
+	Job    string
+	Group  string
+	Source string
+	Topics []string
 }
 
 func GetScaleStatusConfig() *StatusConfig {
 	return &StatusConfig{
 		Latest: viper.GetBool(configKeyScaleStatusLatest),
+		Status: viper.GetString(configKeyScaleStatusStatus),
+		Follow: viper.GetBool(configKeyScaleStatusFollow),
+		Job:    viper.GetString(configKeyScaleStatusJob),
+		Group:  viper.GetString(configKeyScaleStatusGroup),
+		Source: viper.GetString(configKeyScaleStatusSource),
+		Topics: viper.GetStringSlice(configKeyScaleStatusTopics),
 	}
 }
 
@@ -34,4 +55,80 @@ func RegisterScaleStatusConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeyScaleStatusStatus
+			longOpt      = "status"
+			defaultValue = ""
+			description  = "List only scaling events with the status, such as Completed, Failed or DryRun"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyScaleStatusFollow
+			longOpt      = "follow"
+			defaultValue = false
+			description  = "Stream new scaling, policy and leadership events as they occur"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyScaleStatusJob
+			longOpt      = "job"
+			defaultValue = ""
+			description  = "Stream only events of the job when following"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyScaleStatusGroup
+			longOpt      = "group"
+			defaultValue = ""
+			description  = "Stream only events of the job group when following"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyScaleStatusSource
+			longOpt      = "source"
+			defaultValue = ""
+			description  = "Stream only scaling events with the source, such as API or InternalAutoscaler, when following"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key         = configKeyScaleStatusTopics
+			longOpt     = "topics"
+			description = "Stream only events of the topics (ScalingEvent, Policy or Leadership) when following"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
 }
@@ -13,4 +13,10 @@ func Test_ScaleStatusConfig(t *testing.T) {
 
 	cfg := GetScaleStatusConfig()
 	assert.Equal(t, false, cfg.Latest)
+	assert.Equal(t, "", cfg.Status)
+	assert.Equal(t, false, cfg.Follow)
+	assert.Equal(t, "", cfg.Job)
+	assert.Equal(t, "", cfg.Group)
+	assert.Equal(t, "", cfg.Source)
+	assert.Empty(t, cfg.Topics)
 }
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyAuditFilePath              = "audit-file-path"
+	configKeyAuditConsulEnabled         = "audit-consul-enabled"
+	configKeyAuditConsulRingSize        = "audit-consul-ring-size"
+	configKeyAuditConsulRingSizeDefault = 1000
+	configKeyAuditWebhookAddr           = "audit-webhook-addr"
+)
+
+// AuditConfig configures the sinks which audit log entries are written to. The audit log is
+// enabled when at least one sink is configured.
+type AuditConfig struct {
+
+	// FilePath is the path of the file which audit entries are appended to as JSON lines.
+	FilePath string
+
+	// ConsulEnabled enables writing audit entries to a ring buffer within Consul KV, stored under
+	// the Consul storage backend path.
+	ConsulEnabled bool
+
+	// ConsulRingSize is the number of audit entries retained within the Consul KV ring buffer.
+	ConsulRingSize int
+
+	// WebhookAddr is the HTTP address which audit entries are sent to as JSON POST requests.
+	WebhookAddr string
+}
+
+// Enabled returns whether any audit sink is configured.
+func (c *AuditConfig) Enabled() bool {
+	return c.FilePath != "" || c.ConsulEnabled || c.WebhookAddr != ""
+}
+
+func (c *AuditConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.Str(configKeyAuditFilePath, c.FilePath).
+		Bool(configKeyAuditConsulEnabled, c.ConsulEnabled).
+		Int(configKeyAuditConsulRingSize, c.ConsulRingSize).
+		Str(configKeyAuditWebhookAddr, c.WebhookAddr)
+}
+
+func GetAuditConfig() AuditConfig {
+	return AuditConfig{
+		FilePath:       viper.GetString(configKeyAuditFilePath),
+		ConsulEnabled:  viper.GetBool(configKeyAuditConsulEnabled),
+		ConsulRingSize: viper.GetInt(configKeyAuditConsulRingSize),
+		WebhookAddr:    viper.GetString(configKeyAuditWebhookAddr),
+	}
+}
+
+func RegisterAuditConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyAuditFilePath
+			longOpt      = "audit-file-path"
+			defaultValue = ""
+			description  = "The path of the file to append audit log entries to as JSON lines"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAuditConsulEnabled
+			longOpt      = "audit-consul-enabled"
+			defaultValue = false
+			description  = "Enable writing audit log entries to a ring buffer in Consul KV"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAuditConsulRingSize
+			longOpt      = "audit-consul-ring-size"
+			defaultValue = configKeyAuditConsulRingSizeDefault
+			description  = "The number of audit log entries to retain in Consul KV"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAuditWebhookAddr
+			longOpt      = "audit-webhook-addr"
+			defaultValue = ""
+			description  = "The HTTP address to send audit log entries to"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+}
@@ -0,0 +1,23 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuditConfig(t *testing.T) {
+	fakeCMD := &cobra.Command{}
+	RegisterAuditConfig(fakeCMD)
+
+	cfg := GetAuditConfig()
+	assert.Equal(t, "", cfg.FilePath)
+	assert.Equal(t, false, cfg.ConsulEnabled)
+	assert.Equal(t, configKeyAuditConsulRingSizeDefault, cfg.ConsulRingSize)
+	assert.Equal(t, "", cfg.WebhookAddr)
+	assert.False(t, cfg.Enabled())
+
+	cfg.WebhookAddr = "http://127.0.0.1:9000"
+	assert.True(t, cfg.Enabled())
+}
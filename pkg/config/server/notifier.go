@@ -0,0 +1,171 @@
+package server
+
+import (
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyNotifierDefaults             = "notifier-defaults"
+	configKeyNotifierRetries              = "notifier-retries"
+	configKeyNotifierRetriesDefault       = 3
+	configKeyNotifierWebhookAddrs         = "notifier-webhook-addrs"
+	configKeyNotifierWebhookSecrets       = "notifier-webhook-secrets"
+	configKeyNotifierWebhookTemplates     = "notifier-webhook-templates"
+	configKeyNotifierSlackAddrs           = "notifier-slack-addrs"
+	configKeyNotifierPagerDutyRoutingKeys = "notifier-pagerduty-routing-keys"
+	configKeyNotifierPagerDutyAddr        = "notifier-pagerduty-addr"
+	configKeyNotifierPagerDutyAddrDefault = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// NotifierConfig configures the notifiers which scaling events are sent to. Each notifier is
+// identified by an operator specified name, which scaling policies use to route their events.
+type NotifierConfig struct {
+
+	// Defaults are the names of the notifiers used for job groups whose scaling policy does not
+	// specify any notifiers.
+	Defaults []string
+
+	// Retries is the number of times a failed notification is retried.
+	Retries int
+
+	// WebhookAddrs, WebhookSecrets and WebhookTemplates configure the webhook notifiers, in the
+	// form <name>=<value>. The secrets and templates are optional.
+	WebhookAddrs     []string
+	WebhookSecrets   []string
+	WebhookTemplates []string
+
+	// SlackAddrs configure the Slack notifiers, in the form <name>=<address>.
+	SlackAddrs []string
+
+	// PagerDutyRoutingKeys configure the PagerDuty notifiers, in the form <name>=<key>.
+	PagerDutyRoutingKeys []string
+
+	// PagerDutyAddr is the address of the PagerDuty Events API v2.
+	PagerDutyAddr string
+}
+
+// Enabled returns whether any notifier is configured.
+func (c *NotifierConfig) Enabled() bool {
+	return len(c.WebhookAddrs) > 0 || len(c.SlackAddrs) > 0 || len(c.PagerDutyRoutingKeys) > 0
+}
+
+// MarshalZerologObject logs the notifier config. The Slack addresses, webhook secrets and
+// PagerDuty routing keys are credentials and so are not logged.
+func (c *NotifierConfig) MarshalZerologObject(e *zerolog.Event) {
+	e.Strs(configKeyNotifierDefaults, c.Defaults).
+		Int(configKeyNotifierRetries, c.Retries).
+		Strs(configKeyNotifierWebhookAddrs, c.WebhookAddrs).
+		Strs(configKeyNotifierWebhookTemplates, c.WebhookTemplates).
+		Str(configKeyNotifierPagerDutyAddr, c.PagerDutyAddr)
+}
+
+func GetNotifierConfig() NotifierConfig {
+	return NotifierConfig{
+		Defaults:             viper.GetStringSlice(configKeyNotifierDefaults),
+		Retries:              viper.GetInt(configKeyNotifierRetries),
+		WebhookAddrs:         viper.GetStringSlice(configKeyNotifierWebhookAddrs),
+		WebhookSecrets:       viper.GetStringSlice(configKeyNotifierWebhookSecrets),
+		WebhookTemplates:     viper.GetStringSlice(configKeyNotifierWebhookTemplates),
+		SlackAddrs:           viper.GetStringSlice(configKeyNotifierSlackAddrs),
+		PagerDutyRoutingKeys: viper.GetStringSlice(configKeyNotifierPagerDutyRoutingKeys),
+		PagerDutyAddr:        viper.GetString(configKeyNotifierPagerDutyAddr),
+	}
+}
+
+func RegisterNotifierConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key         = configKeyNotifierDefaults
+			longOpt     = "notifier-defaults"
+			description = "The names of the notifiers used for job groups whose scaling policy does not specify notifiers"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNotifierWebhookAddrs
+			longOpt     = "notifier-webhook-addrs"
+			description = "The addresses of webhook notifiers, in the form <name>=<address>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNotifierWebhookSecrets
+			longOpt     = "notifier-webhook-secrets"
+			description = "The HMAC secrets used to sign webhook notifier bodies, in the form <name>=<secret>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNotifierWebhookTemplates
+			longOpt     = "notifier-webhook-templates"
+			description = "The paths of Go template files used to render webhook notifier bodies, in the form <name>=<path>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNotifierSlackAddrs
+			longOpt     = "notifier-slack-addrs"
+			description = "The Slack incoming webhook addresses of Slack notifiers, in the form <name>=<address>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNotifierPagerDutyRoutingKeys
+			longOpt     = "notifier-pagerduty-routing-keys"
+			description = "The PagerDuty integration routing keys of PagerDuty notifiers, in the form <name>=<key>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key          = configKeyNotifierRetries
+			longOpt      = "notifier-retries"
+			defaultValue = configKeyNotifierRetriesDefault
+			description  = "The number of times to retry sending a failed notification"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyNotifierPagerDutyAddr
+			longOpt      = "notifier-pagerduty-addr"
+			defaultValue = configKeyNotifierPagerDutyAddrDefault
+			description  = "The address of the PagerDuty Events API v2"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NotifierConfig(t *testing.T) {
+	fakeCMD := &cobra.Command{}
+	RegisterNotifierConfig(fakeCMD)
+
+	cfg := GetNotifierConfig()
+	assert.Len(t, cfg.Defaults, 0)
+	assert.Equal(t, configKeyNotifierRetriesDefault, cfg.Retries)
+	assert.Len(t, cfg.WebhookAddrs, 0)
+	assert.Len(t, cfg.WebhookSecrets, 0)
+	assert.Len(t, cfg.WebhookTemplates, 0)
+	assert.Len(t, cfg.SlackAddrs, 0)
+	assert.Len(t, cfg.PagerDutyRoutingKeys, 0)
+	assert.Equal(t, configKeyNotifierPagerDutyAddrDefault, cfg.PagerDutyAddr)
+	assert.False(t, cfg.Enabled())
+
+	cfg.SlackAddrs = []string{"ops=https://hooks.slack.com/services/T000/B000/XXXX"}
+	assert.True(t, cfg.Enabled())
+}
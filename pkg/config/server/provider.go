@@ -1,21 +1,100 @@
 package server
 
 import (
+	"strings"
+
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 const (
-	configKeyMetricProviderPrometheusAddr = "metric-provider-prometheus-addr"
+	configKeyMetricProviderPrometheusAddr              = "metric-provider-prometheus-addr"
+	configKeyMetricProviderPrometheusBasicAuthUsername = "metric-provider-prometheus-basic-auth-username"
+	configKeyMetricProviderPrometheusBasicAuthPassword = "metric-provider-prometheus-basic-auth-password"
+	configKeyMetricProviderPrometheusBearerTokenFile   = "metric-provider-prometheus-bearer-token-file"
+	configKeyMetricProviderPrometheusCACertPath        = "metric-provider-prometheus-ca-cert-path"
+	configKeyMetricProviderPrometheusClientCertPath    = "metric-provider-prometheus-client-cert-path"
+	configKeyMetricProviderPrometheusClientCertKeyPath = "metric-provider-prometheus-client-cert-key-path"
+	configKeyMetricProviderPrometheusTLSSkipVerify     = "metric-provider-prometheus-tls-skip-verify"
+	configKeyMetricProviderPrometheusHeaders           = "metric-provider-prometheus-headers"
+
+	// metricProviderHeaderSeparator separates the header name from its value when supplying
+	// custom headers via the CLI or environment.
+	metricProviderHeaderSeparator = "="
+
+	configKeyMetricProviderInfluxDBAddr          = "metric-provider-influxdb-addr"
+	configKeyMetricProviderInfluxDBDatabase      = "metric-provider-influxdb-database"
+	configKeyMetricProviderInfluxDBUsername      = "metric-provider-influxdb-username"
+	configKeyMetricProviderInfluxDBPassword      = "metric-provider-influxdb-password"
+	configKeyMetricProviderInfluxDBToken         = "metric-provider-influxdb-token"
+	configKeyMetricProviderInfluxDBOrganization  = "metric-provider-influxdb-organization"
+	configKeyMetricProviderInfluxDBQueryLanguage = "metric-provider-influxdb-query-language"
+
+	configKeyMetricProviderGraphiteAddr     = "metric-provider-graphite-addr"
+	configKeyMetricProviderGraphiteUsername = "metric-provider-graphite-username"
+	configKeyMetricProviderGraphitePassword = "metric-provider-graphite-password"
+	configKeyMetricProviderGraphiteFrom     = "metric-provider-graphite-from"
+)
+
+const (
+	// InfluxDBQueryLanguageInfluxQL identifies the InfluxQL query language which is queried using
+	// the InfluxDB 1.x compatible /query endpoint.
+	InfluxDBQueryLanguageInfluxQL = "influxql"
+
+	// InfluxDBQueryLanguageFlux identifies the Flux query language which is queried using the
+	// InfluxDB 2.x /api/v2/query endpoint.
+	InfluxDBQueryLanguageFlux = "flux"
 )
 
 type MetricProviderConfig struct {
 	Prometheus *MetricProviderPrometheusConfig
+	InfluxDB   *MetricProviderInfluxDBConfig
+	Graphite   *MetricProviderGraphiteConfig
 }
 
+// MetricProviderPrometheusConfig is the configuration used to setup the Prometheus metric provider.
 type MetricProviderPrometheusConfig struct {
 	Addr string
+
+	// BasicAuthUsername and BasicAuthPassword are used to perform HTTP basic authentication
+	// against Prometheus.
+	BasicAuthUsername string
+	BasicAuthPassword string
+
+	// BearerTokenFile is the path to a file containing a bearer token which is sent in the
+	// Authorization header. The file is read on each request to allow rotation.
+	BearerTokenFile string
+
+	// CACertPath, ClientCertPath and ClientCertKeyPath configure TLS communication with
+	// Prometheus, including mutual TLS.
+	CACertPath        string
+	ClientCertPath    string
+	ClientCertKeyPath string
+	TLSSkipVerify     bool
+
+	// Headers are arbitrary headers added to each request, such as X-Scope-OrgID for multi-tenant
+	// Cortex or Thanos deployments.
+	Headers map[string]string
+}
+
+// MetricProviderInfluxDBConfig is the configuration used to setup the InfluxDB metric provider.
+type MetricProviderInfluxDBConfig struct {
+	Addr          string
+	Database      string
+	Username      string
+	Password      string
+	Token         string
+	Organization  string
+	QueryLanguage string
+}
+
+// MetricProviderGraphiteConfig is the configuration used to setup the Graphite metric provider.
+type MetricProviderGraphiteConfig struct {
+	Addr     string
+	Username string
+	Password string
+	From     string
 }
 
 // MarshalZerologObject is the Zerolog marshaller which allow us to log the object.
@@ -25,13 +104,65 @@ func GetMetricProviderConfig() *MetricProviderConfig {
 	mpc := &MetricProviderConfig{}
 
 	if promAddr := viper.GetString(configKeyMetricProviderPrometheusAddr); promAddr != "" {
-		mpc.Prometheus = &MetricProviderPrometheusConfig{Addr: promAddr}
+		mpc.Prometheus = &MetricProviderPrometheusConfig{
+			Addr:              promAddr,
+			BasicAuthUsername: viper.GetString(configKeyMetricProviderPrometheusBasicAuthUsername),
+			BasicAuthPassword: viper.GetString(configKeyMetricProviderPrometheusBasicAuthPassword),
+			BearerTokenFile:   viper.GetString(configKeyMetricProviderPrometheusBearerTokenFile),
+			CACertPath:        viper.GetString(configKeyMetricProviderPrometheusCACertPath),
+			ClientCertPath:    viper.GetString(configKeyMetricProviderPrometheusClientCertPath),
+			ClientCertKeyPath: viper.GetString(configKeyMetricProviderPrometheusClientCertKeyPath),
+			TLSSkipVerify:     viper.GetBool(configKeyMetricProviderPrometheusTLSSkipVerify),
+			Headers:           parseHeaders(viper.GetStringSlice(configKeyMetricProviderPrometheusHeaders)),
+		}
+	}
+
+	if influxAddr := viper.GetString(configKeyMetricProviderInfluxDBAddr); influxAddr != "" {
+		mpc.InfluxDB = &MetricProviderInfluxDBConfig{
+			Addr:          influxAddr,
+			Database:      viper.GetString(configKeyMetricProviderInfluxDBDatabase),
+			Username:      viper.GetString(configKeyMetricProviderInfluxDBUsername),
+			Password:      viper.GetString(configKeyMetricProviderInfluxDBPassword),
+			Token:         viper.GetString(configKeyMetricProviderInfluxDBToken),
+			Organization:  viper.GetString(configKeyMetricProviderInfluxDBOrganization),
+			QueryLanguage: viper.GetString(configKeyMetricProviderInfluxDBQueryLanguage),
+		}
+	}
+
+	if graphiteAddr := viper.GetString(configKeyMetricProviderGraphiteAddr); graphiteAddr != "" {
+		mpc.Graphite = &MetricProviderGraphiteConfig{
+			Addr:     graphiteAddr,
+			Username: viper.GetString(configKeyMetricProviderGraphiteUsername),
+			Password: viper.GetString(configKeyMetricProviderGraphitePassword),
+			From:     viper.GetString(configKeyMetricProviderGraphiteFrom),
+		}
 	}
 
 	return mpc
 }
 
+// parseHeaders converts a list of name=value strings into a header map. Entries which do not
+// contain the separator are ignored.
+func parseHeaders(raw []string) map[string]string {
+	out := make(map[string]string)
+
+	for i := range raw {
+		kv := strings.SplitN(raw[i], metricProviderHeaderSeparator, 2)
+		if len(kv) != 2 || kv[0] == "" {
+			continue
+		}
+		out[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return out
+}
+
 func RegisterMetricProviderConfig(cmd *cobra.Command) {
+	registerMetricProviderPrometheusConfig(cmd)
+	registerMetricProviderInfluxDBConfig(cmd)
+	registerMetricProviderGraphiteConfig(cmd)
+}
+
+func registerMetricProviderPrometheusConfig(cmd *cobra.Command) {
 	flags := cmd.PersistentFlags()
 
 	{
@@ -46,4 +177,258 @@ func RegisterMetricProviderConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusBasicAuthUsername
+			longOpt      = "metric-provider-prometheus-basic-auth-username"
+			defaultValue = ""
+			description  = "The username used for HTTP basic authentication with Prometheus"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusBasicAuthPassword
+			longOpt      = "metric-provider-prometheus-basic-auth-password"
+			defaultValue = ""
+			description  = "The password used for HTTP basic authentication with Prometheus"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusBearerTokenFile
+			longOpt      = "metric-provider-prometheus-bearer-token-file"
+			defaultValue = ""
+			description  = "Path to a file containing the bearer token used to authenticate with Prometheus"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusCACertPath
+			longOpt      = "metric-provider-prometheus-ca-cert-path"
+			defaultValue = ""
+			description  = "Path to a PEM encoded CA cert file used to verify the Prometheus certificate"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusClientCertPath
+			longOpt      = "metric-provider-prometheus-client-cert-path"
+			defaultValue = ""
+			description  = "Path to a PEM encoded client certificate for TLS authentication with Prometheus"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusClientCertKeyPath
+			longOpt      = "metric-provider-prometheus-client-cert-key-path"
+			defaultValue = ""
+			description  = "Path to the private key matching the Prometheus client certificate"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderPrometheusTLSSkipVerify
+			longOpt      = "metric-provider-prometheus-tls-skip-verify"
+			defaultValue = false
+			description  = "Do not verify the Prometheus TLS certificate"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key         = configKeyMetricProviderPrometheusHeaders
+			longOpt     = "metric-provider-prometheus-headers"
+			description = "Custom headers to send with each Prometheus request in the form <name>=<value>"
+		)
+
+		flags.StringSlice(longOpt, []string{}, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, []string{})
+	}
+}
+
+func registerMetricProviderInfluxDBConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBAddr
+			longOpt      = "metric-provider-influxdb-addr"
+			defaultValue = ""
+			description  = "The address of the InfluxDB endpoint in the form <protocol>://<addr>:<port>"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBDatabase
+			longOpt      = "metric-provider-influxdb-database"
+			defaultValue = ""
+			description  = "The InfluxDB database to run InfluxQL queries against"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBUsername
+			longOpt      = "metric-provider-influxdb-username"
+			defaultValue = ""
+			description  = "The username used to authenticate InfluxQL queries"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBPassword
+			longOpt      = "metric-provider-influxdb-password"
+			defaultValue = ""
+			description  = "The password used to authenticate InfluxQL queries"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBToken
+			longOpt      = "metric-provider-influxdb-token"
+			defaultValue = ""
+			description  = "The InfluxDB API token used to authenticate queries"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBOrganization
+			longOpt      = "metric-provider-influxdb-organization"
+			defaultValue = ""
+			description  = "The InfluxDB organization to run Flux queries against"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderInfluxDBQueryLanguage
+			longOpt      = "metric-provider-influxdb-query-language"
+			defaultValue = InfluxDBQueryLanguageInfluxQL
+			description  = "The query language used for InfluxDB checks (\"influxql\" or \"flux\")"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+}
+
+func registerMetricProviderGraphiteConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyMetricProviderGraphiteAddr
+			longOpt      = "metric-provider-graphite-addr"
+			defaultValue = ""
+			description  = "The address of the Graphite endpoint in the form <protocol>://<addr>:<port>"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderGraphiteUsername
+			longOpt      = "metric-provider-graphite-username"
+			defaultValue = ""
+			description  = "The username used to authenticate Graphite render API requests"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderGraphitePassword
+			longOpt      = "metric-provider-graphite-password"
+			defaultValue = ""
+			description  = "The password used to authenticate Graphite render API requests"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyMetricProviderGraphiteFrom
+			longOpt      = "metric-provider-graphite-from"
+			defaultValue = "-5min"
+			description  = "The relative time window used when querying the Graphite render API"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
 }
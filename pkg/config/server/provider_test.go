@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,4 +14,23 @@ func Test_MetricProviderConfig(t *testing.T) {
 
 	cfg := GetMetricProviderConfig()
 	assert.Nil(t, cfg.Prometheus)
+	assert.Nil(t, cfg.InfluxDB)
+	assert.Nil(t, cfg.Graphite)
+
+	viper.Set(configKeyMetricProviderPrometheusAddr, "http://127.0.0.1:9090")
+	viper.Set(configKeyMetricProviderPrometheusHeaders, []string{"X-Scope-OrgID=tenant-1", "invalid"})
+	viper.Set(configKeyMetricProviderInfluxDBAddr, "http://127.0.0.1:8086")
+	viper.Set(configKeyMetricProviderGraphiteAddr, "http://127.0.0.1:8080")
+	defer viper.Set(configKeyMetricProviderPrometheusAddr, "")
+	defer viper.Set(configKeyMetricProviderPrometheusHeaders, []string{})
+	defer viper.Set(configKeyMetricProviderInfluxDBAddr, "")
+	defer viper.Set(configKeyMetricProviderGraphiteAddr, "")
+
+	cfg = GetMetricProviderConfig()
+	assert.Equal(t, "http://127.0.0.1:9090", cfg.Prometheus.Addr)
+	assert.Equal(t, map[string]string{"X-Scope-OrgID": "tenant-1"}, cfg.Prometheus.Headers)
+	assert.Equal(t, "http://127.0.0.1:8086", cfg.InfluxDB.Addr)
+	assert.Equal(t, InfluxDBQueryLanguageInfluxQL, cfg.InfluxDB.QueryLanguage)
+	assert.Equal(t, "http://127.0.0.1:8080", cfg.Graphite.Addr)
+	assert.Equal(t, "-5min", cfg.Graphite.From)
 }
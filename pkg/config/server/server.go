@@ -12,15 +12,26 @@ const (
 	configKeyStorageBackendConsulPathDefault     = "sherpa/"
 	configKeyAutoscalerEvaluationIntervalDefault = 60
 
+	configKeyACLBootstrapToken                 = "acl-bootstrap-token"
+	configKeyACLEnabled                        = "acl-enabled"
 	configKeyBindAddr                          = "bind-addr"
 	configKeyBindPort                          = "bind-port"
 	configKeyAutoscalerEnabled                 = "autoscaler-enabled"
+	configKeyAutoscalerDryRun                  = "autoscaler-dry-run"
 	configKeyAutoscalerEvaluationInterval      = "autoscaler-evaluation-interval"
 	configKeyAutoscalerThreadNumber            = "autoscaler-num-threads"
 	configKeyAutoscalerThreadNumberDefault     = 3
+	configKeyAutoscalerHistorySize             = "autoscaler-history-size"
+	configKeyAutoscalerHistorySizeDefault      = 60
+	configKeyNomadNamespaces                   = "nomad-namespaces"
+	configKeyNomadRegionAddrs                  = "nomad-region-addrs"
+	configKeyNomadRegionTokens                 = "nomad-region-tokens"
+	configKeyNomadToken                        = "nomad-token"
 	configKeyPolicyEngineAPIEnabled            = "policy-engine-api-enabled"
 	configKeyPolicyEngineNomadMetaEnabled      = "policy-engine-nomad-meta-enabled"
 	configKeyPolicyEngineStrictCheckingEnabled = "policy-engine-strict-checking-enabled"
+	configKeyScalerCapacityCheckEnabled        = "scaler-capacity-check-enabled"
+	configKeyScalerCapacityWebhookAddr         = "scaler-capacity-webhook-addr"
 	configKeyStorageBackendConsulEnabled       = "storage-consul-enabled"
 	configKeyStorageBackendConsulPath          = "storage-consul-path"
 
@@ -28,28 +39,62 @@ const (
 )
 
 type Config struct {
-	Bind                         string
-	ConsulStorageBackendPath     string
-	Port                         uint16
-	APIPolicyEngine              bool
-	NomadMetaPolicyEngine        bool
-	StrictPolicyChecking         bool
-	InternalAutoScaler           bool
-	ConsulStorageBackend         bool
-	UI                           bool
-	InternalAutoScalerEvalPeriod int
-	InternalAutoScalerNumThreads int
+	Bind                          string
+	ConsulStorageBackendPath      string
+	Port                          uint16
+	APIPolicyEngine               bool
+	NomadMetaPolicyEngine         bool
+	StrictPolicyChecking          bool
+	ScalerCapacityCheck           bool
+	ScalerCapacityWebhookAddr     string
+	InternalAutoScaler            bool
+	InternalAutoScalerDryRun      bool
+	ConsulStorageBackend          bool
+	UI                            bool
+	InternalAutoScalerEvalPeriod  int
+	InternalAutoScalerNumThreads  int
+	InternalAutoScalerHistorySize int
+
+	// NomadNamespaces are the Nomad namespaces which Sherpa watches for jobs and deployments.
+	NomadNamespaces []string
+
+	// NomadRegionAddrs are the addresses of additional Nomad targets managed by Sherpa, in the form
+	// <region>=<address>.
+	NomadRegionAddrs []string
+
+	// NomadRegionTokens are the Nomad ACL tokens of additional Nomad targets, in the form
+	// <region>=<token>. It is deliberately excluded from logging.
+	NomadRegionTokens []string
+
+	// NomadToken is the Nomad ACL token used by Sherpa, overriding the NOMAD_TOKEN environment
+	// variable when set. It is deliberately excluded from logging.
+	NomadToken string
+
+	// ACLEnabled enables authentication and authorization of requests to the Sherpa API using
+	// ACL tokens.
+	ACLEnabled bool
+
+	// ACLBootstrapToken is the secret ID of a management token which is always accepted by the
+	// server, used to create the initial ACL tokens. It is deliberately excluded from logging.
+	ACLBootstrapToken string
 }
 
 func (c *Config) MarshalZerologObject(e *zerolog.Event) {
-	e.Str(configKeyBindAddr, c.Bind).
+	e.Bool(configKeyACLEnabled, c.ACLEnabled).
+		Str(configKeyBindAddr, c.Bind).
 		Uint16(configKeyBindPort, c.Port).
 		Bool(configKeyPolicyEngineAPIEnabled, c.APIPolicyEngine).
 		Bool(configKeyPolicyEngineNomadMetaEnabled, c.NomadMetaPolicyEngine).
 		Bool(configKeyPolicyEngineStrictCheckingEnabled, c.StrictPolicyChecking).
+		Bool(configKeyScalerCapacityCheckEnabled, c.ScalerCapacityCheck).
+		Str(configKeyScalerCapacityWebhookAddr, c.ScalerCapacityWebhookAddr).
 		Bool(configKeyAutoscalerEnabled, c.InternalAutoScaler).
+		Bool(configKeyAutoscalerDryRun, c.InternalAutoScalerDryRun).
 		Int(configKeyAutoscalerEvaluationInterval, c.InternalAutoScalerEvalPeriod).
 		Int(configKeyAutoscalerThreadNumber, c.InternalAutoScalerNumThreads).
+		Int(configKeyAutoscalerHistorySize, c.InternalAutoScalerHistorySize).
+		Strs(configKeyNomadNamespaces, c.NomadNamespaces).
+		Strs(configKeyNomadRegionAddrs, c.NomadRegionAddrs).
 		Bool(configKeyStorageBackendConsulEnabled, c.ConsulStorageBackend).
 		Str(configKeyStorageBackendConsulPath, c.ConsulStorageBackendPath).
 		Bool(configKeyUI, c.UI)
@@ -57,23 +102,59 @@ func (c *Config) MarshalZerologObject(e *zerolog.Event) {
 
 func GetConfig() Config {
 	return Config{
-		Bind:                         viper.GetString(configKeyBindAddr),
-		Port:                         uint16(viper.GetInt(configKeyBindPort)),
-		APIPolicyEngine:              viper.GetBool(configKeyPolicyEngineAPIEnabled),
-		NomadMetaPolicyEngine:        viper.GetBool(configKeyPolicyEngineNomadMetaEnabled),
-		StrictPolicyChecking:         viper.GetBool(configKeyPolicyEngineStrictCheckingEnabled),
-		InternalAutoScaler:           viper.GetBool(configKeyAutoscalerEnabled),
-		InternalAutoScalerEvalPeriod: viper.GetInt(configKeyAutoscalerEvaluationInterval),
-		InternalAutoScalerNumThreads: viper.GetInt(configKeyAutoscalerThreadNumber),
-		ConsulStorageBackend:         viper.GetBool(configKeyStorageBackendConsulEnabled),
-		ConsulStorageBackendPath:     viper.GetString(configKeyStorageBackendConsulPath),
-		UI:                           viper.GetBool(configKeyUI),
+		ACLEnabled:                    viper.GetBool(configKeyACLEnabled),
+		ACLBootstrapToken:             viper.GetString(configKeyACLBootstrapToken),
+		Bind:                          viper.GetString(configKeyBindAddr),
+		Port:                          uint16(viper.GetInt(configKeyBindPort)),
+		APIPolicyEngine:               viper.GetBool(configKeyPolicyEngineAPIEnabled),
+		NomadMetaPolicyEngine:         viper.GetBool(configKeyPolicyEngineNomadMetaEnabled),
+		StrictPolicyChecking:          viper.GetBool(configKeyPolicyEngineStrictCheckingEnabled),
+		ScalerCapacityCheck:           viper.GetBool(configKeyScalerCapacityCheckEnabled),
+		ScalerCapacityWebhookAddr:     viper.GetString(configKeyScalerCapacityWebhookAddr),
+		InternalAutoScaler:            viper.GetBool(configKeyAutoscalerEnabled),
+		InternalAutoScalerDryRun:      viper.GetBool(configKeyAutoscalerDryRun),
+		InternalAutoScalerEvalPeriod:  viper.GetInt(configKeyAutoscalerEvaluationInterval),
+		InternalAutoScalerNumThreads:  viper.GetInt(configKeyAutoscalerThreadNumber),
+		InternalAutoScalerHistorySize: viper.GetInt(configKeyAutoscalerHistorySize),
+		NomadNamespaces:               viper.GetStringSlice(configKeyNomadNamespaces),
+		NomadRegionAddrs:              viper.GetStringSlice(configKeyNomadRegionAddrs),
+		NomadRegionTokens:             viper.GetStringSlice(configKeyNomadRegionTokens),
+		NomadToken:                    viper.GetString(configKeyNomadToken),
+		ConsulStorageBackend:          viper.GetBool(configKeyStorageBackendConsulEnabled),
+		ConsulStorageBackendPath:      viper.GetString(configKeyStorageBackendConsulPath),
+		UI:                            viper.GetBool(configKeyUI),
 	}
 }
 
 func RegisterConfig(cmd *cobra.Command) {
 	flags := cmd.PersistentFlags()
 
+	{
+		const (
+			key          = configKeyACLEnabled
+			longOpt      = "acl-enabled"
+			defaultValue = false
+			description  = "Enable ACL token authentication and authorization of API requests"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyACLBootstrapToken
+			longOpt      = "acl-bootstrap-token"
+			defaultValue = ""
+			description  = "The secret ID of a management ACL token used to bootstrap the ACL system"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
 	{
 		const (
 			key          = configKeyBindAddr
@@ -139,6 +220,32 @@ func RegisterConfig(cmd *cobra.Command) {
 		viper.SetDefault(key, defaultValue)
 	}
 
+	{
+		const (
+			key          = configKeyScalerCapacityCheckEnabled
+			longOpt      = "scaler-capacity-check-enabled"
+			defaultValue = false
+			description  = "Cap scale out requests to the free capacity of the Nomad cluster"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyScalerCapacityWebhookAddr
+			longOpt      = "scaler-capacity-webhook-addr"
+			defaultValue = ""
+			description  = "The HTTP address to send insufficient cluster capacity events to"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
 	{
 		const (
 			key          = configKeyAutoscalerEnabled
@@ -152,6 +259,19 @@ func RegisterConfig(cmd *cobra.Command) {
 		viper.SetDefault(key, defaultValue)
 	}
 
+	{
+		const (
+			key          = configKeyAutoscalerDryRun
+			longOpt      = "autoscaler-dry-run"
+			defaultValue = false
+			description  = "Record internal autoscaling decisions as dry-run events rather than acting on them"
+		)
+
+		flags.Bool(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
 	{
 		const (
 			key          = configKeyAutoscalerEvaluationInterval
@@ -178,6 +298,19 @@ func RegisterConfig(cmd *cobra.Command) {
 		viper.SetDefault(key, defaultValue)
 	}
 
+	{
+		const (
+			key          = configKeyAutoscalerHistorySize
+			longOpt      = "autoscaler-history-size"
+			defaultValue = configKeyAutoscalerHistorySizeDefault
+			description  = "The number of autoscaler evaluations to store per job group, 0 disables evaluation history"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
 	{
 		const (
 			key          = configKeyStorageBackendConsulEnabled
@@ -204,6 +337,54 @@ func RegisterConfig(cmd *cobra.Command) {
 		viper.SetDefault(key, defaultValue)
 	}
 
+	{
+		const (
+			key         = configKeyNomadNamespaces
+			longOpt     = "nomad-namespaces"
+			description = "The Nomad namespaces to watch for jobs and deployments"
+		)
+		defaultValue := []string{"default"}
+
+		flags.StringSlice(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key         = configKeyNomadRegionAddrs
+			longOpt     = "nomad-region-addrs"
+			description = "The addresses of additional Nomad regions to manage, in the form <region>=<address>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key         = configKeyNomadRegionTokens
+			longOpt     = "nomad-region-tokens"
+			description = "The Nomad ACL tokens of additional Nomad regions, in the form <region>=<token>"
+		)
+
+		flags.StringSlice(longOpt, nil, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+	}
+
+	{
+		const (
+			key          = configKeyNomadToken
+			longOpt      = "nomad-token"
+			defaultValue = ""
+			description  = "The Nomad ACL token used to interact with Nomad, overriding NOMAD_TOKEN"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
 	{
 		const (
 			key          = configKeyUI
@@ -12,13 +12,23 @@ func Test_ServerConfig(t *testing.T) {
 	RegisterConfig(fakeCMD)
 
 	cfg := GetConfig()
+	assert.Equal(t, false, cfg.ACLEnabled)
+	assert.Equal(t, "", cfg.ACLBootstrapToken)
 	assert.Equal(t, configKeyBindAddrDefault, cfg.Bind)
 	assert.Equal(t, uint16(configKeyBindPortDefault), cfg.Port)
 	assert.Equal(t, true, cfg.APIPolicyEngine)
 	assert.Equal(t, false, cfg.NomadMetaPolicyEngine)
 	assert.Equal(t, true, cfg.StrictPolicyChecking)
+	assert.Equal(t, false, cfg.ScalerCapacityCheck)
+	assert.Equal(t, "", cfg.ScalerCapacityWebhookAddr)
 	assert.Equal(t, false, cfg.InternalAutoScaler)
+	assert.Equal(t, false, cfg.InternalAutoScalerDryRun)
 	assert.Equal(t, configKeyStorageBackendConsulPathDefault, cfg.ConsulStorageBackendPath)
 	assert.Equal(t, configKeyAutoscalerThreadNumberDefault, cfg.InternalAutoScalerNumThreads)
+	assert.Equal(t, configKeyAutoscalerHistorySizeDefault, cfg.InternalAutoScalerHistorySize)
+	assert.Equal(t, []string{"default"}, cfg.NomadNamespaces)
+	assert.Empty(t, cfg.NomadRegionAddrs)
+	assert.Empty(t, cfg.NomadRegionTokens)
+	assert.Equal(t, "", cfg.NomadToken)
 	assert.Equal(t, false, cfg.UI)
 }
@@ -7,24 +7,39 @@ import (
 )
 
 const (
-	configKeyServerTLSCertPath    = "tls-cert-path"
-	configKeyServerTLSCertKeyPath = "tls-cert-key-path"
+	configKeyServerTLSCertPath              = "tls-cert-path"
+	configKeyServerTLSCertKeyPath           = "tls-cert-key-path"
+	configKeyServerTLSClientCAPath          = "tls-client-ca-path"
+	configKeyServerTLSClientIdentity        = "tls-client-identity"
+	configKeyServerTLSClientIdentityDefault = "common-name"
 )
 
 type TLSConfig struct {
 	CertPath    string
 	CertKeyPath string
+
+	// ClientCAPath is the path to a PEM encoded CA bundle used to verify client certificates. When
+	// set, all clients are required to present a certificate signed by the CA.
+	ClientCAPath string
+
+	// ClientIdentity is the field of a verified client certificate used to identify the client
+	// when recording scaling events.
+	ClientIdentity string
 }
 
 func (c *TLSConfig) MarshalZerologObject(e *zerolog.Event) {
 	e.Str(configKeyServerTLSCertPath, c.CertPath).
-		Str(configKeyServerTLSCertKeyPath, c.CertKeyPath)
+		Str(configKeyServerTLSCertKeyPath, c.CertKeyPath).
+		Str(configKeyServerTLSClientCAPath, c.ClientCAPath).
+		Str(configKeyServerTLSClientIdentity, c.ClientIdentity)
 }
 
 func GetTLSConfig() TLSConfig {
 	return TLSConfig{
-		CertPath:    viper.GetString(configKeyServerTLSCertPath),
-		CertKeyPath: viper.GetString(configKeyServerTLSCertKeyPath),
+		CertPath:       viper.GetString(configKeyServerTLSCertPath),
+		CertKeyPath:    viper.GetString(configKeyServerTLSCertKeyPath),
+		ClientCAPath:   viper.GetString(configKeyServerTLSClientCAPath),
+		ClientIdentity: viper.GetString(configKeyServerTLSClientIdentity),
 	}
 }
 
@@ -56,4 +71,30 @@ func RegisterTLSConfig(cmd *cobra.Command) {
 		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
 		viper.SetDefault(key, defaultValue)
 	}
+
+	{
+		const (
+			key          = configKeyServerTLSClientCAPath
+			longOpt      = "tls-client-ca-path"
+			defaultValue = ""
+			description  = "Path to a PEM encoded CA bundle used to require and verify client certificates"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyServerTLSClientIdentity
+			longOpt      = "tls-client-identity"
+			defaultValue = configKeyServerTLSClientIdentityDefault
+			description  = "The client certificate field used as the client identity (common-name, dns-san, email-san or uri-san)"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
 }
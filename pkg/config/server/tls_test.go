@@ -14,4 +14,6 @@ func Test_TLSConfig(t *testing.T) {
 	cfg := GetTLSConfig()
 	assert.Equal(t, "", cfg.CertKeyPath)
 	assert.Equal(t, "", cfg.CertPath)
+	assert.Equal(t, "", cfg.ClientCAPath)
+	assert.Equal(t, configKeyServerTLSClientIdentityDefault, cfg.ClientIdentity)
 }
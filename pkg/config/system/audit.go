@@ -0,0 +1,69 @@
+package system
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	configKeyAuditJob       = "audit-job"
+	configKeyAuditOperation = "audit-operation"
+	configKeyAuditLimit     = "audit-limit"
+)
+
+type AuditConfig struct {
+	Job       string
+	Operation string
+	Limit     int
+}
+
+func GetAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		Job:       viper.GetString(configKeyAuditJob),
+		Operation: viper.GetString(configKeyAuditOperation),
+		Limit:     viper.GetInt(configKeyAuditLimit),
+	}
+}
+
+func RegisterAuditConfig(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+
+	{
+		const (
+			key          = configKeyAuditJob
+			longOpt      = "job"
+			defaultValue = ""
+			description  = "List only audit entries targeting the job, such as namespace/job for non-default namespaces"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAuditOperation
+			longOpt      = "operation"
+			defaultValue = ""
+			description  = "List only audit entries of the operation, such as ScaleOutJobGroup"
+		)
+
+		flags.String(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+
+	{
+		const (
+			key          = configKeyAuditLimit
+			longOpt      = "limit"
+			defaultValue = 0
+			description  = "The maximum number of audit entries to list, defaulting to the server limit"
+		)
+
+		flags.Int(longOpt, defaultValue, description)
+		_ = viper.BindPFlag(key, flags.Lookup(longOpt))
+		viper.SetDefault(key, defaultValue)
+	}
+}
@@ -0,0 +1,18 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AuditConfig(t *testing.T) {
+	fakeCMD := &cobra.Command{}
+	RegisterAuditConfig(fakeCMD)
+
+	cfg := GetAuditConfig()
+	assert.Equal(t, "", cfg.Job)
+	assert.Equal(t, "", cfg.Operation)
+	assert.Equal(t, 0, cfg.Limit)
+}
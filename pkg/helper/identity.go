@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// The client certificate fields which can be used as the identity of an API client.
+const (
+	IdentitySourceCommonName = "common-name"
+	IdentitySourceDNSSAN     = "dns-san"
+	IdentitySourceEmailSAN   = "email-san"
+	IdentitySourceURISAN     = "uri-san"
+)
+
+type identityContextKey struct{}
+
+// ValidateIdentitySource checks that the client certificate identity source is supported.
+func ValidateIdentitySource(source string) error {
+	switch source {
+	case IdentitySourceCommonName, IdentitySourceDNSSAN, IdentitySourceEmailSAN, IdentitySourceURISAN:
+		return nil
+	default:
+		return errors.Errorf("unsupported client identity source %q", source)
+	}
+}
+
+// CertificateIdentity returns the identity of the certificate, read from the field identified by
+// the source. Only the first SAN of the requested type is used.
+func CertificateIdentity(cert *x509.Certificate, source string) string {
+	switch source {
+	case IdentitySourceCommonName:
+		return cert.Subject.CommonName
+	case IdentitySourceDNSSAN:
+		if len(cert.DNSNames) > 0 {
+			return cert.DNSNames[0]
+		}
+	case IdentitySourceEmailSAN:
+		if len(cert.EmailAddresses) > 0 {
+			return cert.EmailAddresses[0]
+		}
+	case IdentitySourceURISAN:
+		if len(cert.URIs) > 0 {
+			return cert.URIs[0].String()
+		}
+	}
+	return ""
+}
+
+// RequestCertificateIdentity returns the identity of the verified client certificate of the HTTP
+// request, or an empty string if the client did not present a verified certificate.
+func RequestCertificateIdentity(r *http.Request, source string) string {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return CertificateIdentity(r.TLS.VerifiedChains[0][0], source)
+}
+
+// ContextWithIdentity returns a copy of the context holding the identity of the API client.
+func ContextWithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the API client, or an empty string if the client
+// is not identified.
+func IdentityFromContext(ctx context.Context) string {
+	identity, _ := ctx.Value(identityContextKey{}).(string)
+	return identity
+}
@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CertificateIdentity(t *testing.T) {
+	uri, _ := url.Parse("spiffe://example.com/ops")
+
+	cert := &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "ops-team"},
+		DNSNames:       []string{"ops.example.com", "ops2.example.com"},
+		EmailAddresses: []string{"ops@example.com"},
+		URIs:           []*url.URL{uri},
+	}
+
+	testCases := []struct {
+		source         string
+		cert           *x509.Certificate
+		expectedOutput string
+	}{
+		{source: IdentitySourceCommonName, cert: cert, expectedOutput: "ops-team"},
+		{source: IdentitySourceDNSSAN, cert: cert, expectedOutput: "ops.example.com"},
+		{source: IdentitySourceEmailSAN, cert: cert, expectedOutput: "ops@example.com"},
+		{source: IdentitySourceURISAN, cert: cert, expectedOutput: "spiffe://example.com/ops"},
+		{source: IdentitySourceDNSSAN, cert: &x509.Certificate{}, expectedOutput: ""},
+		{source: "serial", cert: cert, expectedOutput: ""},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, CertificateIdentity(tc.cert, tc.source), tc.source)
+	}
+}
+
+func Test_ValidateIdentitySource(t *testing.T) {
+	assert.Nil(t, ValidateIdentitySource(IdentitySourceCommonName))
+	assert.Nil(t, ValidateIdentitySource(IdentitySourceURISAN))
+	assert.NotNil(t, ValidateIdentitySource("serial"))
+}
+
+func Test_RequestCertificateIdentity(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/scale/status", nil)
+	assert.Equal(t, "", RequestCertificateIdentity(req, IdentitySourceCommonName))
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "ops-team"}}
+	req.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	assert.Equal(t, "ops-team", RequestCertificateIdentity(req, IdentitySourceCommonName))
+
+	ctx := ContextWithIdentity(context.Background(), "ops-team")
+	assert.Equal(t, "ops-team", IdentityFromContext(ctx))
+	assert.Equal(t, "", IdentityFromContext(context.Background()))
+}
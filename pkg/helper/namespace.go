@@ -0,0 +1,73 @@
+package helper
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// DefaultNamespace is the Nomad namespace used when one is not specified.
+	DefaultNamespace = "default"
+
+	// NamespaceQueryParam is the HTTP query parameter used to specify the Nomad namespace of
+	// the job being referenced by an API request.
+	NamespaceQueryParam = "namespace"
+
+	// RegionQueryParam is the HTTP query parameter used to specify the Nomad region of the job
+	// being referenced by an API request.
+	RegionQueryParam = "region"
+)
+
+// NamespacedJobID returns the identifier Sherpa uses for a Nomad job. Jobs within the default
+// namespace are identified by their job ID alone so that existing policies and state remain
+// valid; jobs in all other namespaces are identified as namespace/job. Default namespace jobs
+// whose ID contains a forward slash or @, such as periodic and dispatch children, are also
+// identified as default/job, so that their ID cannot be misread as a namespace or region.
+func NamespacedJobID(namespace, job string) string {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	if namespace == DefaultNamespace && !strings.ContainsAny(job, "/@") {
+		return job
+	}
+	return namespace + "/" + job
+}
+
+// SplitNamespacedJobID splits a Sherpa job identifier into the Nomad namespace and job ID. Nomad
+// namespaces cannot contain a forward slash, so the first slash always marks the boundary.
+func SplitNamespacedJobID(id string) (string, string) {
+	if i := strings.Index(id, "/"); i > 0 {
+		return id[:i], id[i+1:]
+	}
+	return DefaultNamespace, id
+}
+
+// RegionalJobID returns the identifier Sherpa uses for a Nomad job within the region. Jobs of the
+// primary Nomad target have an empty region and are identified by their namespaced job ID alone;
+// jobs of all other targets are identified as region@id.
+func RegionalJobID(region, id string) string {
+	if region == "" {
+		return id
+	}
+	return region + "@" + id
+}
+
+// SplitRegionalJobID splits a Sherpa job identifier into the Nomad region and the namespaced job
+// ID. The region is only recognised before any namespace separator, as region names and
+// namespaces cannot contain a forward slash.
+func SplitRegionalJobID(id string) (string, string) {
+	i := strings.Index(id, "@")
+	if i <= 0 || strings.Contains(id[:i], "/") {
+		return "", id
+	}
+	return id[:i], id[i+1:]
+}
+
+// JobIDFromRequest builds the Sherpa job identifier from the job_id route variable and the
+// optional region and namespace query parameters of an HTTP request.
+func JobIDFromRequest(r *http.Request) string {
+	q := r.URL.Query()
+	return RegionalJobID(q.Get(RegionQueryParam), NamespacedJobID(q.Get(NamespaceQueryParam), mux.Vars(r)["job_id"]))
+}
@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NamespacedJobID(t *testing.T) {
+	testCases := []struct {
+		namespace       string
+		job             string
+		expectedID      string
+		expectedNS      string
+		expectedJobName string
+	}{
+		{namespace: "", job: "example", expectedID: "example", expectedNS: "default", expectedJobName: "example"},
+		{namespace: "default", job: "example", expectedID: "example", expectedNS: "default", expectedJobName: "example"},
+		{namespace: "platform", job: "example", expectedID: "platform/example", expectedNS: "platform", expectedJobName: "example"},
+		{namespace: "platform", job: "batch/periodic-123", expectedID: "platform/batch/periodic-123", expectedNS: "platform", expectedJobName: "batch/periodic-123"},
+		{namespace: "", job: "batch/periodic-1700000000", expectedID: "default/batch/periodic-1700000000", expectedNS: "default", expectedJobName: "batch/periodic-1700000000"},
+		{namespace: "default", job: "batch/dispatch-1700000000-3a9c", expectedID: "default/batch/dispatch-1700000000-3a9c", expectedNS: "default", expectedJobName: "batch/dispatch-1700000000-3a9c"},
+		{namespace: "default", job: "eu-west-1@example", expectedID: "default/eu-west-1@example", expectedNS: "default", expectedJobName: "eu-west-1@example"},
+	}
+
+	for _, tc := range testCases {
+		id := NamespacedJobID(tc.namespace, tc.job)
+		assert.Equal(t, tc.expectedID, id)
+
+		ns, job := SplitNamespacedJobID(id)
+		assert.Equal(t, tc.expectedNS, ns)
+		assert.Equal(t, tc.expectedJobName, job)
+	}
+}
+
+func Test_RegionalJobID(t *testing.T) {
+	testCases := []struct {
+		region         string
+		id             string
+		expectedID     string
+		expectedRegion string
+		expectedJobID  string
+	}{
+		{region: "", id: "example", expectedID: "example", expectedRegion: "", expectedJobID: "example"},
+		{region: "eu-west-1", id: "example", expectedID: "eu-west-1@example", expectedRegion: "eu-west-1", expectedJobID: "example"},
+		{region: "eu-west-1", id: "platform/example", expectedID: "eu-west-1@platform/example", expectedRegion: "eu-west-1", expectedJobID: "platform/example"},
+		{region: "", id: "platform/example@v2", expectedID: "platform/example@v2", expectedRegion: "", expectedJobID: "platform/example@v2"},
+	}
+
+	for _, tc := range testCases {
+		id := RegionalJobID(tc.region, tc.id)
+		assert.Equal(t, tc.expectedID, id)
+
+		region, jobID := SplitRegionalJobID(id)
+		assert.Equal(t, tc.expectedRegion, region)
+		assert.Equal(t, tc.expectedJobID, jobID)
+	}
+}
+
+func Test_JobIDFromRequest(t *testing.T) {
+	req := httptest.NewRequest("GET", "/v1/policy/example?namespace=platform", nil)
+	req = mux.SetURLVars(req, map[string]string{"job_id": "example"})
+	assert.Equal(t, "platform/example", JobIDFromRequest(req))
+
+	req = httptest.NewRequest("GET", "/v1/policy/example?namespace=platform&region=eu-west-1", nil)
+	req = mux.SetURLVars(req, map[string]string{"job_id": "example"})
+	assert.Equal(t, "eu-west-1@platform/example", JobIDFromRequest(req))
+
+	req = httptest.NewRequest("GET", "/v1/policy/example", nil)
+	req = mux.SetURLVars(req, map[string]string{"job_id": "eu-west-1@example"})
+	assert.Equal(t, "default/eu-west-1@example", JobIDFromRequest(req))
+
+	req = httptest.NewRequest("GET", "/v1/policy/example", nil)
+	req = mux.SetURLVars(req, map[string]string{"job_id": "example"})
+	assert.Equal(t, "example", JobIDFromRequest(req))
+}
@@ -2,3 +2,6 @@ package helper
 
 // Float64Pointer is a helper function to return a pointer to f.
 func Float64ToPointer(f float64) *float64 { return &f }
+
+// IntToPointer is a helper function to return a pointer to i.
+func IntToPointer(i int) *int { return &i }
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	serverCfg "github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// NewDispatcherFromConfig builds the notifiers configured by the operator and returns a
+// dispatcher which routes to them. Notifier names must be unique across all notifier types.
+func NewDispatcherFromConfig(logger zerolog.Logger, cfg *serverCfg.NotifierConfig) (*Dispatcher, error) {
+	webhookAddrs, err := ParseNamedValues(cfg.WebhookAddrs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse webhook notifier addresses")
+	}
+	webhookSecrets, err := ParseNamedValues(cfg.WebhookSecrets)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse webhook notifier secrets")
+	}
+	webhookTemplates, err := ParseNamedValues(cfg.WebhookTemplates)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse webhook notifier templates")
+	}
+	slackAddrs, err := ParseNamedValues(cfg.SlackAddrs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse Slack notifier addresses")
+	}
+	pagerDutyKeys, err := ParseNamedValues(cfg.PagerDutyRoutingKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse PagerDuty notifier routing keys")
+	}
+
+	for name := range webhookSecrets {
+		if _, ok := webhookAddrs[name]; !ok {
+			return nil, errors.Errorf("webhook notifier secret %q has no address", name)
+		}
+	}
+	for name := range webhookTemplates {
+		if _, ok := webhookAddrs[name]; !ok {
+			return nil, errors.Errorf("webhook notifier template %q has no address", name)
+		}
+	}
+
+	notifiers := make(map[string]Notifier)
+
+	add := func(name string, n Notifier) error {
+		if _, ok := notifiers[name]; ok {
+			return errors.Errorf("notifier name %q is used by more than one notifier", name)
+		}
+		notifiers[name] = n
+		return nil
+	}
+
+	for name, addr := range webhookAddrs {
+		n, err := NewWebhookNotifier(addr, webhookSecrets[name], webhookTemplates[name], cfg.Retries)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to setup webhook notifier %q", name)
+		}
+		if err := add(name, n); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, addr := range slackAddrs {
+		if err := add(name, NewSlackNotifier(addr, cfg.Retries)); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, key := range pagerDutyKeys {
+		if err := add(name, NewPagerDutyNotifier(cfg.PagerDutyAddr, key, cfg.Retries)); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewDispatcher(logger, notifiers, cfg.Defaults)
+}
@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// requestTimeout is the HTTP client timeout used for each notification request.
+	requestTimeout = 10 * time.Second
+
+	// defaultRetryBackoff is the wait before the first retry of a failed notification request,
+	// which doubles on each subsequent retry.
+	defaultRetryBackoff = time.Second
+)
+
+// sender sends notification requests over HTTP, retrying requests which fail due to network
+// errors or retryable response codes.
+type sender struct {
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// JSONSender sends JSON encoded values to a HTTP address, retrying failed requests in the same
+// way as the notifiers. It is used to deliver payloads which are not scaling event notifications,
+// such as insufficient cluster capacity events.
+type JSONSender struct {
+	addr   string
+	sender *sender
+}
+
+// NewJSONSender returns a JSON sender for the address, which retries failed requests up to the
+// number of retries.
+func NewJSONSender(addr string, retries int) *JSONSender {
+	return &JSONSender{addr: addr, sender: newSender(retries)}
+}
+
+// Send posts the JSON encoded value to the address.
+func (j *JSONSender) Send(v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return j.sender.post(j.addr, body, nil)
+}
+
+func newSender(retries int) *sender {
+	return &sender{
+		client:  &http.Client{Timeout: requestTimeout},
+		retries: retries,
+		backoff: defaultRetryBackoff,
+	}
+}
+
+// post sends the body as a JSON POST request with the headers, retrying up to the configured
+// number of times.
+func (s *sender) post(addr string, body []byte, headers map[string]string) error {
+	var err error
+
+	backoff := s.backoff
+
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var retry bool
+		if retry, err = s.attempt(addr, body, headers); err == nil || !retry {
+			return err
+		}
+	}
+	return errors.Wrapf(err, "failed after %d retries", s.retries)
+}
+
+// attempt sends a single request, returning whether a failed request should be retried.
+func (s *sender) attempt(addr string, body []byte, headers map[string]string) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, addr, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, errors.Errorf("unexpected response code %d", resp.StatusCode)
+	default:
+		return false, errors.Errorf("unexpected response code %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,152 @@
+package notify
+
+import (
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// StatusSkipped is the status of events sent when a scaling request was rejected before a scaling
+// event could be recorded, such as when it fails policy validation or scale in safety checks.
+const StatusSkipped = "Skipped"
+
+// Define our metric keys.
+var (
+	metricKeyNotify       = []string{"notify", "send"}
+	metricKeyNotifyFailed = []string{"notify", "failed"}
+)
+
+// Event is a notification of a scaling event, or skipped scaling request, of a single job group.
+type Event struct {
+
+	// ID is the scaling ID. This is empty for skipped scaling requests.
+	ID string `json:",omitempty"`
+
+	// Job and Group identify the job group which was scaled.
+	Job   string
+	Group string
+
+	// Status is the scaling event status, or StatusSkipped.
+	Status string
+
+	// Source shows the origin source of the scaling request.
+	Source string
+
+	// Time is a UnixNano timestamp declaring when the scaling request was triggered.
+	Time int64
+
+	// Count and Direction describe the change requested to the job group.
+	Count     int
+	Direction string
+
+	// EvalID is the Nomad evaluation ID created by the scaling event, if any.
+	EvalID string `json:",omitempty"`
+
+	// Identity is the identity of the API client which requested the scaling.
+	Identity string `json:",omitempty"`
+
+	// Reason details why the scaling failed or was skipped.
+	Reason string `json:",omitempty"`
+
+	Meta map[string]string `json:",omitempty"`
+}
+
+// Failed returns whether the event represents a scaling request which failed, was skipped or
+// could not be fully placed.
+func (e *Event) Failed() bool {
+	switch e.Status {
+	case state.StatusFailed, state.StatusInsufficientCapacity, StatusSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// Notifier is the interface which notification sinks implement in order to deliver scaling
+// event notifications.
+type Notifier interface {
+
+	// Notify delivers the event notification, returning an error if it could not be delivered
+	// after any retries.
+	Notify(e *Event) error
+}
+
+// Dispatcher routes scaling event notifications to the configured notifiers, which are
+// identified by operator specified names.
+type Dispatcher struct {
+	logger    zerolog.Logger
+	notifiers map[string]Notifier
+
+	// defaults are the names of the notifiers used for job groups whose scaling policy does not
+	// specify any notifiers.
+	defaults []string
+}
+
+// NewDispatcher returns a dispatcher for the named notifiers, ensuring the default notifiers
+// exist.
+func NewDispatcher(logger zerolog.Logger, notifiers map[string]Notifier, defaults []string) (*Dispatcher, error) {
+	for _, name := range defaults {
+		if _, ok := notifiers[name]; !ok {
+			return nil, errors.Errorf("default notifier %q is not configured", name)
+		}
+	}
+	return &Dispatcher{logger: logger, notifiers: notifiers, defaults: defaults}, nil
+}
+
+// Dispatch asynchronously sends the event to the notifiers named by the routes, or to the default
+// notifiers if no routes are specified. A nil dispatcher does nothing, allowing callers to use a
+// nil value when notifications are not configured.
+func (d *Dispatcher) Dispatch(e *Event, routes []string) {
+	if d == nil {
+		return
+	}
+
+	if len(routes) == 0 {
+		routes = d.defaults
+	}
+
+	for _, name := range routes {
+		n, ok := d.notifiers[name]
+		if !ok {
+			d.logger.Warn().
+				Str("notifier", name).
+				Str("job", e.Job).
+				Str("group", e.Group).
+				Msg("scaling policy references unknown notifier")
+			continue
+		}
+		go d.send(name, n, e)
+	}
+}
+
+func (d *Dispatcher) send(name string, n Notifier, e *Event) {
+	defer metrics.MeasureSince(metricKeyNotify, time.Now())
+
+	if err := n.Notify(e); err != nil {
+		metrics.IncrCounter(metricKeyNotifyFailed, 1)
+		d.logger.Error().
+			Err(err).
+			Str("notifier", name).
+			Str("job", e.Job).
+			Str("group", e.Group).
+			Msg("failed to send scaling event notification")
+	}
+}
+
+// ParseNamedValues parses values in the form <name>=<value> into a map keyed by name.
+func ParseNamedValues(values []string) (map[string]string, error) {
+	out := make(map[string]string)
+
+	for _, v := range values {
+		split := strings.SplitN(v, "=", 2)
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return nil, errors.Errorf("invalid value %q, expected <name>=<value>", v)
+		}
+		out[split[0]] = split[1]
+	}
+	return out, nil
+}
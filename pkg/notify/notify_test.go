@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNotifier struct {
+	events chan *Event
+}
+
+func (t *testNotifier) Notify(e *Event) error {
+	t.events <- e
+	return nil
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	ops := &testNotifier{events: make(chan *Event, 1)}
+	platform := &testNotifier{events: make(chan *Event, 1)}
+
+	_, err := NewDispatcher(zerolog.Nop(), map[string]Notifier{"ops": ops}, []string{"unknown"})
+	assert.NotNil(t, err)
+
+	d, err := NewDispatcher(zerolog.Nop(), map[string]Notifier{"ops": ops, "platform": platform}, []string{"ops"})
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		routes   []string
+		expected *testNotifier
+		other    *testNotifier
+		name     string
+	}{
+		{routes: nil, expected: ops, other: platform, name: "default notifiers"},
+		{routes: []string{"platform", "unknown"}, expected: platform, other: ops, name: "policy routed notifiers"},
+	}
+
+	for _, tc := range testCases {
+		e := &Event{Job: "example", Group: "cache", Status: state.StatusCompleted}
+		d.Dispatch(e, tc.routes)
+
+		select {
+		case actual := <-tc.expected.events:
+			assert.Equal(t, e, actual, tc.name)
+		case <-time.After(time.Second):
+			t.Fatalf("%s: notification not received", tc.name)
+		}
+
+		select {
+		case <-tc.other.events:
+			t.Fatalf("%s: notification unexpectedly routed", tc.name)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// A nil dispatcher is used when notifications are not configured.
+	var nilDispatcher *Dispatcher
+	nilDispatcher.Dispatch(&Event{}, nil)
+}
+
+func TestParseNamedValues(t *testing.T) {
+	testCases := []struct {
+		input          []string
+		expectedOutput map[string]string
+		expectedError  bool
+		name           string
+	}{
+		{input: nil, expectedOutput: map[string]string{}, name: "no values"},
+		{
+			input:          []string{"ops=https://example.com/hook?a=b", "platform=http://127.0.0.1"},
+			expectedOutput: map[string]string{"ops": "https://example.com/hook?a=b", "platform": "http://127.0.0.1"},
+			name:           "valid values",
+		},
+		{input: []string{"ops"}, expectedError: true, name: "missing value"},
+		{input: []string{"=http://127.0.0.1"}, expectedError: true, name: "missing name"},
+	}
+
+	for _, tc := range testCases {
+		actual, err := ParseNamedValues(tc.input)
+		if tc.expectedError {
+			assert.NotNil(t, err, tc.name)
+			continue
+		}
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedOutput, actual, tc.name)
+	}
+}
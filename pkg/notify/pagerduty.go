@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+const (
+	pagerDutyActionTrigger = "trigger"
+	pagerDutyActionResolve = "resolve"
+	pagerDutySource        = "sherpa"
+)
+
+var _ Notifier = (*PagerDutyNotifier)(nil)
+
+// PagerDutyNotifier sends scaling event notifications to the PagerDuty Events API v2. Only failed
+// and skipped scaling triggers an alert, which is deduplicated per job group; the alert is
+// resolved by the next completed scaling event of the job group. Other events are ignored.
+type PagerDutyNotifier struct {
+	addr       string
+	routingKey string
+	sender     *sender
+
+	// triggered tracks the job groups which have an alert triggered by this notifier, so that
+	// resolve events are only sent when required.
+	triggered     map[string]bool
+	triggeredLock sync.Mutex
+}
+
+// pagerDutyEvent is the PagerDuty Events API v2 request body.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Timestamp     string `json:"timestamp,omitempty"`
+	Component     string `json:"component"`
+	Group         string `json:"group"`
+	Class         string `json:"class"`
+	CustomDetails *Event `json:"custom_details"`
+}
+
+// NewPagerDutyNotifier returns a PagerDuty notifier which sends events to the addr using the
+// integration routing key.
+func NewPagerDutyNotifier(addr, routingKey string, retries int) *PagerDutyNotifier {
+	return &PagerDutyNotifier{
+		addr:       addr,
+		routingKey: routingKey,
+		sender:     newSender(retries),
+		triggered:  make(map[string]bool),
+	}
+}
+
+func (p *PagerDutyNotifier) Notify(e *Event) error {
+	dedupKey := fmt.Sprintf("%s/%s/%s", pagerDutySource, e.Job, e.Group)
+
+	var event *pagerDutyEvent
+
+	switch {
+	case e.Failed():
+		event = &pagerDutyEvent{
+			EventAction: pagerDutyActionTrigger,
+			Payload: &pagerDutyPayload{
+				Summary:       fmt.Sprintf("Sherpa scaling of job %s group %s: %s", e.Job, e.Group, e.Status),
+				Source:        pagerDutySource,
+				Severity:      pagerDutySeverity(e),
+				Timestamp:     time.Unix(0, e.Time).UTC().Format(time.RFC3339),
+				Component:     e.Job,
+				Group:         e.Group,
+				Class:         e.Status,
+				CustomDetails: e,
+			},
+		}
+	case e.Status == state.StatusCompleted && p.isTriggered(dedupKey):
+		event = &pagerDutyEvent{EventAction: pagerDutyActionResolve}
+	default:
+		return nil
+	}
+
+	event.RoutingKey = p.routingKey
+	event.DedupKey = dedupKey
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := p.sender.post(p.addr, body, nil); err != nil {
+		return err
+	}
+
+	p.triggeredLock.Lock()
+	if event.EventAction == pagerDutyActionTrigger {
+		p.triggered[dedupKey] = true
+	} else {
+		delete(p.triggered, dedupKey)
+	}
+	p.triggeredLock.Unlock()
+	return nil
+}
+
+func (p *PagerDutyNotifier) isTriggered(dedupKey string) bool {
+	p.triggeredLock.Lock()
+	defer p.triggeredLock.Unlock()
+	return p.triggered[dedupKey]
+}
+
+// pagerDutySeverity returns the alert severity of the event. Failed scaling is an error, whereas
+// skipped and partially placed scaling are warnings as Sherpa acted as configured.
+func pagerDutySeverity(e *Event) string {
+	if e.Status == state.StatusFailed {
+		return "error"
+	}
+	return "warning"
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagerDutyNotifier_Notify(t *testing.T) {
+	var received []*pagerDutyEvent
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e pagerDutyEvent
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&e))
+		received = append(received, &e)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n := NewPagerDutyNotifier(srv.URL, "routing-key", 0)
+
+	// Completed events should not be sent while no alert is triggered for the job group.
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: state.StatusCompleted}))
+	assert.Len(t, received, 0)
+
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: state.StatusFailed, Reason: "Nomad unavailable"}))
+	assert.Len(t, received, 1)
+	assert.Equal(t, "routing-key", received[0].RoutingKey)
+	assert.Equal(t, pagerDutyActionTrigger, received[0].EventAction)
+	assert.Equal(t, "sherpa/example/cache", received[0].DedupKey)
+	assert.Equal(t, "error", received[0].Payload.Severity)
+	assert.Equal(t, "Nomad unavailable", received[0].Payload.CustomDetails.Reason)
+
+	// Dry-run events are ignored.
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: state.StatusDryRun}))
+	assert.Len(t, received, 1)
+
+	// The next completed event should resolve the triggered alert, and only once.
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: state.StatusCompleted}))
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: state.StatusCompleted}))
+	assert.Len(t, received, 2)
+	assert.Equal(t, pagerDutyActionResolve, received[1].EventAction)
+	assert.Equal(t, "sherpa/example/cache", received[1].DedupKey)
+	assert.Nil(t, received[1].Payload)
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var _ Notifier = (*SlackNotifier)(nil)
+
+// SlackNotifier sends scaling event notifications to a Slack incoming webhook, or any chat
+// service which accepts Slack compatible webhook messages.
+type SlackNotifier struct {
+	addr   string
+	sender *sender
+}
+
+// slackMessage is the Slack incoming webhook message body.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+func NewSlackNotifier(addr string, retries int) *SlackNotifier {
+	return &SlackNotifier{addr: addr, sender: newSender(retries)}
+}
+
+func (s *SlackNotifier) Notify(e *Event) error {
+	body, err := json.Marshal(&slackMessage{Text: slackText(e)})
+	if err != nil {
+		return err
+	}
+	return s.sender.post(s.addr, body, nil)
+}
+
+// slackText formats the event as a Slack message using Slack markdown.
+func slackText(e *Event) string {
+	var b strings.Builder
+
+	if e.Failed() {
+		b.WriteString(":warning: ")
+	}
+
+	fmt.Fprintf(&b, "Sherpa scaling of job `%s` group `%s` %s %v: *%s*",
+		e.Job, e.Group, e.Direction, e.Count, e.Status)
+	fmt.Fprintf(&b, "\nSource: %s", e.Source)
+
+	if e.Identity != "" {
+		fmt.Fprintf(&b, " (%s)", e.Identity)
+	}
+	if e.ID != "" {
+		fmt.Fprintf(&b, "\nID: %s", e.ID)
+	}
+	if e.Reason != "" {
+		fmt.Fprintf(&b, "\nReason: %s", e.Reason)
+	}
+	return b.String()
+}
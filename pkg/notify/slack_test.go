@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var msg slackMessage
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&msg))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n := NewSlackNotifier(srv.URL, 0)
+	assert.Nil(t, n.Notify(&Event{
+		Job:       "example",
+		Group:     "cache",
+		Status:    StatusSkipped,
+		Source:    "API",
+		Direction: "out",
+		Count:     2,
+		Identity:  "ops-team",
+		Reason:    "job group scaling policy is currently disabled",
+	}))
+
+	expected := ":warning: Sherpa scaling of job `example` group `cache` out 2: *Skipped*\n" +
+		"Source: API (ops-team)\nReason: job group scaling policy is currently disabled"
+	assert.Equal(t, expected, msg.Text)
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader is the header which holds the HMAC-SHA256 signature of webhook notification
+// bodies, in the form sha256=<hex digest>.
+const SignatureHeader = "X-Sherpa-Signature"
+
+var _ Notifier = (*WebhookNotifier)(nil)
+
+// WebhookNotifier sends scaling event notifications to a generic HTTP endpoint. The body is the
+// JSON encoded event, unless a template is configured.
+type WebhookNotifier struct {
+	addr   string
+	secret []byte
+	tmpl   *template.Template
+	sender *sender
+}
+
+// NewWebhookNotifier returns a webhook notifier. The secret and template path are optional; when
+// set the body is signed using the secret, and rendered from the Go template file at the path.
+func NewWebhookNotifier(addr, secret, templatePath string, retries int) (*WebhookNotifier, error) {
+	w := &WebhookNotifier{addr: addr, secret: []byte(secret), sender: newSender(retries)}
+
+	if templatePath != "" {
+		b, err := ioutil.ReadFile(templatePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read webhook template")
+		}
+
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{"json": templateJSON}).Parse(string(b))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse webhook template")
+		}
+		w.tmpl = tmpl
+	}
+	return w, nil
+}
+
+func (w *WebhookNotifier) Notify(e *Event) error {
+	body, err := w.body(e)
+	if err != nil {
+		return err
+	}
+
+	var headers map[string]string
+	if len(w.secret) > 0 {
+		headers = map[string]string{SignatureHeader: Signature(w.secret, body)}
+	}
+	return w.sender.post(w.addr, body, headers)
+}
+
+// body renders the notification body, ensuring templated bodies are valid JSON.
+func (w *WebhookNotifier) body(e *Event) ([]byte, error) {
+	if w.tmpl == nil {
+		return json.Marshal(e)
+	}
+
+	var buf bytes.Buffer
+	if err := w.tmpl.Execute(&buf, e); err != nil {
+		return nil, errors.Wrap(err, "failed to execute webhook template")
+	}
+
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.New("webhook template did not render valid JSON")
+	}
+	return buf.Bytes(), nil
+}
+
+// Signature returns the HMAC-SHA256 signature of the body using the secret, in the form used by
+// the SignatureHeader.
+func Signature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// templateJSON is the json template function, which encodes the value as JSON so that strings
+// are safely quoted and escaped.
+func templateJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sherpa-notify")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tmplPath := filepath.Join(dir, "webhook.tmpl")
+	assert.Nil(t, ioutil.WriteFile(tmplPath,
+		[]byte(`{"summary":{{ printf "%s/%s %s" .Job .Group .Status | json }},"count":{{ .Count }}}`), 0600))
+
+	var (
+		attempts  int
+		body      []byte
+		signature string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ = ioutil.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	n, err := NewWebhookNotifier(srv.URL, "secret", tmplPath, 2)
+	assert.Nil(t, err)
+	n.sender.backoff = time.Millisecond
+
+	// The first attempt fails with a retryable response code, and so should be retried.
+	assert.Nil(t, n.Notify(&Event{Job: "example", Group: "cache", Status: "Completed", Count: 2}))
+	assert.Equal(t, 2, attempts)
+	assert.JSONEq(t, `{"summary":"example/cache Completed","count":2}`, string(body))
+	assert.Equal(t, Signature([]byte("secret"), body), signature)
+
+	// Templates which do not render valid JSON should not be sent.
+	assert.Nil(t, ioutil.WriteFile(tmplPath, []byte(`{"job":{{ .Job }}}`), 0600))
+	n, err = NewWebhookNotifier(srv.URL, "", tmplPath, 0)
+	assert.Nil(t, err)
+	assert.NotNil(t, n.Notify(&Event{Job: "example"}))
+}
+
+func TestWebhookNotifier_NotifyRetries(t *testing.T) {
+	var attempts int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n, err := NewWebhookNotifier(srv.URL, "", "", 2)
+	assert.Nil(t, err)
+	n.sender.backoff = time.Millisecond
+
+	assert.NotNil(t, n.Notify(&Event{Job: "example"}))
+	assert.Equal(t, 3, attempts)
+
+	// Client errors are not retried.
+	attempts = 0
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	assert.NotNil(t, n.Notify(&Event{Job: "example"}))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestJSONSender_Send(t *testing.T) {
+	var (
+		attempts int
+		body     []byte
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s := NewJSONSender(srv.URL, 2)
+	s.sender.backoff = time.Millisecond
+
+	// The first attempt fails with a retryable response code, and so should be retried.
+	assert.Nil(t, s.Send(map[string]interface{}{"Type": "InsufficientClusterCapacity", "Job": "example"}))
+	assert.Equal(t, 2, attempts)
+	assert.JSONEq(t, `{"Type":"InsufficientClusterCapacity","Job":"example"}`, string(body))
+
+	// Client errors are not retried.
+	attempts = 0
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	assert.EqualError(t, s.Send(map[string]string{"Job": "example"}), "unexpected response code 400")
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,134 @@
+package policy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// AdjustmentType dictates how the scaling counts of a policy are used to change the job group
+// count.
+type AdjustmentType string
+
+// String returns the string form of the AdjustmentType.
+func (at AdjustmentType) String() string { return string(at) }
+
+// Validate checks the AdjustmentType is valid and that it can be handled by the scaler.
+func (at AdjustmentType) Validate() error {
+	switch at {
+	case "", AdjustmentTypeCount, AdjustmentTypePercent:
+		return nil
+	default:
+		return errors.Errorf("AdjustmentType %s is not a valid option", at.String())
+	}
+}
+
+const (
+	// AdjustmentTypeCount changes the job group count by the scaling count.
+	AdjustmentTypeCount AdjustmentType = "count"
+
+	// AdjustmentTypePercent changes the job group count by the scaling count as a percentage of
+	// the current job group count.
+	AdjustmentTypePercent AdjustmentType = "percent"
+)
+
+// StepAdjustment is a scaling count used when the metric value of a threshold check breaks its
+// threshold by at least the LowerBound.
+type StepAdjustment struct {
+
+	// LowerBound is the percentage by which the metric value must break its threshold for the
+	// step to be used.
+	LowerBound float64 `json:"LowerBound"`
+
+	// Count is the scaling count used when the step is selected. It is interpreted according to
+	// the policy AdjustmentType.
+	Count int `json:"Count"`
+}
+
+// PercentageAdjustment identifies whether the scaling counts of the policy are percentages of the
+// current job group count.
+func (gsp GroupScalingPolicy) PercentageAdjustment() bool {
+	return gsp.AdjustmentType == AdjustmentTypePercent
+}
+
+// ActionCount returns the scaling count for the action, where breach is the percentage by which
+// the metric value broke its threshold. The step with the largest LowerBound not greater than the
+// breach is used; if no step applies the ScaleOutCount or ScaleInCount is used.
+func (gsp GroupScalingPolicy) ActionCount(action ComparisonAction, breach float64) int {
+	count, steps := gsp.ScaleOutCount, gsp.ScaleOutSteps
+	if action == ActionScaleIn {
+		count, steps = gsp.ScaleInCount, gsp.ScaleInSteps
+	}
+
+	var bound float64
+	for _, step := range steps {
+		if step.LowerBound <= breach && step.LowerBound >= bound {
+			count, bound = step.Count, step.LowerBound
+		}
+	}
+	return count
+}
+
+// AdjustmentCount returns the number by which to change the job group count, converting the
+// percentage count using the current job group count. The result is rounded up, and is never less
+// than the MinAdjustmentStep.
+func (gsp GroupScalingPolicy) AdjustmentCount(current, percentage int) int {
+	count := int(math.Ceil(float64(current) * float64(percentage) / 100))
+	if count < gsp.MinAdjustmentStep {
+		return gsp.MinAdjustmentStep
+	}
+	return count
+}
+
+// validateAdjustments ensures the adjustment type and step adjustments of the group policy are
+// valid. Step adjustments are selected by how far a threshold is broken, so cannot be used with
+// target tracking.
+func (gsp GroupScalingPolicy) validateAdjustments() error {
+	if err := gsp.AdjustmentType.Validate(); err != nil {
+		return err
+	}
+	if gsp.MinAdjustmentStep < 0 {
+		return errors.New("MinAdjustmentStep must not be negative")
+	}
+	if gsp.MinAdjustmentStep > 0 && !gsp.PercentageAdjustment() {
+		return errors.New("MinAdjustmentStep can only be set when using the percent AdjustmentType")
+	}
+
+	if len(gsp.ScaleOutSteps) == 0 && len(gsp.ScaleInSteps) == 0 {
+		return nil
+	}
+	if gsp.TargetTrackingEnabled() {
+		return errors.New("step adjustments cannot be used with target tracking")
+	}
+	if err := validateSteps(gsp.ScaleOutSteps); err != nil {
+		return errors.Wrap(err, "failed to validate ScaleOutSteps")
+	}
+	if err := validateSteps(gsp.ScaleInSteps); err != nil {
+		return errors.Wrap(err, "failed to validate ScaleInSteps")
+	}
+	return nil
+}
+
+// validateSteps ensures each step has a positive count, and a unique non-negative lower bound.
+func validateSteps(steps []*StepAdjustment) error {
+	bounds := make([]float64, 0, len(steps))
+
+	for _, step := range steps {
+		if step.LowerBound < 0 {
+			return errors.New("LowerBound must not be negative")
+		}
+		if step.Count < 1 {
+			return errors.New("Count must be greater than zero")
+		}
+		bounds = append(bounds, step.LowerBound)
+	}
+
+	sort.Float64s(bounds)
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] == bounds[i-1] {
+			return errors.Errorf("LowerBound %v is used by more than one step", bounds[i])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupScalingPolicy_ActionCount(t *testing.T) {
+	pol := GroupScalingPolicy{
+		ScaleOutCount: 1,
+		ScaleInCount:  2,
+		ScaleOutSteps: []*StepAdjustment{
+			{LowerBound: 50, Count: 5},
+			{LowerBound: 10, Count: 2},
+		},
+	}
+
+	testCases := []struct {
+		inputAction    ComparisonAction
+		inputBreach    float64
+		expectedOutput int
+		name           string
+	}{
+		{inputAction: ActionScaleOut, inputBreach: 5, expectedOutput: 1, name: "below all steps"},
+		{inputAction: ActionScaleOut, inputBreach: 10, expectedOutput: 2, name: "equal to step lower bound"},
+		{inputAction: ActionScaleOut, inputBreach: 49.9, expectedOutput: 2, name: "between steps"},
+		{inputAction: ActionScaleOut, inputBreach: 200, expectedOutput: 5, name: "above all steps"},
+		{inputAction: ActionScaleIn, inputBreach: 200, expectedOutput: 2, name: "action without steps"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, pol.ActionCount(tc.inputAction, tc.inputBreach), tc.name)
+	}
+}
+
+func TestGroupScalingPolicy_AdjustmentCount(t *testing.T) {
+	testCases := []struct {
+		inputPolicy     GroupScalingPolicy
+		inputCurrent    int
+		inputPercentage int
+		expectedOutput  int
+		name            string
+	}{
+		{
+			inputPolicy:     GroupScalingPolicy{AdjustmentType: AdjustmentTypePercent},
+			inputCurrent:    10,
+			inputPercentage: 25,
+			expectedOutput:  3,
+			name:            "rounded up",
+		},
+		{
+			inputPolicy:     GroupScalingPolicy{AdjustmentType: AdjustmentTypePercent, MinAdjustmentStep: 2},
+			inputCurrent:    4,
+			inputPercentage: 10,
+			expectedOutput:  2,
+			name:            "minimum step",
+		},
+		{
+			inputPolicy:     GroupScalingPolicy{AdjustmentType: AdjustmentTypePercent},
+			inputCurrent:    20,
+			inputPercentage: 50,
+			expectedOutput:  10,
+			name:            "exact percentage",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.inputPolicy.AdjustmentCount(tc.inputCurrent, tc.inputPercentage), tc.name)
+	}
+}
+
+func TestGroupScalingPolicy_validateAdjustments(t *testing.T) {
+	testCases := []struct {
+		inputPolicy    GroupScalingPolicy
+		expectedOutput string
+		name           string
+	}{
+		{
+			inputPolicy: GroupScalingPolicy{
+				AdjustmentType:    AdjustmentTypePercent,
+				MinAdjustmentStep: 1,
+				ScaleOutSteps:     []*StepAdjustment{{LowerBound: 10, Count: 20}, {LowerBound: 50, Count: 50}},
+			},
+			expectedOutput: "",
+			name:           "valid",
+		},
+		{
+			inputPolicy:    GroupScalingPolicy{AdjustmentType: "fraction"},
+			expectedOutput: "AdjustmentType fraction is not a valid option",
+			name:           "invalid adjustment type",
+		},
+		{
+			inputPolicy:    GroupScalingPolicy{MinAdjustmentStep: 2},
+			expectedOutput: "MinAdjustmentStep can only be set when using the percent AdjustmentType",
+			name:           "minimum step without percent",
+		},
+		{
+			inputPolicy: GroupScalingPolicy{
+				ScalingMode:  ScalingModeTargetTracking,
+				ScaleInSteps: []*StepAdjustment{{LowerBound: 10, Count: 1}},
+			},
+			expectedOutput: "step adjustments cannot be used with target tracking",
+			name:           "steps with target tracking",
+		},
+		{
+			inputPolicy: GroupScalingPolicy{
+				ScaleOutSteps: []*StepAdjustment{{LowerBound: 10, Count: 1}, {LowerBound: 10, Count: 2}},
+			},
+			expectedOutput: "failed to validate ScaleOutSteps: LowerBound 10 is used by more than one step",
+			name:           "duplicate lower bound",
+		},
+		{
+			inputPolicy: GroupScalingPolicy{
+				ScaleInSteps: []*StepAdjustment{{LowerBound: 10, Count: 0}},
+			},
+			expectedOutput: "failed to validate ScaleInSteps: Count must be greater than zero",
+			name:           "zero step count",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputPolicy.validateAdjustments()
+		if tc.expectedOutput == "" {
+			assert.Nil(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedOutput, tc.name)
+		}
+	}
+}
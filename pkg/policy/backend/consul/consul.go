@@ -7,6 +7,7 @@ import (
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/pkg/errors"
@@ -66,9 +67,11 @@ func (p *PolicyBackend) GetPolicies() (map[string]map[string]*policy.GroupScalin
 			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
 		}
 
-		keySplit := strings.Split(kv[i].Key, "/")
-		jobName := keySplit[len(keySplit)-2]
-		groupName := keySplit[len(keySplit)-1]
+		jobName, groupName, ok := splitPolicyKey(strings.TrimPrefix(kv[i].Key, p.path))
+		if !ok {
+			p.logger.Warn().Str("key", kv[i].Key).Msg("skipping unexpected policy Consul KV key")
+			continue
+		}
 
 		if _, ok := out[jobName]; !ok {
 			out[jobName] = map[string]*policy.GroupScalingPolicy{}
@@ -83,12 +86,12 @@ func (p *PolicyBackend) GetPolicies() (map[string]map[string]*policy.GroupScalin
 func (p *PolicyBackend) GetJobPolicy(job string) (map[string]*policy.GroupScalingPolicy, error) {
 	defer metrics.MeasureSince(metricKeyGetJobPolicy, time.Now())
 
-	kv, _, err := p.kv.List(p.path+job, nil)
+	kv, err := p.listJobPolicyKeys(job)
 	if err != nil {
 		return nil, err
 	}
 
-	if kv == nil {
+	if len(kv) == 0 {
 		return nil, nil
 	}
 
@@ -182,8 +185,28 @@ func (p *PolicyBackend) PutJobGroupPolicy(job, group string, pol *policy.GroupSc
 func (p *PolicyBackend) DeleteJobPolicy(job string) error {
 	defer metrics.MeasureSince(metricKeyDeleteJobPolicy, time.Now())
 
-	_, err := p.kv.DeleteTree(p.path+job, nil)
-	return err
+	kv, err := p.listJobPolicyKeys(job)
+	if err != nil || len(kv) == 0 {
+		return err
+	}
+
+	// The policies of jobs within a namespace are stored beneath a prefix which can match the ID
+	// of a job in the default namespace, so only the job group keys of the job are deleted rather
+	// than the whole tree.
+	kvOpts := make([]*api.KVTxnOp, len(kv))
+	for i := range kv {
+		kvOpts[i] = &api.KVTxnOp{Verb: api.KVDelete, Key: kv[i].Key}
+	}
+
+	success, _, _, err := p.kv.Txn(kvOpts, nil)
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return errors.New("failed to delete job policy Consul transaction")
+	}
+	return nil
 }
 
 func (p *PolicyBackend) DeleteJobGroupPolicy(job, group string) error {
@@ -192,3 +215,42 @@ func (p *PolicyBackend) DeleteJobGroupPolicy(job, group string) error {
 	_, err := p.kv.Delete(p.path+job+"/"+group, nil)
 	return err
 }
+
+// listJobPolicyKeys lists the job group policy keys of the job. Keys nested further beneath the job
+// prefix belong to jobs within a namespace of the same name and are excluded.
+func (p *PolicyBackend) listJobPolicyKeys(job string) (api.KVPairs, error) {
+	prefix := p.path + job + "/"
+
+	kv, _, err := p.kv.List(prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out api.KVPairs // nolint:prealloc
+
+	for i := range kv {
+		if strings.Contains(strings.TrimPrefix(kv[i].Key, prefix), "/") {
+			continue
+		}
+		out = append(out, kv[i])
+	}
+	return out, nil
+}
+
+// splitPolicyKey splits a policy key, relative to the policy path, into the Sherpa job identifier
+// and group name. Policies of jobs within the default namespace are stored as job/group, and all
+// others as namespace/job/group. The group is always the final key segment, as the job ID of
+// namespaced jobs may itself contain forward slashes.
+func splitPolicyKey(key string) (string, string, bool) {
+	keySplit := strings.Split(key, "/")
+
+	switch len(keySplit) {
+	case 0, 1:
+		return "", "", false
+	case 2:
+		return keySplit[0], keySplit[1], true
+	default:
+		last := len(keySplit) - 1
+		return helper.NamespacedJobID(keySplit[0], strings.Join(keySplit[1:last], "/")), keySplit[last], true
+	}
+}
@@ -0,0 +1,30 @@
+package consul
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitPolicyKey(t *testing.T) {
+	testCases := []struct {
+		inputKey      string
+		expectedJob   string
+		expectedGroup string
+		expectedOK    bool
+	}{
+		{inputKey: "example/cache", expectedJob: "example", expectedGroup: "cache", expectedOK: true},
+		{inputKey: "platform/example/cache", expectedJob: "platform/example", expectedGroup: "cache", expectedOK: true},
+		{inputKey: "default/example/cache", expectedJob: "example", expectedGroup: "cache", expectedOK: true},
+		{inputKey: "example", expectedOK: false},
+		{inputKey: "platform/batch/periodic-123/cache", expectedJob: "platform/batch/periodic-123", expectedGroup: "cache", expectedOK: true},
+		{inputKey: "default/batch/periodic-123/cache", expectedJob: "default/batch/periodic-123", expectedGroup: "cache", expectedOK: true},
+	}
+
+	for _, tc := range testCases {
+		job, group, ok := splitPolicyKey(tc.inputKey)
+		assert.Equal(t, tc.expectedJob, job, tc.inputKey)
+		assert.Equal(t, tc.expectedGroup, group, tc.inputKey)
+		assert.Equal(t, tc.expectedOK, ok, tc.inputKey)
+	}
+}
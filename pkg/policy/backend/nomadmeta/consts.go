@@ -2,7 +2,10 @@ package nomadmeta
 
 const (
 	metaKeyEnabled                           = "sherpa_enabled"
+	metaKeyDryRun                            = "sherpa_dry_run"
 	metaKeyCooldown                          = "sherpa_cooldown"
+	metaKeyScaleOutCooldown                  = "sherpa_scale_out_cooldown"
+	metaKeyScaleInCooldown                   = "sherpa_scale_in_cooldown"
 	metaKeyMaxCount                          = "sherpa_max_count"
 	metaKeyMinCount                          = "sherpa_min_count"
 	metaKeyScaleInCount                      = "sherpa_scale_in_count"
@@ -12,4 +15,18 @@ const (
 	metaKeyScaleInCPUPercentageThreshold     = "sherpa_scale_in_cpu_percentage_threshold"
 	metaKeyScaleInMemoryPercentageThreshold  = "sherpa_scale_in_memory_percentage_threshold"
 	metaKeyExternalChecks                    = "sherpa_external_checks"
+	metaKeyScalingMode                       = "sherpa_scaling_mode"
+	metaKeyTargetCPUPercentage               = "sherpa_target_cpu_percentage"
+	metaKeyTargetMemoryPercentage            = "sherpa_target_memory_percentage"
+	metaKeySchedules                         = "sherpa_schedules"
+	metaKeyPredictive                        = "sherpa_predictive"
+	metaKeyScaleOutCondition                 = "sherpa_scale_out_condition"
+	metaKeyScaleInCondition                  = "sherpa_scale_in_condition"
+	metaKeyNomadBreachPeriods                = "sherpa_nomad_breach_periods"
+	metaKeyAdjustmentType                    = "sherpa_adjustment_type"
+	metaKeyMinAdjustmentStep                 = "sherpa_min_adjustment_step"
+	metaKeyScaleOutSteps                     = "sherpa_scale_out_steps"
+	metaKeyScaleInSteps                      = "sherpa_scale_in_steps"
+	metaKeyScaleInSafety                     = "sherpa_scale_in_safety"
+	metaKeyNotifiers                         = "sherpa_notifiers"
 )
@@ -1,17 +1,19 @@
 package nomadmeta
 
 import (
-	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
 	"github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/jrasell/sherpa/pkg/policy/backend/memory"
+	"github.com/jrasell/sherpa/pkg/stream"
 	"github.com/rs/zerolog"
 )
 
 // NewJobScalingPolicies produces a new policy backend and processor. The policy backend is just
-// the memory backend. The processor is used to handle job watcher updates, where the job is
-// inspected for its status, and then any Sherpa meta parameters pulled out and validated.
-func NewJobScalingPolicies(logger zerolog.Logger, nomad *api.Client) (backend.PolicyBackend, *Processor) {
-	b := memory.NewJobScalingPolicies()
+// the memory backend, wrapped so that policy changes are published to the hub. The processor is
+// used to handle job watcher updates, where the job is inspected for its status, and then any
+// Sherpa meta parameters pulled out and validated.
+func NewJobScalingPolicies(logger zerolog.Logger, nomad *client.NomadTargets, hub *stream.Hub) (backend.PolicyBackend, *Processor) {
+	b := stream.NewPolicyBackend(memory.NewJobScalingPolicies(), hub)
 	return b, &Processor{
 		logger:        logger,
 		nomad:         nomad,
@@ -3,16 +3,20 @@ package nomadmeta
 import (
 	"encoding/json"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/policy/backend"
+	"github.com/jrasell/sherpa/pkg/watcher/job"
 	"github.com/rs/zerolog"
 )
 
 type Processor struct {
 	logger        zerolog.Logger
-	nomad         *api.Client
+	nomad         *client.NomadTargets
 	backend       backend.PolicyBackend
 	jobUpdateChan chan interface{}
 }
@@ -32,18 +36,22 @@ func (pr *Processor) GetUpdateChannel() chan interface{} {
 }
 
 func (pr *Processor) handleJobListMessage(msg interface{}) {
-	job, ok := msg.(*api.JobListStub)
+	update, ok := msg.(*job.Update)
 	if !ok {
 		pr.logger.Error().Msg("received unexpected job update message type")
 		return
 	}
 	pr.logger.Debug().Msg("received job list update message to handle")
 
-	switch job.Status {
+	// Policies are stored using the Sherpa job identifier, which includes the namespace of jobs
+	// outside the default namespace.
+	jobID := helper.RegionalJobID(update.Region, helper.NamespacedJobID(update.Namespace, update.Job.ID))
+
+	switch update.Job.Status {
 	case "running":
-		go pr.handleRunningJob(job.ID)
+		go pr.handleRunningJob(jobID)
 	case "dead":
-		go pr.handleDeadJob(job.ID)
+		go pr.handleDeadJob(jobID)
 	case "pending":
 		// Pending is an in-between state, so just pass this through and do not do any work until
 		// the job has a more actionable state.
@@ -62,7 +70,13 @@ func (pr *Processor) handleDeadJob(jobID string) {
 func (pr *Processor) handleRunningJob(jobID string) {
 	pr.logger.Debug().Str("job", jobID).Msg("reading job group meta stanzas")
 
-	info, _, err := pr.nomad.Jobs().Info(jobID, nil)
+	nomad, ns, nomadJobID, err := pr.nomad.Job(jobID)
+	if err != nil {
+		pr.logger.Error().Err(err).Str("job", jobID).Msg("failed to find Nomad target of job")
+		return
+	}
+
+	info, _, err := nomad.Jobs().Info(nomadJobID, &api.QueryOptions{Namespace: ns})
 	if err != nil {
 		pr.logger.Error().Err(err).Msg("failed to call Nomad API for job information")
 		return
@@ -104,14 +118,31 @@ func (pr *Processor) policyFromMeta(meta map[string]string) *policy.GroupScaling
 		MaxCount:                          pr.maxCountValueOrDefault(meta),
 		MinCount:                          pr.minCountValueOrDefault(meta),
 		Enabled:                           pr.enabledValueOrDefault(meta),
+		DryRun:                            pr.dryRunValueOrDefault(meta),
 		Cooldown:                          pr.cooldownValueOrDefault(meta),
+		ScaleOutCooldown:                  pr.intValueOrZero(meta, metaKeyScaleOutCooldown),
+		ScaleInCooldown:                   pr.intValueOrZero(meta, metaKeyScaleInCooldown),
 		ScaleInCount:                      pr.scaleInValueOrDefault(meta),
 		ScaleOutCount:                     pr.scaleOutValueOrDefault(meta),
+		AdjustmentType:                    policy.AdjustmentType(meta[metaKeyAdjustmentType]),
+		MinAdjustmentStep:                 pr.intValueOrZero(meta, metaKeyMinAdjustmentStep),
+		ScaleOutSteps:                     pr.stepsFromMeta(meta, metaKeyScaleOutSteps),
+		ScaleInSteps:                      pr.stepsFromMeta(meta, metaKeyScaleInSteps),
 		ScaleOutCPUPercentageThreshold:    pr.scaleOutCPUThresholdValueOrNil(meta),
 		ScaleOutMemoryPercentageThreshold: pr.scaleOutMemoryThresholdValueOrNil(meta),
 		ScaleInCPUPercentageThreshold:     pr.scaleInCPUThresholdValueOrNil(meta),
 		ScaleInMemoryPercentageThreshold:  pr.scaleInMemoryThresholdValueOrNil(meta),
 		ExternalChecks:                    pr.externalChecksFromMeta(meta),
+		ScalingMode:                       policy.ScalingMode(meta[metaKeyScalingMode]),
+		TargetCPUPercentage:               pr.floatValueOrNil(meta, metaKeyTargetCPUPercentage),
+		TargetMemoryPercentage:            pr.floatValueOrNil(meta, metaKeyTargetMemoryPercentage),
+		Schedules:                         pr.schedulesFromMeta(meta),
+		Predictive:                        pr.predictiveFromMeta(meta),
+		ScaleOutCondition:                 conditionFromMeta(meta, metaKeyScaleOutCondition),
+		ScaleInCondition:                  conditionFromMeta(meta, metaKeyScaleInCondition),
+		ScaleInSafety:                     pr.scaleInSafetyFromMeta(meta),
+		NomadBreachPeriods:                pr.nomadBreachPeriodsFromMeta(meta),
+		Notifiers:                         notifiersFromMeta(meta),
 	}
 }
 
@@ -127,6 +158,18 @@ func (pr *Processor) enabledValueOrDefault(meta map[string]string) bool {
 	return false
 }
 
+func (pr *Processor) dryRunValueOrDefault(meta map[string]string) bool {
+	if val, ok := meta[metaKeyDryRun]; ok {
+		dryRun, err := strconv.ParseBool(val)
+		if err != nil {
+			pr.logger.Error().Err(err).Msg("failed to convert dry run meta value to bool")
+			return false
+		}
+		return dryRun
+	}
+	return false
+}
+
 func (pr *Processor) cooldownValueOrDefault(meta map[string]string) int {
 	if val, ok := meta[metaKeyCooldown]; ok {
 		cooldown, err := strconv.Atoi(val)
@@ -247,6 +290,120 @@ func (pr *Processor) externalChecksFromMeta(meta map[string]string) map[string]*
 	return nil
 }
 
+func (pr *Processor) schedulesFromMeta(meta map[string]string) map[string]*policy.Schedule {
+	if val, ok := meta[metaKeySchedules]; ok {
+		var schedules map[string]*policy.Schedule
+		if err := json.Unmarshal([]byte(val), &schedules); err != nil {
+			pr.logger.Error().Err(err).Msg("failed to unmarshal schedules into struct")
+			return nil
+		}
+		return schedules
+	}
+	return nil
+}
+
+func (pr *Processor) predictiveFromMeta(meta map[string]string) *policy.Predictive {
+	if val, ok := meta[metaKeyPredictive]; ok {
+		var predictive policy.Predictive
+		if err := json.Unmarshal([]byte(val), &predictive); err != nil {
+			pr.logger.Error().Err(err).Msg("failed to unmarshal predictive config into struct")
+			return nil
+		}
+		return &predictive
+	}
+	return nil
+}
+
+func (pr *Processor) stepsFromMeta(meta map[string]string, key string) []*policy.StepAdjustment {
+	if val, ok := meta[key]; ok {
+		var steps []*policy.StepAdjustment
+		if err := json.Unmarshal([]byte(val), &steps); err != nil {
+			pr.logger.Error().Err(err).Str("key", key).Msg("failed to unmarshal step adjustments into struct")
+			return nil
+		}
+		return steps
+	}
+	return nil
+}
+
+func (pr *Processor) nomadBreachPeriodsFromMeta(meta map[string]string) *policy.BreachPeriods {
+	if val, ok := meta[metaKeyNomadBreachPeriods]; ok {
+		var periods policy.BreachPeriods
+		if err := json.Unmarshal([]byte(val), &periods); err != nil {
+			pr.logger.Error().Err(err).Msg("failed to unmarshal Nomad breach periods into struct")
+			return nil
+		}
+		return &periods
+	}
+	return nil
+}
+
+func (pr *Processor) scaleInSafetyFromMeta(meta map[string]string) *policy.ScaleInSafety {
+	if val, ok := meta[metaKeyScaleInSafety]; ok {
+		var safety policy.ScaleInSafety
+		if err := json.Unmarshal([]byte(val), &safety); err != nil {
+			pr.logger.Error().Err(err).Msg("failed to unmarshal scale in safety into struct")
+			return nil
+		}
+		return &safety
+	}
+	return nil
+}
+
+// notifiersFromMeta parses the comma separated list of notifier names from the meta value.
+func notifiersFromMeta(meta map[string]string) []string {
+	val, ok := meta[metaKeyNotifiers]
+	if !ok {
+		return nil
+	}
+
+	var out []string
+	for _, name := range strings.Split(val, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// conditionFromMeta builds the check condition from the meta value. An integer value is used as
+// the condition quorum, otherwise the value is used as the condition expression.
+func conditionFromMeta(meta map[string]string, key string) *policy.CheckCondition {
+	val, ok := meta[key]
+	if !ok {
+		return nil
+	}
+
+	if quorum, err := strconv.Atoi(val); err == nil {
+		return &policy.CheckCondition{Quorum: quorum}
+	}
+	return policy.NewExpressionCondition(val)
+}
+
+func (pr *Processor) intValueOrZero(meta map[string]string, key string) int {
+	if val, ok := meta[key]; ok {
+		intVal, err := strconv.Atoi(val)
+		if err != nil {
+			pr.logger.Error().Err(err).Str("key", key).Msg("failed to convert meta value to int")
+			return 0
+		}
+		return intVal
+	}
+	return 0
+}
+
+func (pr *Processor) floatValueOrNil(meta map[string]string, key string) *float64 {
+	if val, ok := meta[key]; ok {
+		floatVal, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			pr.logger.Error().Err(err).Str("key", key).Msg("failed to convert meta value to float64")
+			return nil
+		}
+		return &floatVal
+	}
+	return nil
+}
+
 func (pr *Processor) hasMetaKeys(meta map[string]string) bool {
 	if _, ok := meta[metaKeyEnabled]; ok {
 		return true
@@ -11,7 +11,7 @@ import (
 )
 
 func TestProcessor_policyFromMeta(t *testing.T) {
-	_, p := NewJobScalingPolicies(zerolog.Logger{}, nil)
+	_, p := NewJobScalingPolicies(zerolog.Logger{}, nil, nil)
 
 	testCases := []struct {
 		meta           map[string]string
@@ -56,6 +56,164 @@ func TestProcessor_policyFromMeta(t *testing.T) {
 				ScaleInCount:  1,
 			},
 		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:             "true",
+				metaKeyScalingMode:         "target-tracking",
+				metaKeyTargetCPUPercentage: "60",
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:             true,
+				Cooldown:            180,
+				MinCount:            2,
+				MaxCount:            10,
+				ScaleOutCount:       1,
+				ScaleInCount:        1,
+				ScalingMode:         policy.ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(60),
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:   "true",
+				metaKeySchedules: `{"weekday-morning":{"Enabled":true,"Cron":"0 8 * * 1-5","Timezone":"Europe/London","Duration":3600,"MinCount":6}}`,
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:       true,
+				Cooldown:      180,
+				MinCount:      2,
+				MaxCount:      10,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				Schedules: map[string]*policy.Schedule{
+					"weekday-morning": {
+						Enabled:  true,
+						Cron:     "0 8 * * 1-5",
+						Timezone: "Europe/London",
+						Duration: 3600,
+						MinCount: helper.IntToPointer(6),
+					},
+				},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:    "true",
+				metaKeyDryRun:     "true",
+				metaKeyPredictive: `{"Enabled":true,"LookAhead":1800}`,
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:       true,
+				DryRun:        true,
+				Cooldown:      180,
+				MinCount:      2,
+				MaxCount:      10,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				Predictive:    &policy.Predictive{Enabled: true, LookAhead: 1800},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:           "true",
+				metaKeyScaleOutCondition: "nomad-cpu AND queue",
+				metaKeyScaleInCondition:  "2",
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:           true,
+				Cooldown:          180,
+				MinCount:          2,
+				MaxCount:          10,
+				ScaleOutCount:     1,
+				ScaleInCount:      1,
+				ScaleOutCondition: policy.NewExpressionCondition("nomad-cpu AND queue"),
+				ScaleInCondition:  &policy.CheckCondition{Quorum: 2},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:            "true",
+				metaKeyNomadBreachPeriods: `{"Datapoints":3,"EvaluationPeriods":5}`,
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:            true,
+				Cooldown:           180,
+				MinCount:           2,
+				MaxCount:           10,
+				ScaleOutCount:      1,
+				ScaleInCount:       1,
+				NomadBreachPeriods: &policy.BreachPeriods{Datapoints: 3, EvaluationPeriods: 5},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:       "true",
+				metaKeyScaleInSafety: `{"MaxPercentage":20,"Window":1800,"MinHealthyAllocs":2,"BlockWhileUnhealthy":true}`,
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:       true,
+				Cooldown:      180,
+				MinCount:      2,
+				MaxCount:      10,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				ScaleInSafety: &policy.ScaleInSafety{MaxPercentage: 20, Window: 1800, MinHealthyAllocs: 2, BlockWhileUnhealthy: true},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:   "true",
+				metaKeyNotifiers: "ops-slack, pagerduty",
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:       true,
+				Cooldown:      180,
+				MinCount:      2,
+				MaxCount:      10,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				Notifiers:     []string{"ops-slack", "pagerduty"},
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:          "true",
+				metaKeyScaleOutCooldown: "60",
+				metaKeyScaleInCooldown:  "600",
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:          true,
+				Cooldown:         180,
+				ScaleOutCooldown: 60,
+				ScaleInCooldown:  600,
+				MinCount:         2,
+				MaxCount:         10,
+				ScaleOutCount:    1,
+				ScaleInCount:     1,
+			},
+		},
+		{
+			meta: map[string]string{
+				metaKeyEnabled:           "true",
+				metaKeyAdjustmentType:    "percent",
+				metaKeyMinAdjustmentStep: "2",
+				metaKeyScaleOutSteps:     `[{"LowerBound":10,"Count":20},{"LowerBound":50,"Count":50}]`,
+			},
+			expectedPolicy: &policy.GroupScalingPolicy{
+				Enabled:           true,
+				Cooldown:          180,
+				MinCount:          2,
+				MaxCount:          10,
+				ScaleOutCount:     1,
+				ScaleInCount:      1,
+				AdjustmentType:    policy.AdjustmentTypePercent,
+				MinAdjustmentStep: 2,
+				ScaleOutSteps: []*policy.StepAdjustment{
+					{LowerBound: 10, Count: 20},
+					{LowerBound: 50, Count: 50},
+				},
+			},
+		},
 		{
 			meta: map[string]string{
 				metaKeyEnabled: "false",
@@ -0,0 +1,62 @@
+package policy
+
+import "github.com/pkg/errors"
+
+// MaxBreachEvaluationPeriods is the maximum number of evaluations which can be considered when
+// determining whether a check is breaching. It bounds the size of the breach history stored for
+// each check.
+const MaxBreachEvaluationPeriods = 100
+
+// BreachPeriods configures a threshold check to only request scaling once it has broken its
+// threshold within a number of the most recent evaluations, rather than on a single evaluation.
+// This prevents transient spikes from triggering scaling actions.
+type BreachPeriods struct {
+
+	// Datapoints is the number of evaluations, within the EvaluationPeriods, in which the check
+	// must break its threshold before scaling is requested.
+	Datapoints int `json:"Datapoints"`
+
+	// EvaluationPeriods is the number of most recent evaluations considered.
+	EvaluationPeriods int `json:"EvaluationPeriods"`
+}
+
+// Validate checks the BreachPeriods are valid and consistent.
+func (bp BreachPeriods) Validate() error {
+	if bp.Datapoints < 1 {
+		return errors.New("Datapoints must be greater than zero")
+	}
+	if bp.EvaluationPeriods < bp.Datapoints {
+		return errors.New("EvaluationPeriods must not be less than Datapoints")
+	}
+	if bp.EvaluationPeriods > MaxBreachEvaluationPeriods {
+		return errors.Errorf("EvaluationPeriods must not be greater than %v", MaxBreachEvaluationPeriods)
+	}
+	return nil
+}
+
+// validateBreachPeriods ensures the breach periods configured on the group policy and its checks
+// are valid. Breach periods only apply to threshold checks, so cannot be used alongside target
+// tracking.
+func (gsp GroupScalingPolicy) validateBreachPeriods() error {
+	if gsp.NomadBreachPeriods != nil {
+		if gsp.TargetTrackingEnabled() {
+			return errors.New("NomadBreachPeriods cannot be used with target tracking")
+		}
+		if err := gsp.NomadBreachPeriods.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate NomadBreachPeriods")
+		}
+	}
+
+	for name, check := range gsp.ExternalChecks {
+		if check.BreachPeriods == nil {
+			continue
+		}
+		if gsp.TargetTrackingEnabled() {
+			return errors.Errorf("check %s BreachPeriods cannot be used with target tracking", name)
+		}
+		if err := check.BreachPeriods.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate check "+name+" BreachPeriods")
+		}
+	}
+	return nil
+}
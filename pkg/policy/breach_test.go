@@ -0,0 +1,78 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreachPeriods_Validate(t *testing.T) {
+	testCases := []struct {
+		periods        BreachPeriods
+		expectedOutput error
+		name           string
+	}{
+		{
+			periods:        BreachPeriods{Datapoints: 3, EvaluationPeriods: 5},
+			expectedOutput: nil,
+			name:           "valid",
+		},
+		{
+			periods:        BreachPeriods{Datapoints: 2, EvaluationPeriods: 2},
+			expectedOutput: nil,
+			name:           "valid equal",
+		},
+		{
+			periods:        BreachPeriods{Datapoints: 0, EvaluationPeriods: 5},
+			expectedOutput: errors.New("Datapoints must be greater than zero"),
+			name:           "zero datapoints",
+		},
+		{
+			periods:        BreachPeriods{Datapoints: 3, EvaluationPeriods: 2},
+			expectedOutput: errors.New("EvaluationPeriods must not be less than Datapoints"),
+			name:           "periods less than datapoints",
+		},
+		{
+			periods:        BreachPeriods{Datapoints: 3, EvaluationPeriods: 101},
+			expectedOutput: errors.New("EvaluationPeriods must not be greater than 100"),
+			name:           "periods too large",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.periods.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput, tc.name)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error(), tc.name)
+		}
+	}
+}
+
+func TestGroupScalingPolicy_validateBreachPeriods(t *testing.T) {
+	pol := GroupScalingPolicy{
+		ScalingMode:         ScalingModeTargetTracking,
+		TargetCPUPercentage: helper.Float64ToPointer(50),
+		NomadBreachPeriods:  &BreachPeriods{Datapoints: 2, EvaluationPeriods: 3},
+	}
+	assert.EqualError(t, pol.validateBreachPeriods(), "NomadBreachPeriods cannot be used with target tracking")
+
+	pol = GroupScalingPolicy{
+		ExternalChecks: map[string]*ExternalCheck{
+			"queue": {BreachPeriods: &BreachPeriods{Datapoints: 4, EvaluationPeriods: 3}},
+		},
+	}
+	assert.EqualError(t, pol.validateBreachPeriods(),
+		"failed to validate check queue BreachPeriods: EvaluationPeriods must not be less than Datapoints")
+
+	pol = GroupScalingPolicy{
+		ScaleOutCPUPercentageThreshold: helper.Float64ToPointer(80),
+		NomadBreachPeriods:             &BreachPeriods{Datapoints: 2, EvaluationPeriods: 3},
+		ExternalChecks: map[string]*ExternalCheck{
+			"queue": {BreachPeriods: &BreachPeriods{Datapoints: 1, EvaluationPeriods: 3}},
+		},
+	}
+	assert.Nil(t, pol.validateBreachPeriods())
+}
@@ -0,0 +1,296 @@
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The check names used to reference the Nomad resource threshold checks within check conditions.
+const (
+	NomadCPUCheckName    = "nomad-cpu"
+	NomadMemoryCheckName = "nomad-memory"
+)
+
+// CheckCondition dictates which of the threshold checks requesting a scaling action must agree
+// before the action is taken. Without a condition, a single check is enough to trigger scaling.
+// Exactly one of Quorum or Expression must be set.
+type CheckCondition struct {
+
+	// Quorum is the number of checks which must request the scaling action.
+	Quorum int `json:"Quorum,omitempty"`
+
+	// Expression is a boolean expression of check names, combined using AND, OR and parentheses,
+	// which must be true for the scaling action to be taken. A check name is true when the check
+	// requested the scaling action. AND takes precedence over OR.
+	Expression string `json:"Expression,omitempty"`
+
+	// expr is the parsed Expression, cached when the condition is loaded or validated so that it
+	// is not parsed on each evaluation.
+	expr conditionExpr
+}
+
+// NewExpressionCondition returns a check condition using the expression, which is parsed ready
+// for evaluation. An invalid expression is reported by Validate.
+func NewExpressionCondition(expression string) *CheckCondition {
+	cc := CheckCondition{Expression: expression}
+	_ = cc.parse()
+	return &cc
+}
+
+// UnmarshalJSON decodes the check condition, parsing the expression ready for evaluation. An
+// invalid expression is reported by Validate rather than failing the decode.
+func (cc *CheckCondition) UnmarshalJSON(data []byte) error {
+	type alias CheckCondition
+
+	var out alias
+	if err := json.Unmarshal(data, &out); err != nil {
+		return err
+	}
+
+	*cc = CheckCondition(out)
+	_ = cc.parse()
+	return nil
+}
+
+// parse parses the expression and caches the result on the condition.
+func (cc *CheckCondition) parse() error {
+	cc.expr = nil
+	if cc.Expression == "" {
+		return nil
+	}
+
+	expr, err := parseConditionExpression(cc.Expression)
+	if err != nil {
+		return err
+	}
+	cc.expr = expr
+	return nil
+}
+
+// Validate checks the CheckCondition is valid, using the names of the checks which are able to
+// request the scaling action. A valid expression is cached on the condition.
+func (cc *CheckCondition) Validate(checks map[string]struct{}) error {
+	if (cc.Quorum == 0) == (cc.Expression == "") {
+		return errors.New("exactly one of Quorum or Expression must be set")
+	}
+
+	if cc.Expression == "" {
+		if cc.Quorum < 1 || cc.Quorum > len(checks) {
+			return errors.Errorf("Quorum must be between 1 and the number of checks (%v)", len(checks))
+		}
+		return nil
+	}
+
+	if err := cc.parse(); err != nil {
+		return errors.Wrap(err, "failed to parse Expression")
+	}
+
+	for _, name := range cc.expr.names() {
+		if _, ok := checks[name]; !ok {
+			return errors.Errorf("Expression check %s not found within checks for the action", name)
+		}
+	}
+	return nil
+}
+
+// Met identifies whether the condition is satisfied by the named checks which requested the
+// scaling action. The cached expression is evaluated, and so an expression which was not valid
+// when the condition was loaded is never met. Conditions may be shared between evaluations, so Met
+// never modifies the condition.
+func (cc *CheckCondition) Met(breached map[string]struct{}) bool {
+	if cc.Expression == "" {
+		return len(breached) >= cc.Quorum
+	}
+
+	if cc.expr == nil {
+		return false
+	}
+	return cc.expr.eval(breached)
+}
+
+// ConditionChecks returns the names of the threshold checks configured within the policy which
+// are able to request the scaling action. These are the names which can be used within the
+// CheckCondition for the action.
+func (gsp GroupScalingPolicy) ConditionChecks(action ComparisonAction) map[string]struct{} {
+	out := make(map[string]struct{})
+
+	cpu, mem := gsp.ScaleOutCPUPercentageThreshold, gsp.ScaleOutMemoryPercentageThreshold
+	if action == ActionScaleIn {
+		cpu, mem = gsp.ScaleInCPUPercentageThreshold, gsp.ScaleInMemoryPercentageThreshold
+	}
+
+	if cpu != nil {
+		out[NomadCPUCheckName] = struct{}{}
+	}
+	if mem != nil {
+		out[NomadMemoryCheckName] = struct{}{}
+	}
+
+	for name, check := range gsp.ExternalChecks {
+		if check.Action == action {
+			out[name] = struct{}{}
+		}
+	}
+	return out
+}
+
+// CheckConditionsEnabled identifies whether the group policy has a check condition configured for
+// either scaling action.
+func (gsp GroupScalingPolicy) CheckConditionsEnabled() bool {
+	return gsp.ScaleOutCondition != nil || gsp.ScaleInCondition != nil
+}
+
+// validateConditions ensures the configured check conditions are valid for the checks able to
+// request each scaling action.
+func (gsp GroupScalingPolicy) validateConditions() error {
+	if gsp.TargetTrackingEnabled() {
+		return errors.New("check conditions cannot be used with target tracking")
+	}
+
+	if gsp.ScaleOutCondition != nil {
+		if err := gsp.ScaleOutCondition.Validate(gsp.ConditionChecks(ActionScaleOut)); err != nil {
+			return errors.Wrap(err, "failed to validate ScaleOutCondition")
+		}
+	}
+	if gsp.ScaleInCondition != nil {
+		if err := gsp.ScaleInCondition.Validate(gsp.ConditionChecks(ActionScaleIn)); err != nil {
+			return errors.Wrap(err, "failed to validate ScaleInCondition")
+		}
+	}
+	return nil
+}
+
+// conditionExpr is a parsed node of a check condition expression.
+type conditionExpr interface {
+	eval(breached map[string]struct{}) bool
+	names() []string
+}
+
+type (
+	conditionName string
+	conditionAnd  []conditionExpr
+	conditionOr   []conditionExpr
+)
+
+func (n conditionName) eval(breached map[string]struct{}) bool {
+	_, ok := breached[string(n)]
+	return ok
+}
+
+func (n conditionName) names() []string { return []string{string(n)} }
+
+func (a conditionAnd) eval(breached map[string]struct{}) bool {
+	for _, expr := range a {
+		if !expr.eval(breached) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a conditionAnd) names() []string { return conditionNames(a) }
+
+func (o conditionOr) eval(breached map[string]struct{}) bool {
+	for _, expr := range o {
+		if expr.eval(breached) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o conditionOr) names() []string { return conditionNames(o) }
+
+func conditionNames(exprs []conditionExpr) []string {
+	var out []string
+	for _, expr := range exprs {
+		out = append(out, expr.names()...)
+	}
+	return out
+}
+
+// conditionParser is a recursive descent parser of check condition expressions.
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseConditionExpression parses the expression into its evaluable form.
+func parseConditionExpression(s string) (conditionExpr, error) {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(s)
+
+	p := conditionParser{tokens: strings.Fields(s)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, errors.Errorf("unexpected token %q", p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+func (p *conditionParser) parseOr() (conditionExpr, error) {
+	return p.parseList("OR", p.parseAnd, func(exprs []conditionExpr) conditionExpr { return conditionOr(exprs) })
+}
+
+func (p *conditionParser) parseAnd() (conditionExpr, error) {
+	return p.parseList("AND", p.parseTerm, func(exprs []conditionExpr) conditionExpr { return conditionAnd(exprs) })
+}
+
+// parseList parses one or more sub expressions separated by the operator.
+func (p *conditionParser) parseList(op string, next func() (conditionExpr, error),
+	build func([]conditionExpr) conditionExpr) (conditionExpr, error) {
+
+	var exprs []conditionExpr
+
+	for {
+		expr, err := next()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+
+		if p.pos >= len(p.tokens) || !strings.EqualFold(p.tokens[p.pos], op) {
+			break
+		}
+		p.pos++
+	}
+
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return build(exprs), nil
+}
+
+func (p *conditionParser) parseTerm() (conditionExpr, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch {
+	case tok == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case tok == ")", strings.EqualFold(tok, "AND"), strings.EqualFold(tok, "OR"):
+		return nil, errors.Errorf("unexpected token %q", tok)
+	default:
+		return conditionName(tok), nil
+	}
+}
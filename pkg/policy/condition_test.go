@@ -0,0 +1,160 @@
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCondition_Validate(t *testing.T) {
+	checks := map[string]*ExternalCheck{
+		"queue":   {Enabled: true, Provider: ProviderPrometheus, Action: ActionScaleOut},
+		"latency": {Enabled: true, Provider: ProviderPrometheus, Action: ActionScaleOut},
+		"idle":    {Enabled: true, Provider: ProviderPrometheus, Action: ActionScaleIn},
+	}
+	pol := GroupScalingPolicy{ScaleOutCPUPercentageThreshold: helper.Float64ToPointer(80), ExternalChecks: checks}
+	names := pol.ConditionChecks(ActionScaleOut)
+
+	testCases := []struct {
+		condition      CheckCondition
+		expectedOutput string
+		name           string
+	}{
+		{
+			condition: CheckCondition{Quorum: 2},
+			name:      "valid quorum",
+		},
+		{
+			condition: CheckCondition{Expression: "nomad-cpu AND (queue OR latency)"},
+			name:      "valid expression",
+		},
+		{
+			condition:      CheckCondition{Quorum: 4},
+			expectedOutput: "Quorum must be between 1 and the number of checks (3)",
+			name:           "quorum larger than checks",
+		},
+		{
+			condition:      CheckCondition{},
+			expectedOutput: "exactly one of Quorum or Expression must be set",
+			name:           "neither set",
+		},
+		{
+			condition:      CheckCondition{Quorum: 1, Expression: "queue"},
+			expectedOutput: "exactly one of Quorum or Expression must be set",
+			name:           "both set",
+		},
+		{
+			condition:      CheckCondition{Expression: "nomad-cpu AND idle"},
+			expectedOutput: "Expression check idle not found within checks for the action",
+			name:           "check for other action",
+		},
+		{
+			condition:      CheckCondition{Expression: "(nomad-cpu AND queue"},
+			expectedOutput: "failed to parse Expression: missing closing parenthesis",
+			name:           "unclosed parenthesis",
+		},
+		{
+			condition:      CheckCondition{Expression: "nomad-cpu queue"},
+			expectedOutput: `failed to parse Expression: unexpected token "queue"`,
+			name:           "missing operator",
+		},
+		{
+			condition:      CheckCondition{Expression: "nomad-cpu AND OR queue"},
+			expectedOutput: `failed to parse Expression: unexpected token "OR"`,
+			name:           "double operator",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.condition.Validate(names)
+		if tc.expectedOutput == "" {
+			assert.Nil(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedOutput, tc.name)
+		}
+	}
+}
+
+func TestCheckCondition_Met(t *testing.T) {
+	testCases := []struct {
+		condition      *CheckCondition
+		breached       []string
+		expectedOutput bool
+		name           string
+	}{
+		{
+			condition:      &CheckCondition{Quorum: 2},
+			breached:       []string{"nomad-cpu", "queue"},
+			expectedOutput: true,
+			name:           "quorum met",
+		},
+		{
+			condition:      &CheckCondition{Quorum: 2},
+			breached:       []string{"queue"},
+			expectedOutput: false,
+			name:           "quorum not met",
+		},
+		{
+			condition:      NewExpressionCondition("nomad-cpu AND queue"),
+			breached:       []string{"nomad-cpu"},
+			expectedOutput: false,
+			name:           "and not met",
+		},
+		{
+			condition:      NewExpressionCondition("nomad-cpu and queue"),
+			breached:       []string{"nomad-cpu", "queue"},
+			expectedOutput: true,
+			name:           "lowercase and met",
+		},
+		{
+			condition:      NewExpressionCondition("nomad-cpu AND queue OR latency"),
+			breached:       []string{"latency"},
+			expectedOutput: true,
+			name:           "and takes precedence over or",
+		},
+		{
+			condition:      NewExpressionCondition("nomad-cpu AND (queue OR latency)"),
+			breached:       []string{"latency"},
+			expectedOutput: false,
+			name:           "parentheses take precedence",
+		},
+		{
+			condition:      NewExpressionCondition("nomad-cpu AND"),
+			breached:       []string{"nomad-cpu"},
+			expectedOutput: false,
+			name:           "invalid expression",
+		},
+	}
+
+	for _, tc := range testCases {
+		breached := make(map[string]struct{})
+		for _, name := range tc.breached {
+			breached[name] = struct{}{}
+		}
+		assert.Equal(t, tc.expectedOutput, tc.condition.Met(breached), tc.name)
+	}
+
+	// Expressions decoded from JSON are parsed ready for evaluation.
+	var decoded CheckCondition
+	assert.Nil(t, json.Unmarshal([]byte(`{"Expression":"nomad-cpu AND queue"}`), &decoded))
+	assert.Equal(t, NewExpressionCondition("nomad-cpu AND queue"), &decoded)
+	assert.True(t, decoded.Met(map[string]struct{}{"nomad-cpu": {}, "queue": {}}))
+}
+
+func TestGroupScalingPolicy_validateConditions(t *testing.T) {
+	pol := GroupScalingPolicy{
+		ScalingMode:         ScalingModeTargetTracking,
+		TargetCPUPercentage: helper.Float64ToPointer(50),
+		ScaleOutCondition:   &CheckCondition{Quorum: 1},
+	}
+	assert.EqualError(t, pol.validateConditions(), "check conditions cannot be used with target tracking")
+
+	pol = GroupScalingPolicy{
+		ScaleInCPUPercentageThreshold:    helper.Float64ToPointer(20),
+		ScaleInMemoryPercentageThreshold: helper.Float64ToPointer(20),
+		ScaleInCondition:                 &CheckCondition{Expression: "nomad-cpu AND nomad-memory"},
+	}
+	assert.Nil(t, pol.validateConditions())
+}
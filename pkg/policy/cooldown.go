@@ -0,0 +1,44 @@
+package policy
+
+import "github.com/pkg/errors"
+
+// ActionCooldown returns the cooldown period in seconds which must have passed since the last
+// scaling event before the job group can be scaled in the direction of the action. If a cooldown
+// has not been configured for the action, the policy Cooldown is used.
+func (gsp GroupScalingPolicy) ActionCooldown(action ComparisonAction) int {
+	switch action {
+	case ActionScaleOut:
+		if gsp.ScaleOutCooldown > 0 {
+			return gsp.ScaleOutCooldown
+		}
+	case ActionScaleIn:
+		if gsp.ScaleInCooldown > 0 {
+			return gsp.ScaleInCooldown
+		}
+	}
+	return gsp.Cooldown
+}
+
+// MinCooldown returns the shortest cooldown period in seconds of the scaling actions. Once this
+// has passed, the job group can be scaled in at least one direction.
+func (gsp GroupScalingPolicy) MinCooldown() int {
+	out, in := gsp.ActionCooldown(ActionScaleOut), gsp.ActionCooldown(ActionScaleIn)
+	if in < out {
+		return in
+	}
+	return out
+}
+
+// ActionCooldownsEnabled identifies whether the group policy is configured with a separate
+// cooldown for either scaling action.
+func (gsp GroupScalingPolicy) ActionCooldownsEnabled() bool {
+	return gsp.ScaleOutCooldown > 0 || gsp.ScaleInCooldown > 0
+}
+
+// validateCooldowns ensures the cooldown periods of the group policy are not negative.
+func (gsp GroupScalingPolicy) validateCooldowns() error {
+	if gsp.Cooldown < 0 || gsp.ScaleOutCooldown < 0 || gsp.ScaleInCooldown < 0 {
+		return errors.New("Cooldown, ScaleOutCooldown and ScaleInCooldown must not be negative")
+	}
+	return nil
+}
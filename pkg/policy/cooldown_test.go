@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupScalingPolicy_ActionCooldown(t *testing.T) {
+	testCases := []struct {
+		inputPolicy         GroupScalingPolicy
+		expectedOutCooldown int
+		expectedInCooldown  int
+		expectedMinCooldown int
+		name                string
+	}{
+		{
+			inputPolicy:         GroupScalingPolicy{Cooldown: 180},
+			expectedOutCooldown: 180,
+			expectedInCooldown:  180,
+			expectedMinCooldown: 180,
+			name:                "policy cooldown only",
+		},
+		{
+			inputPolicy:         GroupScalingPolicy{Cooldown: 180, ScaleOutCooldown: 60, ScaleInCooldown: 600},
+			expectedOutCooldown: 60,
+			expectedInCooldown:  600,
+			expectedMinCooldown: 60,
+			name:                "separate action cooldowns",
+		},
+		{
+			inputPolicy:         GroupScalingPolicy{Cooldown: 180, ScaleInCooldown: 120},
+			expectedOutCooldown: 180,
+			expectedInCooldown:  120,
+			expectedMinCooldown: 120,
+			name:                "scale in cooldown only",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutCooldown, tc.inputPolicy.ActionCooldown(ActionScaleOut), tc.name)
+		assert.Equal(t, tc.expectedInCooldown, tc.inputPolicy.ActionCooldown(ActionScaleIn), tc.name)
+		assert.Equal(t, tc.expectedMinCooldown, tc.inputPolicy.MinCooldown(), tc.name)
+	}
+
+	pol := GroupScalingPolicy{ScaleInCooldown: -1}
+	assert.EqualError(t, pol.validateCooldowns(), "Cooldown, ScaleOutCooldown and ScaleInCooldown must not be negative")
+}
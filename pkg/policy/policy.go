@@ -1,6 +1,9 @@
 package policy
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/pkg/errors"
 )
 
@@ -12,11 +15,23 @@ type GroupScalingPolicy struct {
 	// Enabled is a boolean which tells whether the policy is disabled or not.
 	Enabled bool `json:"Enabled"`
 
+	// DryRun is a boolean which tells the internal autoscaler to record the scaling decisions it
+	// makes for the group as dry-run events, rather than acting upon them.
+	DryRun bool `json:"DryRun,omitempty"`
+
 	// Cooldown is a time period in seconds. Once a scaling action has been triggered on the
 	// desired group, another action will not be triggered until the cooldown period has
 	// passed.
 	Cooldown int `json:"Cooldown"`
 
+	// ScaleOutCooldown is a time period in seconds which must have passed since the last scaling
+	// action before the group is scaled out. If this is not set, the Cooldown is used.
+	ScaleOutCooldown int `json:"ScaleOutCooldown,omitempty"`
+
+	// ScaleInCooldown is a time period in seconds which must have passed since the last scaling
+	// action before the group is scaled in. If this is not set, the Cooldown is used.
+	ScaleInCooldown int `json:"ScaleInCooldown,omitempty"`
+
 	// MinCount is the minimum count a task group should reach.
 	MinCount int `json:"MinCount"`
 
@@ -29,6 +44,22 @@ type GroupScalingPolicy struct {
 	// ScaleInCount is the number which a task group is decremented by during scaling.
 	ScaleInCount int `json:"ScaleInCount"`
 
+	// AdjustmentType dictates how the scaling counts change the task group count. An empty value
+	// is treated as AdjustmentTypeCount.
+	AdjustmentType AdjustmentType `json:"AdjustmentType,omitempty"`
+
+	// MinAdjustmentStep is the minimum number a task group is changed by when using the percent
+	// AdjustmentType.
+	MinAdjustmentStep int `json:"MinAdjustmentStep,omitempty"`
+
+	// ScaleOutSteps are scaling counts used in place of the ScaleOutCount, depending on how far
+	// the scale out threshold checks have been broken.
+	ScaleOutSteps []*StepAdjustment `json:"ScaleOutSteps,omitempty"`
+
+	// ScaleInSteps are scaling counts used in place of the ScaleInCount, depending on how far the
+	// scale in threshold checks have been broken.
+	ScaleInSteps []*StepAdjustment `json:"ScaleInSteps,omitempty"`
+
 	// ScaleOutCPUPercentageThreshold is used to perform an upper bound check on the CPU resource
 	// consumption of a job group based on Nomad obtained metrics. This value can be nil indicating
 	// this check should not be performed.
@@ -53,6 +84,51 @@ type GroupScalingPolicy struct {
 	// during scaling evaluations. They are keyed by a user specified name which is a free form
 	// string and does not have any requirements which impact the running on the check itself.
 	ExternalChecks map[string]*ExternalCheck `json:"ExternalChecks,omitempty"`
+
+	// ScalingMode dictates how the autoscaler calculates the new count of the job group. An empty
+	// value is treated as ScalingModeStep.
+	ScalingMode ScalingMode `json:"ScalingMode,omitempty"`
+
+	// TargetCPUPercentage is the CPU utilisation percentage which target tracking policies aim to
+	// keep the job group at, based on Nomad obtained metrics. This value can be nil indicating
+	// this target should not be tracked.
+	TargetCPUPercentage *float64 `json:"TargetCPUPercentage,omitempty"`
+
+	// TargetMemoryPercentage is the memory utilisation percentage which target tracking policies
+	// aim to keep the job group at, based on Nomad obtained metrics. This value can be nil
+	// indicating this target should not be tracked.
+	TargetMemoryPercentage *float64 `json:"TargetMemoryPercentage,omitempty"`
+
+	// Schedules are time based capacity windows which override the policy counts while open. They
+	// are keyed by a user specified name which is a free form string used to identify the
+	// schedule within scaling events.
+	Schedules map[string]*Schedule `json:"Schedules,omitempty"`
+
+	// Predictive configures predictive scaling based on a weekly seasonal baseline of the job
+	// group. This value can be nil indicating predictive scaling is not configured.
+	Predictive *Predictive `json:"Predictive,omitempty"`
+
+	// ScaleOutCondition dictates which of the threshold checks requesting scale out must agree
+	// before the group is scaled out. This value can be nil indicating any single check is
+	// enough.
+	ScaleOutCondition *CheckCondition `json:"ScaleOutCondition,omitempty"`
+
+	// ScaleInCondition dictates which of the threshold checks requesting scale in must agree
+	// before the group is scaled in. This value can be nil indicating any single check is enough.
+	ScaleInCondition *CheckCondition `json:"ScaleInCondition,omitempty"`
+
+	// ScaleInSafety configures protections which limit the rate and circumstances in which the
+	// group can be scaled in. This value can be nil indicating scale in is not restricted.
+	ScaleInSafety *ScaleInSafety `json:"ScaleInSafety,omitempty"`
+
+	// NomadBreachPeriods configures the Nomad resource threshold checks to only request scaling
+	// once they have broken their threshold within a number of the most recent evaluations. This
+	// value can be nil indicating a single evaluation is enough.
+	NomadBreachPeriods *BreachPeriods `json:"NomadBreachPeriods,omitempty"`
+
+	// Notifiers are the names of the server configured notifiers which scaling events of the
+	// group are sent to. If empty, the server default notifiers are used.
+	Notifiers []string `json:"Notifiers,omitempty"`
 }
 
 // ExternalCheck is an individual check of a metric from an external source. The check contains all
@@ -81,6 +157,77 @@ type ExternalCheck struct {
 	// Action is the scaling action that should be taken if the queried metric fails the comparison
 	// check.
 	Action ComparisonAction `json:"Action"`
+
+	// TargetValue is the metric value which a target tracking policy aims to keep the job group
+	// at. It must be set on checks within target tracking policies, in which case the
+	// ComparisonOperator, ComparisonValue and Action are not used.
+	TargetValue *float64 `json:"TargetValue,omitempty"`
+
+	// QueryType dictates whether the query is run as an instant or range query against the
+	// provider. An empty value is treated as an instant query.
+	QueryType QueryType `json:"QueryType,omitempty"`
+
+	// QueryWindow is the lookback period in seconds used when running a range query.
+	QueryWindow int `json:"QueryWindow,omitempty"`
+
+	// QueryStep is the resolution step in seconds used when running a range query. If this is not
+	// set, DefaultQueryStep is used.
+	QueryStep int `json:"QueryStep,omitempty"`
+
+	// Reducer is the function used to collapse multiple values returned by the query into the
+	// single value used for comparison. An empty value means the query must return exactly one
+	// value, unless the query is a range query in which case DefaultReducer is used.
+	Reducer Reducer `json:"Reducer,omitempty"`
+
+	// BreachPeriods configures the check to only request scaling once it has broken its threshold
+	// within a number of the most recent evaluations. This value can be nil indicating a single
+	// evaluation is enough.
+	BreachPeriods *BreachPeriods `json:"BreachPeriods,omitempty"`
+}
+
+// HasQueryOptions identifies whether the external check has been configured with query options
+// which require the provider to support more than a single instant value lookup.
+func (ec ExternalCheck) HasQueryOptions() bool {
+	return ec.QueryType == QueryTypeRange || ec.Reducer != ""
+}
+
+// Validate checks the query options of the ExternalCheck are valid and consistent.
+func (ec ExternalCheck) Validate() error {
+	if err := ec.Provider.Validate(); err != nil {
+		return err
+	}
+
+	// Target tracking checks do not perform a threshold comparison, so the operator and action
+	// are not required.
+	if ec.TargetValue != nil {
+		if *ec.TargetValue <= 0 {
+			return errors.New("TargetValue must be greater than zero")
+		}
+	} else {
+		if err := ec.ComparisonOperator.Validate(); err != nil {
+			return err
+		}
+		if err := ec.Action.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := ec.QueryType.Validate(); err != nil {
+		return err
+	}
+	if err := ec.Reducer.Validate(); err != nil {
+		return err
+	}
+
+	if ec.QueryType == QueryTypeRange && ec.QueryWindow < 1 {
+		return errors.New("QueryWindow must be greater than zero when using a range query")
+	}
+	if ec.QueryWindow < 0 || ec.QueryStep < 0 {
+		return errors.New("QueryWindow and QueryStep must not be negative")
+	}
+	if ec.QueryStep > ec.QueryWindow && ec.QueryType == QueryTypeRange {
+		return errors.New("QueryStep must not be greater than QueryWindow")
+	}
+	return nil
 }
 
 // Validate performs a number of checks on the GroupScalingPolicy to ensure it is valid for use.
@@ -94,28 +241,105 @@ func (gsp GroupScalingPolicy) Validate() error {
 		return errors.New("please specify non-default scaling policy")
 	}
 
+	if err := gsp.validateCooldowns(); err != nil {
+		return err
+	}
+
+	if err := gsp.ScalingMode.Validate(); err != nil {
+		return err
+	}
+
+	if err := gsp.validateAdjustments(); err != nil {
+		return err
+	}
+
+	if gsp.ScaleInSafety != nil {
+		if err := gsp.ScaleInSafety.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate ScaleInSafety")
+		}
+	}
+
 	// Iterate over the external checks and validate the required components. The first error is
 	// returned, rather than collecting.
 	for name, check := range gsp.ExternalChecks {
-		if err := check.Provider.Validate(); err != nil {
+		if err := check.Validate(); err != nil {
 			return errors.Wrap(err, "failed to validate check"+name)
 		}
+		if gsp.TargetTrackingEnabled() != (check.TargetValue != nil) {
+			return errors.Errorf("check %s TargetValue must be set only when using target tracking", name)
+		}
+	}
 
-		if err := check.ComparisonOperator.Validate(); err != nil {
-			return errors.Wrap(err, "failed to validate check"+name)
+	for name, schedule := range gsp.Schedules {
+		if err := schedule.Validate(); err != nil {
+			return errors.Wrap(err, "failed to validate schedule "+name)
 		}
+	}
 
-		if err := check.Action.Validate(); err != nil {
-			return errors.Wrap(err, "failed to validate check"+name)
+	if gsp.Predictive != nil {
+		if err := gsp.Predictive.Validate(gsp.ExternalChecks); err != nil {
+			return errors.Wrap(err, "failed to validate predictive config")
 		}
 	}
 
+	if err := gsp.validateBreachPeriods(); err != nil {
+		return err
+	}
+
+	if gsp.CheckConditionsEnabled() {
+		if err := gsp.validateConditions(); err != nil {
+			return err
+		}
+	}
+
+	if gsp.TargetTrackingEnabled() {
+		return gsp.validateTargets()
+	}
+	if gsp.TargetCPUPercentage != nil || gsp.TargetMemoryPercentage != nil {
+		return errors.New("target percentages can only be set when using target tracking")
+	}
 	return nil
 }
 
+// validateTargets ensures a target tracking policy has at least one target configured, and that
+// all targets are positive values.
+func (gsp GroupScalingPolicy) validateTargets() error {
+	var targets int
+
+	for _, target := range []*float64{gsp.TargetCPUPercentage, gsp.TargetMemoryPercentage} {
+		if target == nil {
+			continue
+		}
+		if *target <= 0 {
+			return errors.New("target percentages must be greater than zero")
+		}
+		targets++
+	}
+
+	for _, check := range gsp.ExternalChecks {
+		if check.Enabled {
+			targets++
+		}
+	}
+
+	if targets < 1 {
+		return errors.New("target tracking requires at least one target to be configured")
+	}
+	return nil
+}
+
+// TargetTrackingEnabled identifies whether the group policy is configured to use target tracking
+// to calculate the desired job group count.
+func (gsp GroupScalingPolicy) TargetTrackingEnabled() bool {
+	return gsp.ScalingMode == ScalingModeTargetTracking
+}
+
 // NomadChecksEnabled helps determine whether the group policy ins configured to run scaling checks
 // based on Nomad resource metrics.
 func (gsp GroupScalingPolicy) NomadChecksEnabled() bool {
+	if gsp.TargetTrackingEnabled() {
+		return gsp.TargetCPUPercentage != nil || gsp.TargetMemoryPercentage != nil
+	}
 	if gsp.ScaleInMemoryPercentageThreshold == nil && gsp.ScaleOutMemoryPercentageThreshold == nil &&
 		gsp.ScaleInCPUPercentageThreshold == nil && gsp.ScaleOutCPUPercentageThreshold == nil {
 		return false
@@ -150,6 +374,32 @@ func (gsp GroupScalingPolicy) MergeWithDefaults() *GroupScalingPolicy {
 	return &n
 }
 
+// ScalingMode is the method used by the autoscaler to calculate the new count of a job group.
+type ScalingMode string
+
+// String returns the string form of the ScalingMode.
+func (sm ScalingMode) String() string { return string(sm) }
+
+// Validate checks the ScalingMode is a valid and that it can be handled within the autoscaler.
+func (sm ScalingMode) Validate() error {
+	switch sm {
+	case "", ScalingModeStep, ScalingModeTargetTracking:
+		return nil
+	default:
+		return errors.Errorf("ScalingMode %s is not a valid option", sm.String())
+	}
+}
+
+const (
+	// ScalingModeStep changes the job group count by the ScaleOutCount or ScaleInCount when a
+	// threshold check fails.
+	ScalingModeStep ScalingMode = "step"
+
+	// ScalingModeTargetTracking calculates the desired job group count based on the ratio between
+	// the current metric value and the configured target.
+	ScalingModeTargetTracking ScalingMode = "target-tracking"
+)
+
 // MetricsProvider represents the backend providers which can supply metric values for autoscaling.
 type MetricsProvider string
 
@@ -158,19 +408,60 @@ func (mp MetricsProvider) String() string { return string(mp) }
 
 // Validate checks the MetricsProvider is a valid and that it can be handled within the autoscaler.
 func (mp MetricsProvider) Validate() error {
-	switch mp {
-	case ProviderPrometheus:
-		return nil
-	default:
+	metricsProvidersLock.RLock()
+	_, ok := metricsProviders[mp]
+	metricsProvidersLock.RUnlock()
+
+	if !ok {
 		return errors.Errorf("Provider %s is not a valid option", mp.String())
 	}
+	return nil
 }
 
 const (
 	// ProviderPrometheus is the Prometheus metrics backend.
 	ProviderPrometheus MetricsProvider = "prometheus"
+
+	// ProviderInfluxDB is the InfluxDB metrics backend, supporting both InfluxQL and Flux queries.
+	ProviderInfluxDB MetricsProvider = "influxdb"
+
+	// ProviderGraphite is the Graphite metrics backend, queried via the render API.
+	ProviderGraphite MetricsProvider = "graphite"
+)
+
+var (
+	// metricsProviders is the registry of MetricsProvider names which are considered valid when
+	// validating external checks. It is seeded with the providers built into Sherpa.
+	metricsProviders = map[MetricsProvider]struct{}{
+		ProviderPrometheus: {},
+		ProviderInfluxDB:   {},
+		ProviderGraphite:   {},
+	}
+	metricsProvidersLock sync.RWMutex
 )
 
+// RegisterMetricsProvider adds the MetricsProvider to the registry of valid providers, allowing
+// external checks which reference it to pass validation.
+func RegisterMetricsProvider(mp MetricsProvider) {
+	metricsProvidersLock.Lock()
+	metricsProviders[mp] = struct{}{}
+	metricsProvidersLock.Unlock()
+}
+
+// RegisteredMetricsProviders returns an alphabetically sorted list of all the MetricsProviders
+// which are currently registered.
+func RegisteredMetricsProviders() []MetricsProvider {
+	metricsProvidersLock.RLock()
+	defer metricsProvidersLock.RUnlock()
+
+	out := make([]MetricsProvider, 0, len(metricsProviders))
+	for mp := range metricsProviders {
+		out = append(out, mp)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
 // ComparisonOperator is the operator used when evaluating a metric value against a threshold.
 type ComparisonOperator string
 
@@ -218,10 +509,67 @@ const (
 	ActionScaleOut ComparisonAction = "scale-out"
 )
 
+// QueryType is the type of query run against the metrics provider.
+type QueryType string
+
+// String returns the string form of the QueryType.
+func (qt QueryType) String() string { return string(qt) }
+
+// Validate checks the QueryType is a valid and that it can be handled within the autoscaler.
+func (qt QueryType) Validate() error {
+	switch qt {
+	case "", QueryTypeInstant, QueryTypeRange:
+		return nil
+	default:
+		return errors.Errorf("QueryType %s is not a valid option", qt.String())
+	}
+}
+
+const (
+	// QueryTypeInstant queries the provider for values at a single point in time.
+	QueryTypeInstant QueryType = "instant"
+
+	// QueryTypeRange queries the provider for values across the QueryWindow.
+	QueryTypeRange QueryType = "range"
+)
+
+// Reducer is the function used to collapse multiple metric values into a single value.
+type Reducer string
+
+// String returns the string form of the Reducer.
+func (r Reducer) String() string { return string(r) }
+
+// Validate checks the Reducer is a valid and that it can be handled within the autoscaler.
+func (r Reducer) Validate() error {
+	switch r {
+	case "", ReducerAvg, ReducerMax, ReducerMin, ReducerSum, ReducerLast, ReducerP95:
+		return nil
+	default:
+		return errors.Errorf("Reducer %s is not a valid option", r.String())
+	}
+}
+
+const (
+	ReducerAvg  Reducer = "avg"
+	ReducerMax  Reducer = "max"
+	ReducerMin  Reducer = "min"
+	ReducerSum  Reducer = "sum"
+	ReducerLast Reducer = "last"
+	ReducerP95  Reducer = "p95"
+)
+
 const (
 	DefaultMinCount      = 2
 	DefaultMaxCount      = 10
 	DefaultCooldown      = 180
 	DefaultScaleOutCount = 1
 	DefaultScaleInCount  = 1
+
+	// DefaultQueryStep is the range query resolution step in seconds used when an external check
+	// does not specify one.
+	DefaultQueryStep = 60
+
+	// DefaultReducer is the reducer used for range queries when an external check does not
+	// specify one.
+	DefaultReducer = ReducerAvg
 )
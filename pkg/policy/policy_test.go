@@ -51,6 +51,127 @@ func TestGroupScalingPolicy_Validate(t *testing.T) {
 			expectedOutput: nil,
 			name:           "valid core params with external check",
 		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:       true,
+				Cooldown:      100,
+				MinCount:      10,
+				MaxCount:      1000,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				ExternalChecks: map[string]*ExternalCheck{"test_external_check": {
+					Enabled:            true,
+					Provider:           ProviderPrometheus,
+					Query:              "what_do_you_get_when_you_multiply_six_by_nine",
+					ComparisonOperator: ComparisonGreaterThan,
+					ComparisonValue:    42,
+					Action:             ActionScaleIn,
+					QueryType:          QueryTypeRange,
+					QueryWindow:        300,
+					QueryStep:          30,
+					Reducer:            ReducerP95,
+				}},
+			},
+			expectedOutput: nil,
+			name:           "valid core params with range external check",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:       true,
+				Cooldown:      100,
+				MinCount:      10,
+				MaxCount:      1000,
+				ScaleOutCount: 1,
+				ScaleInCount:  1,
+				ExternalChecks: map[string]*ExternalCheck{"test_external_check": {
+					Enabled:            true,
+					Provider:           ProviderPrometheus,
+					Query:              "what_do_you_get_when_you_multiply_six_by_nine",
+					ComparisonOperator: ComparisonGreaterThan,
+					ComparisonValue:    42,
+					Action:             ActionScaleIn,
+					QueryType:          QueryTypeRange,
+				}},
+			},
+			expectedOutput: errors.New("failed to validate checktest_external_check: QueryWindow must be greater than zero when using a range query"),
+			name:           "range external check without window",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:             true,
+				MinCount:            1,
+				MaxCount:            10,
+				ScalingMode:         ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(60),
+				ExternalChecks: map[string]*ExternalCheck{"test_external_check": {
+					Enabled:     true,
+					Provider:    ProviderPrometheus,
+					Query:       "avg(http_requests_per_instance)",
+					TargetValue: helper.Float64ToPointer(100),
+				}},
+			},
+			expectedOutput: nil,
+			name:           "valid target tracking policy",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:     true,
+				MinCount:    1,
+				MaxCount:    10,
+				ScalingMode: ScalingModeTargetTracking,
+			},
+			expectedOutput: errors.New("target tracking requires at least one target to be configured"),
+			name:           "target tracking policy without targets",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:             true,
+				MinCount:            1,
+				MaxCount:            10,
+				ScalingMode:         ScalingModeTargetTracking,
+				TargetCPUPercentage: helper.Float64ToPointer(-1),
+			},
+			expectedOutput: errors.New("target percentages must be greater than zero"),
+			name:           "target tracking policy with negative target",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:     true,
+				MinCount:    1,
+				MaxCount:    10,
+				ScalingMode: ScalingModeTargetTracking,
+				ExternalChecks: map[string]*ExternalCheck{"test_external_check": {
+					Enabled:            true,
+					Provider:           ProviderPrometheus,
+					Query:              "what_do_you_get_when_you_multiply_six_by_nine",
+					ComparisonOperator: ComparisonGreaterThan,
+					ComparisonValue:    42,
+					Action:             ActionScaleIn,
+				}},
+			},
+			expectedOutput: errors.New("check test_external_check TargetValue must be set only when using target tracking"),
+			name:           "target tracking policy with threshold check",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:             true,
+				MinCount:            1,
+				MaxCount:            10,
+				TargetCPUPercentage: helper.Float64ToPointer(60),
+			},
+			expectedOutput: errors.New("target percentages can only be set when using target tracking"),
+			name:           "step policy with target",
+		},
+		{
+			policy: GroupScalingPolicy{
+				Enabled:     true,
+				MinCount:    1,
+				MaxCount:    10,
+				ScalingMode: "predictive",
+			},
+			expectedOutput: errors.New("ScalingMode predictive is not a valid option"),
+			name:           "invalid scaling mode",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -84,6 +205,21 @@ func TestGroupScalingPolicy_NomadChecksEnabled(t *testing.T) {
 			expectedOutput: true,
 			name:           "nomad checks enabled",
 		},
+		{
+			policy: GroupScalingPolicy{
+				ScalingMode:            ScalingModeTargetTracking,
+				TargetMemoryPercentage: helper.Float64ToPointer(70),
+			},
+			expectedOutput: true,
+			name:           "nomad target tracking enabled",
+		},
+		{
+			policy: GroupScalingPolicy{
+				ScalingMode: ScalingModeTargetTracking,
+			},
+			expectedOutput: false,
+			name:           "target tracking without nomad targets",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -136,6 +272,8 @@ func TestMetricsProvider_String(t *testing.T) {
 		expectedOutput string
 	}{
 		{inputProvider: ProviderPrometheus, expectedOutput: "prometheus"},
+		{inputProvider: ProviderInfluxDB, expectedOutput: "influxdb"},
+		{inputProvider: ProviderGraphite, expectedOutput: "graphite"},
 	}
 
 	for _, tc := range testCases {
@@ -152,6 +290,8 @@ func TestMetricsProvider_Validate(t *testing.T) {
 		expectedOutput error
 	}{
 		{inputOperator: ProviderPrometheus, expectedOutput: nil},
+		{inputOperator: ProviderInfluxDB, expectedOutput: nil},
+		{inputOperator: ProviderGraphite, expectedOutput: nil},
 		{inputOperator: fakeProvider, expectedOutput: errors.Errorf("Provider %s is not a valid option", fakeProvider.String())},
 	}
 
@@ -165,6 +305,65 @@ func TestMetricsProvider_Validate(t *testing.T) {
 	}
 }
 
+func TestRegisterMetricsProvider(t *testing.T) {
+	const customProvider MetricsProvider = "custom-provider"
+
+	assert.NotNil(t, customProvider.Validate())
+	RegisterMetricsProvider(customProvider)
+	assert.Nil(t, customProvider.Validate())
+	assert.Contains(t, RegisteredMetricsProviders(), customProvider)
+}
+
+func TestQueryType_Validate(t *testing.T) {
+	const fakeQueryType QueryType = "fake-query-type"
+
+	testCases := []struct {
+		inputQueryType QueryType
+		expectedOutput error
+	}{
+		{inputQueryType: "", expectedOutput: nil},
+		{inputQueryType: QueryTypeInstant, expectedOutput: nil},
+		{inputQueryType: QueryTypeRange, expectedOutput: nil},
+		{inputQueryType: fakeQueryType, expectedOutput: errors.Errorf("QueryType %s is not a valid option", fakeQueryType.String())},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputQueryType.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error())
+		}
+	}
+}
+
+func TestReducer_Validate(t *testing.T) {
+	const fakeReducer Reducer = "fake-reducer"
+
+	testCases := []struct {
+		inputReducer   Reducer
+		expectedOutput error
+	}{
+		{inputReducer: "", expectedOutput: nil},
+		{inputReducer: ReducerAvg, expectedOutput: nil},
+		{inputReducer: ReducerMax, expectedOutput: nil},
+		{inputReducer: ReducerMin, expectedOutput: nil},
+		{inputReducer: ReducerSum, expectedOutput: nil},
+		{inputReducer: ReducerLast, expectedOutput: nil},
+		{inputReducer: ReducerP95, expectedOutput: nil},
+		{inputReducer: fakeReducer, expectedOutput: errors.Errorf("Reducer %s is not a valid option", fakeReducer.String())},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.inputReducer.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error())
+		}
+	}
+}
+
 func TestComparisonOperator_String(t *testing.T) {
 	testCases := []struct {
 		inputOperator  ComparisonOperator
@@ -0,0 +1,105 @@
+package policy
+
+import "github.com/pkg/errors"
+
+// Predictive configures predictive scaling for a job group. When configured, the autoscaler builds
+// a weekly seasonal baseline for the job group on each evaluation. When enabled, the baseline is
+// used to pre-emptively scale out ahead of predicted increases in load.
+type Predictive struct {
+
+	// Enabled is a boolean flag to identify whether predictive scale out actions should be taken.
+	// When disabled, the baseline is still recorded, allowing predictions to be reviewed before
+	// being acted upon.
+	Enabled bool `json:"Enabled"`
+
+	// Check is the name of the external check whose values are recorded within the baseline. If
+	// this is not set, the baseline is built from the job group count history alone.
+	Check string `json:"Check,omitempty"`
+
+	// TargetValue is the per allocation value of the check which the predicted count aims to
+	// achieve. If this is not set, the TargetValue of the check is used.
+	TargetValue *float64 `json:"TargetValue,omitempty"`
+
+	// Aggregate indicates the check value represents the load of the whole job group, rather than
+	// a per allocation value such as utilisation.
+	Aggregate bool `json:"Aggregate,omitempty"`
+
+	// LookAhead is the time period in seconds into the future which predictions are made for.
+	LookAhead int `json:"LookAhead,omitempty"`
+
+	// BucketSize is the resolution in seconds of the weekly baseline.
+	BucketSize int `json:"BucketSize,omitempty"`
+
+	// MinSamples is the minimum number of completed weeks a baseline bucket must be built from
+	// before it is used to make predictions.
+	MinSamples int `json:"MinSamples,omitempty"`
+}
+
+// Validate checks the Predictive config is valid, using the external checks configured within the
+// same group policy.
+func (p Predictive) Validate(checks map[string]*ExternalCheck) error {
+	if p.LookAhead < 0 || p.BucketSize < 0 || p.MinSamples < 0 {
+		return errors.New("LookAhead, BucketSize and MinSamples must not be negative")
+	}
+	if p.TargetValue != nil && *p.TargetValue <= 0 {
+		return errors.New("TargetValue must be greater than zero")
+	}
+
+	if p.Check == "" {
+		return nil
+	}
+
+	check, ok := checks[p.Check]
+	if !ok {
+		return errors.Errorf("Check %s not found within external checks", p.Check)
+	}
+	if p.TargetValue == nil && check.TargetValue == nil {
+		return errors.New("TargetValue must be set when the check does not have a TargetValue")
+	}
+	return nil
+}
+
+// Target returns the per allocation target value used to calculate the predicted count. A nil
+// return indicates the baseline is built from count history alone.
+func (p Predictive) Target(checks map[string]*ExternalCheck) *float64 {
+	if p.Check == "" {
+		return nil
+	}
+	if p.TargetValue != nil {
+		return p.TargetValue
+	}
+	if check, ok := checks[p.Check]; ok {
+		return check.TargetValue
+	}
+	return nil
+}
+
+// MergeWithDefaults returns a copy of the Predictive config with defaults set where the user has
+// not set some.
+func (p Predictive) MergeWithDefaults() *Predictive {
+	n := p
+
+	if n.LookAhead == 0 {
+		n.LookAhead = DefaultPredictiveLookAhead
+	}
+	if n.BucketSize == 0 {
+		n.BucketSize = DefaultPredictiveBucketSize
+	}
+	if n.MinSamples == 0 {
+		n.MinSamples = DefaultPredictiveMinSamples
+	}
+	return &n
+}
+
+const (
+	// DefaultPredictiveLookAhead is the default time period in seconds which predictions are made
+	// for.
+	DefaultPredictiveLookAhead = 900
+
+	// DefaultPredictiveBucketSize is the default resolution in seconds of the weekly baseline.
+	DefaultPredictiveBucketSize = 900
+
+	// DefaultPredictiveMinSamples is the default minimum number of completed weeks a baseline
+	// bucket must be built from before it is used to make predictions.
+	DefaultPredictiveMinSamples = 3
+)
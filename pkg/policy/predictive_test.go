@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictive_Validate(t *testing.T) {
+	checks := map[string]*ExternalCheck{
+		"requests": {Enabled: true, Provider: ProviderPrometheus, TargetValue: helper.Float64ToPointer(100)},
+		"latency":  {Enabled: true, Provider: ProviderPrometheus, ComparisonValue: 200},
+	}
+
+	testCases := []struct {
+		predictive     Predictive
+		expectedOutput error
+		name           string
+	}{
+		{
+			predictive:     Predictive{Enabled: true},
+			expectedOutput: nil,
+			name:           "valid count only",
+		},
+		{
+			predictive:     Predictive{Enabled: true, Check: "requests"},
+			expectedOutput: nil,
+			name:           "valid check with target",
+		},
+		{
+			predictive:     Predictive{Enabled: true, Check: "latency", TargetValue: helper.Float64ToPointer(50)},
+			expectedOutput: nil,
+			name:           "valid check with predictive target",
+		},
+		{
+			predictive:     Predictive{Enabled: true, Check: "latency"},
+			expectedOutput: errors.New("TargetValue must be set when the check does not have a TargetValue"),
+			name:           "check without target",
+		},
+		{
+			predictive:     Predictive{Enabled: true, Check: "missing"},
+			expectedOutput: errors.New("Check missing not found within external checks"),
+			name:           "check not found",
+		},
+		{
+			predictive:     Predictive{Enabled: true, LookAhead: -1},
+			expectedOutput: errors.New("LookAhead, BucketSize and MinSamples must not be negative"),
+			name:           "negative look ahead",
+		},
+		{
+			predictive:     Predictive{Enabled: true, TargetValue: helper.Float64ToPointer(0)},
+			expectedOutput: errors.New("TargetValue must be greater than zero"),
+			name:           "zero target value",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.predictive.Validate(checks)
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput, tc.name)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error(), tc.name)
+		}
+	}
+}
+
+func TestPredictive_MergeWithDefaults(t *testing.T) {
+	expectedOutput := &Predictive{
+		Enabled:    true,
+		LookAhead:  1800,
+		BucketSize: DefaultPredictiveBucketSize,
+		MinSamples: DefaultPredictiveMinSamples,
+	}
+	assert.Equal(t, expectedOutput, Predictive{Enabled: true, LookAhead: 1800}.MergeWithDefaults())
+}
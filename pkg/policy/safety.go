@@ -0,0 +1,62 @@
+package policy
+
+import "github.com/pkg/errors"
+
+const (
+	// DefaultScaleInSafetyWindow is the time period in seconds used to limit the scale in rate
+	// when a window is not configured.
+	DefaultScaleInSafetyWindow = 3600
+
+	// MaxScaleInSafetyWindow is the largest time period in seconds which can be used to limit the
+	// scale in rate. It matches the period scaling events are retained by the state backend.
+	MaxScaleInSafetyWindow = 86400
+)
+
+// ScaleInSafety configures protections which prevent scaling in of a job group from removing too
+// much capacity, or from compounding an ongoing incident.
+type ScaleInSafety struct {
+
+	// MaxPercentage is the maximum percentage of the job group count which can be removed by
+	// scaling in within the Window. A zero value indicates the scale in rate is not limited.
+	MaxPercentage int `json:"MaxPercentage,omitempty"`
+
+	// Window is the time period in seconds used to limit the scale in rate. If this is not set,
+	// DefaultScaleInSafetyWindow is used.
+	Window int `json:"Window,omitempty"`
+
+	// MinHealthyAllocs is the minimum number of healthy allocations the job group must have once
+	// it has been scaled in.
+	MinHealthyAllocs int `json:"MinHealthyAllocs,omitempty"`
+
+	// BlockWhileUnhealthy blocks scaling in of the job group while any of its allocations are
+	// unhealthy or pending.
+	BlockWhileUnhealthy bool `json:"BlockWhileUnhealthy,omitempty"`
+}
+
+// Validate checks the ScaleInSafety options are valid.
+func (sis ScaleInSafety) Validate() error {
+	if sis.MaxPercentage < 0 || sis.MaxPercentage > 100 {
+		return errors.New("MaxPercentage must be between 0 and 100")
+	}
+	if sis.Window < 0 || sis.Window > MaxScaleInSafetyWindow {
+		return errors.Errorf("Window must be between 0 and %v", MaxScaleInSafetyWindow)
+	}
+	if sis.MinHealthyAllocs < 0 {
+		return errors.New("MinHealthyAllocs must not be negative")
+	}
+	return nil
+}
+
+// WindowOrDefault returns the time period in seconds used to limit the scale in rate.
+func (sis ScaleInSafety) WindowOrDefault() int {
+	if sis.Window == 0 {
+		return DefaultScaleInSafetyWindow
+	}
+	return sis.Window
+}
+
+// RequiresAllocations identifies whether the job group allocations are required to perform the
+// scale in safety checks.
+func (sis ScaleInSafety) RequiresAllocations() bool {
+	return sis.BlockWhileUnhealthy || sis.MinHealthyAllocs > 0
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleInSafety_Validate(t *testing.T) {
+	testCases := []struct {
+		inputSafety    ScaleInSafety
+		expectedOutput string
+		name           string
+	}{
+		{
+			inputSafety:    ScaleInSafety{MaxPercentage: 20, Window: 3600, MinHealthyAllocs: 2, BlockWhileUnhealthy: true},
+			expectedOutput: "",
+			name:           "valid",
+		},
+		{
+			inputSafety:    ScaleInSafety{MaxPercentage: 101},
+			expectedOutput: "MaxPercentage must be between 0 and 100",
+			name:           "percentage too large",
+		},
+		{
+			inputSafety:    ScaleInSafety{Window: 86401},
+			expectedOutput: "Window must be between 0 and 86400",
+			name:           "window too large",
+		},
+		{
+			inputSafety:    ScaleInSafety{MinHealthyAllocs: -1},
+			expectedOutput: "MinHealthyAllocs must not be negative",
+			name:           "negative healthy floor",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.inputSafety.Validate()
+		if tc.expectedOutput == "" {
+			assert.Nil(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedOutput, tc.name)
+		}
+	}
+
+	assert.Equal(t, DefaultScaleInSafetyWindow, ScaleInSafety{}.WindowOrDefault())
+	assert.Equal(t, 600, ScaleInSafety{Window: 600}.WindowOrDefault())
+}
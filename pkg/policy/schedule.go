@@ -0,0 +1,166 @@
+package policy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+// Schedule is a time based capacity window for a job group. The window opens each time the cron
+// expression fires, and remains open for the configured duration. While open, the schedule either
+// holds the job group at a fixed count, or overrides the policy minimum and maximum counts.
+type Schedule struct {
+
+	// Enabled is a boolean flag to identify whether this specific schedule should be actively
+	// enforced or not.
+	Enabled bool `json:"Enabled"`
+
+	// Cron is the cron expression which dictates when the schedule window opens.
+	Cron string `json:"Cron"`
+
+	// Timezone is the IANA timezone name used when evaluating the cron expression. If this is not
+	// set, UTC is used.
+	Timezone string `json:"Timezone,omitempty"`
+
+	// Duration is the time period in seconds which the schedule window stays open once the cron
+	// expression has fired.
+	Duration int `json:"Duration"`
+
+	// Count is the job group count to hold while the schedule window is open. This cannot be
+	// used alongside MinCount or MaxCount.
+	Count *int `json:"Count,omitempty"`
+
+	// MinCount overrides the policy minimum count while the schedule window is open.
+	MinCount *int `json:"MinCount,omitempty"`
+
+	// MaxCount overrides the policy maximum count while the schedule window is open.
+	MaxCount *int `json:"MaxCount,omitempty"`
+}
+
+// Validate checks the Schedule is valid and can be enforced by the autoscaler.
+func (s Schedule) Validate() error {
+	if _, err := cronexpr.Parse(s.Cron); err != nil {
+		return errors.Wrap(err, "failed to parse Cron")
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return errors.Wrap(err, "failed to load Timezone")
+	}
+	if s.Duration < 1 {
+		return errors.New("Duration must be greater than zero")
+	}
+
+	if s.Count != nil {
+		if s.MinCount != nil || s.MaxCount != nil {
+			return errors.New("Count cannot be used alongside MinCount or MaxCount")
+		}
+		if *s.Count < 0 {
+			return errors.New("Count must not be negative")
+		}
+		return nil
+	}
+
+	if s.MinCount == nil && s.MaxCount == nil {
+		return errors.New("one of Count, MinCount or MaxCount must be set")
+	}
+	if (s.MinCount != nil && *s.MinCount < 0) || (s.MaxCount != nil && *s.MaxCount < 0) {
+		return errors.New("MinCount and MaxCount must not be negative")
+	}
+	if s.MinCount != nil && s.MaxCount != nil && *s.MinCount > *s.MaxCount {
+		return errors.New("MinCount must not be greater than MaxCount")
+	}
+	return nil
+}
+
+// openedAt returns the time the schedule window most recently opened, if the window is open at the
+// passed time. The bool return indicates whether the window is open.
+func (s Schedule) openedAt(t time.Time) (time.Time, bool) {
+	expr, err := cronexpr.Parse(s.Cron)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// The window is open if the cron expression fired within the last duration. Finding the next
+	// fire time after the start of the lookback allows this to be calculated without the
+	// expression supporting previous fire time lookups.
+	lookback := t.In(loc).Add(-time.Duration(s.Duration) * time.Second)
+
+	next := expr.Next(lookback)
+	if next.IsZero() || next.After(t) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// ActiveSchedule returns the name and schedule which is currently open at the passed time. If
+// multiple schedules are open, the most recently opened wins, with ties broken by name. An empty
+// name and nil schedule are returned if no schedules are open.
+func (gsp GroupScalingPolicy) ActiveSchedule(t time.Time) (string, *Schedule) {
+	names := make([]string, 0, len(gsp.Schedules))
+	for name := range gsp.Schedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var (
+		activeName   string
+		activeOpened time.Time
+	)
+
+	for _, name := range names {
+		if !gsp.Schedules[name].Enabled {
+			continue
+		}
+
+		opened, ok := gsp.Schedules[name].openedAt(t)
+		if !ok {
+			continue
+		}
+		if activeName == "" || opened.After(activeOpened) {
+			activeName, activeOpened = name, opened
+		}
+	}
+
+	if activeName == "" {
+		return "", nil
+	}
+	return activeName, gsp.Schedules[activeName]
+}
+
+// ApplySchedule returns a copy of the policy with the schedule overrides applied to the minimum
+// and maximum counts. A schedule count sets both the minimum and maximum to the count.
+func (gsp GroupScalingPolicy) ApplySchedule(s *Schedule) *GroupScalingPolicy {
+	n := gsp
+
+	if s == nil {
+		return &n
+	}
+
+	if s.Count != nil {
+		n.MinCount, n.MaxCount = *s.Count, *s.Count
+		return &n
+	}
+	if s.MinCount != nil {
+		n.MinCount = *s.MinCount
+	}
+	if s.MaxCount != nil {
+		n.MaxCount = *s.MaxCount
+	}
+
+	// An override of only one bound could leave the policy with an impossible range, so ensure
+	// the overridden bound always wins.
+	if n.MinCount > n.MaxCount {
+		if s.MinCount != nil {
+			n.MaxCount = n.MinCount
+		} else {
+			n.MinCount = n.MaxCount
+		}
+	}
+	return &n
+}
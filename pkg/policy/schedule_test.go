@@ -0,0 +1,197 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	testCases := []struct {
+		schedule       Schedule
+		expectedOutput error
+		name           string
+	}{
+		{
+			schedule:       Schedule{Cron: "0 8 * * 1-5", Timezone: "Europe/London", Duration: 3600, Count: helper.IntToPointer(10)},
+			expectedOutput: nil,
+			name:           "valid count schedule",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Duration: 3600, MinCount: helper.IntToPointer(4), MaxCount: helper.IntToPointer(20)},
+			expectedOutput: nil,
+			name:           "valid min max schedule",
+		},
+		{
+			schedule:       Schedule{Cron: "not a cron", Duration: 3600, Count: helper.IntToPointer(10)},
+			expectedOutput: errors.New("failed to parse Cron: missing field(s)"),
+			name:           "invalid cron",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Timezone: "Mars/Olympus", Duration: 3600, Count: helper.IntToPointer(10)},
+			expectedOutput: errors.New("failed to load Timezone: unknown time zone Mars/Olympus"),
+			name:           "invalid timezone",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Count: helper.IntToPointer(10)},
+			expectedOutput: errors.New("Duration must be greater than zero"),
+			name:           "missing duration",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Duration: 3600, Count: helper.IntToPointer(10), MinCount: helper.IntToPointer(4)},
+			expectedOutput: errors.New("Count cannot be used alongside MinCount or MaxCount"),
+			name:           "count with overrides",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Duration: 3600},
+			expectedOutput: errors.New("one of Count, MinCount or MaxCount must be set"),
+			name:           "no counts",
+		},
+		{
+			schedule:       Schedule{Cron: "0 8 * * *", Duration: 3600, MinCount: helper.IntToPointer(20), MaxCount: helper.IntToPointer(4)},
+			expectedOutput: errors.New("MinCount must not be greater than MaxCount"),
+			name:           "min greater than max",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput := tc.schedule.Validate()
+		if tc.expectedOutput == nil {
+			assert.Nil(t, actualOutput, tc.name)
+		} else {
+			assert.EqualError(t, actualOutput, tc.expectedOutput.Error(), tc.name)
+		}
+	}
+}
+
+func TestGroupScalingPolicy_ActiveSchedule(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	assert.Nil(t, err)
+
+	pol := GroupScalingPolicy{
+		Schedules: map[string]*Schedule{
+			"morning": {
+				Enabled:  true,
+				Cron:     "0 8 * * *",
+				Timezone: "Europe/London",
+				Duration: 7200,
+				MinCount: helper.IntToPointer(6),
+			},
+			"late-morning": {
+				Enabled:  true,
+				Cron:     "0 9 * * *",
+				Timezone: "Europe/London",
+				Duration: 1800,
+				Count:    helper.IntToPointer(12),
+			},
+			"disabled": {
+				Enabled:  false,
+				Cron:     "* * * * *",
+				Duration: 3600,
+				Count:    helper.IntToPointer(1),
+			},
+		},
+	}
+
+	testCases := []struct {
+		time         time.Time
+		expectedName string
+		name         string
+	}{
+		{
+			time:         time.Date(2020, 6, 1, 7, 59, 0, 0, london),
+			expectedName: "",
+			name:         "before any window opens",
+		},
+		{
+			time:         time.Date(2020, 6, 1, 8, 30, 0, 0, london),
+			expectedName: "morning",
+			name:         "single open window",
+		},
+		{
+			time:         time.Date(2020, 6, 1, 9, 15, 0, 0, london),
+			expectedName: "late-morning",
+			name:         "most recently opened window wins",
+		},
+		{
+			time:         time.Date(2020, 6, 1, 9, 45, 0, 0, london),
+			expectedName: "morning",
+			name:         "shorter window has closed",
+		},
+		{
+			time:         time.Date(2020, 6, 1, 9, 15, 0, 0, london).UTC(),
+			expectedName: "late-morning",
+			name:         "time in different location",
+		},
+		{
+			time:         time.Date(2020, 6, 1, 10, 0, 1, 0, london),
+			expectedName: "",
+			name:         "all windows closed",
+		},
+	}
+
+	for _, tc := range testCases {
+		actualName, actualSchedule := pol.ActiveSchedule(tc.time)
+		assert.Equal(t, tc.expectedName, actualName, tc.name)
+		if tc.expectedName == "" {
+			assert.Nil(t, actualSchedule, tc.name)
+		} else {
+			assert.Equal(t, pol.Schedules[tc.expectedName], actualSchedule, tc.name)
+		}
+	}
+}
+
+func TestGroupScalingPolicy_ApplySchedule(t *testing.T) {
+	testCases := []struct {
+		schedule         *Schedule
+		expectedMinCount int
+		expectedMaxCount int
+		name             string
+	}{
+		{
+			schedule:         nil,
+			expectedMinCount: 2,
+			expectedMaxCount: 10,
+			name:             "no schedule",
+		},
+		{
+			schedule:         &Schedule{Count: helper.IntToPointer(8)},
+			expectedMinCount: 8,
+			expectedMaxCount: 8,
+			name:             "count schedule",
+		},
+		{
+			schedule:         &Schedule{MinCount: helper.IntToPointer(5), MaxCount: helper.IntToPointer(30)},
+			expectedMinCount: 5,
+			expectedMaxCount: 30,
+			name:             "min and max override",
+		},
+		{
+			schedule:         &Schedule{MinCount: helper.IntToPointer(15)},
+			expectedMinCount: 15,
+			expectedMaxCount: 15,
+			name:             "min override above policy max",
+		},
+		{
+			schedule:         &Schedule{MaxCount: helper.IntToPointer(1)},
+			expectedMinCount: 1,
+			expectedMaxCount: 1,
+			name:             "max override below policy min",
+		},
+	}
+
+	pol := GroupScalingPolicy{MinCount: 2, MaxCount: 10}
+
+	for _, tc := range testCases {
+		actualOutput := pol.ApplySchedule(tc.schedule)
+		assert.Equal(t, tc.expectedMinCount, actualOutput.MinCount, tc.name)
+		assert.Equal(t, tc.expectedMaxCount, actualOutput.MaxCount, tc.name)
+	}
+
+	// The original policy should not be modified.
+	assert.Equal(t, 2, pol.MinCount)
+	assert.Equal(t, 10, pol.MaxCount)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/policy"
 	"github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/pkg/errors"
@@ -41,8 +42,7 @@ func (p *Policy) GetJobPolicies(w http.ResponseWriter, r *http.Request) {
 
 func (p *Policy) GetJobPolicy(w http.ResponseWriter, r *http.Request) {
 
-	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 
 	policies, err := p.backend.GetJobPolicy(job)
 	if err != nil {
@@ -68,7 +68,7 @@ func (p *Policy) GetJobPolicy(w http.ResponseWriter, r *http.Request) {
 func (p *Policy) GetJobGroupPolicy(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 	group := vars["group"]
 
 	gPolicy, err := p.backend.GetJobGroupPolicy(job, group)
@@ -109,8 +109,7 @@ func (p *Policy) PutJobPolicy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 
 	if err := p.backend.PutJobPolicy(job, jobPolicy); err != nil {
 		p.logger.Error().Err(err).Msg("failed to call policy backend")
@@ -124,7 +123,7 @@ func (p *Policy) PutJobPolicy(w http.ResponseWriter, r *http.Request) {
 func (p *Policy) PutJobGroupPolicy(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 	group := vars["group"]
 
 	b, err := ioutil.ReadAll(r.Body)
@@ -152,7 +151,7 @@ func (p *Policy) PutJobGroupPolicy(w http.ResponseWriter, r *http.Request) {
 func (p *Policy) DeleteJobGroupPolicy(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 	group := vars["group"]
 
 	if err := p.backend.DeleteJobGroupPolicy(job, group); err != nil {
@@ -164,8 +163,7 @@ func (p *Policy) DeleteJobGroupPolicy(w http.ResponseWriter, r *http.Request) {
 
 func (p *Policy) DeleteJobPolicy(w http.ResponseWriter, r *http.Request) {
 
-	vars := mux.Vars(r)
-	job := vars["job_id"]
+	job := helper.JobIDFromRequest(r)
 
 	if err := p.backend.DeleteJobPolicy(job); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,300 @@
+package scale
+
+import (
+	"strconv"
+
+	"github.com/armon/go-metrics"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/notify"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/pkg/errors"
+)
+
+const (
+	// InsufficientCapacityEventType is the type of event sent to the capacity webhook when a scale
+	// out request could not be fully placed on the Nomad cluster.
+	InsufficientCapacityEventType = "InsufficientClusterCapacity"
+
+	// nodeStatusReady and nodeEligible are the Nomad node status and scheduling eligibility
+	// of nodes which can run allocations. The Nomad API does not currently export these values.
+	nodeStatusReady = "ready"
+	nodeEligible    = "eligible"
+
+	// The meta keys added to insufficient capacity scaling events.
+	capacityMetaKeyRequested = "requested-count"
+	capacityMetaKeyPlaceable = "placeable-count"
+)
+
+// CapacityConfig configures the Nomad cluster capacity checks performed before scaling out job
+// groups.
+type CapacityConfig struct {
+
+	// Enabled indicates whether scale out requests should be checked, and capped, against the
+	// free capacity of the Nomad cluster.
+	Enabled bool
+
+	// Webhook optionally sends insufficient capacity events to a HTTP address, so that a node
+	// autoscaler can react. This is nil if no webhook is configured.
+	Webhook *notify.JSONSender
+}
+
+// InsufficientCapacityEvent is sent to the capacity webhook when a scale out request could not be
+// fully placed on the Nomad cluster.
+type InsufficientCapacityEvent struct {
+	Type        string
+	ID          uuid.UUID
+	Job         string
+	Datacenters []string
+	Time        int64
+	Groups      []*CapacityShortfall
+}
+
+// CapacityShortfall details a job group scale out request which could not be fully placed.
+type CapacityShortfall struct {
+
+	// Group is the name of the job group.
+	Group string
+
+	// Requested is the number by which the job group was requested to be scaled out.
+	Requested int
+
+	// Placeable is the number of allocations which fit within the free cluster capacity, and by
+	// which the job group was scaled out.
+	Placeable int
+
+	// CPU and MemoryMB are the resources required by each allocation of the job group.
+	CPU      int
+	MemoryMB int
+
+	// policy is the scaling policy of the job group, used to route notifications of the
+	// insufficient capacity scaling event.
+	policy *policy.GroupScalingPolicy
+}
+
+// nodeCapacity is the free capacity of a single Nomad node.
+type nodeCapacity struct {
+	cpu      int
+	memoryMB int
+}
+
+// applyClusterCapacity caps the scale out requests to the number of allocations which fit within
+// the free capacity of the Nomad cluster, updating the job group counts accordingly. Any
+// shortfall is recorded as an insufficient capacity scaling event. The returned requests exclude
+// those which can no longer make any change to the job.
+func (s *Scaler) applyClusterCapacity(jobID string, job *api.Job, groupReqs []*GroupReq, source state.Source) []*GroupReq {
+	capacity, err := s.clusterCapacity(jobID, job)
+	if err != nil {
+		s.logger.Error().Str("job", jobID).Err(err).Msg("failed to calculate Nomad cluster capacity")
+		return groupReqs
+	}
+
+	var (
+		shortfalls []*CapacityShortfall
+		reqs       []*GroupReq
+	)
+
+	for _, req := range groupReqs {
+		if req.Direction != DirectionOut || req.Count == 0 {
+			reqs = append(reqs, req)
+			continue
+		}
+
+		tg := s.checkJobGroupExists(job, req.GroupName)
+		if tg == nil {
+			reqs = append(reqs, req)
+			continue
+		}
+
+		cpu, mem := groupAllocResources(tg)
+		placeable := placeableCount(capacity, cpu, mem, req.Count)
+
+		if placeable < req.Count {
+			shortfalls = append(shortfalls, &CapacityShortfall{
+				Group: req.GroupName, Requested: req.Count, Placeable: placeable, CPU: cpu, MemoryMB: mem,
+				policy: req.GroupScalingPolicy,
+			})
+			*tg.Count -= req.Count - placeable
+			req.Count = placeable
+		}
+
+		if req.Count > 0 {
+			reqs = append(reqs, req)
+		}
+	}
+
+	if len(shortfalls) > 0 {
+		s.handleInsufficientCapacity(jobID, job, shortfalls, source)
+	}
+	return reqs
+}
+
+// handleInsufficientCapacity records the shortfalls as an insufficient capacity scaling event,
+// and sends the event to the webhook if configured.
+func (s *Scaler) handleInsufficientCapacity(jobID string, job *api.Job, shortfalls []*CapacityShortfall, source state.Source) {
+	t := helper.GenerateEventTimestamp()
+
+	reqs := make([]*GroupReq, len(shortfalls))
+	for i, shortfall := range shortfalls {
+		s.logger.Warn().
+			Str("job", jobID).
+			Str("group", shortfall.Group).
+			Int("requested", shortfall.Requested).
+			Int("placeable", shortfall.Placeable).
+			Msg("insufficient cluster capacity to scale out job group")
+
+		reqs[i] = &GroupReq{
+			Direction:          DirectionOut,
+			Count:              shortfall.Requested - shortfall.Placeable,
+			GroupName:          shortfall.Group,
+			GroupScalingPolicy: shortfall.policy,
+			Time:               t,
+			Meta: map[string]string{
+				capacityMetaKeyRequested: strconv.Itoa(shortfall.Requested),
+				capacityMetaKeyPlaceable: strconv.Itoa(shortfall.Placeable),
+			},
+		}
+	}
+
+	metrics.IncrCounter([]string{"scale", "capacity", "insufficient"}, 1)
+	metrics.IncrCounter([]string{"scale", jobID, "capacity", "insufficient"}, 1)
+
+	id := s.sendScalingEventToState(jobID, "", 0, source, reqs, state.StatusInsufficientCapacity,
+		errors.New("insufficient cluster capacity to scale out job group"))
+
+	if s.capacity.Webhook == nil {
+		return
+	}
+
+	event := &InsufficientCapacityEvent{
+		Type:        InsufficientCapacityEventType,
+		ID:          id,
+		Job:         jobID,
+		Datacenters: job.Datacenters,
+		Time:        t,
+		Groups:      shortfalls,
+	}
+
+	go func() {
+		if err := s.capacity.Webhook.Send(event); err != nil {
+			s.logger.Error().Str("job", jobID).Err(err).Msg("failed to send insufficient capacity webhook")
+		}
+	}()
+}
+
+// clusterCapacity returns the free capacity of each Nomad node which is able to run allocations
+// of the job.
+func (s *Scaler) clusterCapacity(jobID string, job *api.Job) ([]*nodeCapacity, error) {
+	nomad, _, _, err := s.nomad.Job(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, _, err := nomad.Nodes().List(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list Nomad nodes")
+	}
+
+	var capacity []*nodeCapacity
+
+	for _, stub := range nodes {
+		if stub.Status != nodeStatusReady || stub.SchedulingEligibility != nodeEligible || stub.Drain {
+			continue
+		}
+		if !containsString(job.Datacenters, stub.Datacenter) {
+			continue
+		}
+
+		node, _, err := nomad.Nodes().Info(stub.ID, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read Nomad node %s", stub.ID)
+		}
+
+		allocs, _, err := nomad.Nodes().Allocations(stub.ID, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list allocations of Nomad node %s", stub.ID)
+		}
+		capacity = append(capacity, nodeFreeCapacity(node, allocs))
+	}
+	return capacity, nil
+}
+
+// nodeFreeCapacity calculates the free capacity of the node, taking into account reserved
+// resources and the resources of allocations which are running or pending.
+func nodeFreeCapacity(node *api.Node, allocs []*api.Allocation) *nodeCapacity {
+	free := &nodeCapacity{}
+
+	if node.Resources != nil {
+		free.cpu += intValue(node.Resources.CPU)
+		free.memoryMB += intValue(node.Resources.MemoryMB)
+	}
+	if node.Reserved != nil {
+		free.cpu -= intValue(node.Reserved.CPU)
+		free.memoryMB -= intValue(node.Reserved.MemoryMB)
+	}
+
+	for _, alloc := range allocs {
+		if alloc.DesiredStatus != api.AllocDesiredStatusRun || alloc.Resources == nil {
+			continue
+		}
+		if alloc.ClientStatus != api.AllocClientStatusRunning && alloc.ClientStatus != api.AllocClientStatusPending {
+			continue
+		}
+		free.cpu -= intValue(alloc.Resources.CPU)
+		free.memoryMB -= intValue(alloc.Resources.MemoryMB)
+	}
+	return free
+}
+
+// groupAllocResources returns the CPU and memory resources required by a single allocation of
+// the job group.
+func groupAllocResources(tg *api.TaskGroup) (int, int) {
+	var cpu, mem int
+
+	for _, task := range tg.Tasks {
+		if task.Resources == nil {
+			continue
+		}
+		cpu += intValue(task.Resources.CPU)
+		mem += intValue(task.Resources.MemoryMB)
+	}
+	return cpu, mem
+}
+
+// placeableCount returns the number of allocations, up to the wanted number, which fit within the
+// free node capacity. The node capacity is reduced by the allocations placed, so that multiple
+// job groups can be checked against the same capacity.
+func placeableCount(capacity []*nodeCapacity, cpu, mem, want int) int {
+	if cpu <= 0 && mem <= 0 {
+		return want
+	}
+
+	var placed int
+
+	for _, node := range capacity {
+		for placed < want && node.cpu >= cpu && node.memoryMB >= mem {
+			node.cpu -= cpu
+			node.memoryMB -= mem
+			placed++
+		}
+	}
+	return placed
+}
+
+func containsString(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
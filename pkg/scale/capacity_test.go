@@ -0,0 +1,88 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nodeFreeCapacity(t *testing.T) {
+	node := &api.Node{
+		Resources: &api.Resources{CPU: helper.IntToPointer(4000), MemoryMB: helper.IntToPointer(8192)},
+		Reserved:  &api.Resources{CPU: helper.IntToPointer(500), MemoryMB: helper.IntToPointer(512)},
+	}
+
+	allocs := []*api.Allocation{
+		{DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning,
+			Resources: &api.Resources{CPU: helper.IntToPointer(1000), MemoryMB: helper.IntToPointer(2048)}},
+		{DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusPending,
+			Resources: &api.Resources{CPU: helper.IntToPointer(500), MemoryMB: helper.IntToPointer(1024)}},
+		{DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusComplete,
+			Resources: &api.Resources{CPU: helper.IntToPointer(500), MemoryMB: helper.IntToPointer(1024)}},
+		{DesiredStatus: api.AllocDesiredStatusStop, ClientStatus: api.AllocClientStatusRunning,
+			Resources: &api.Resources{CPU: helper.IntToPointer(500), MemoryMB: helper.IntToPointer(1024)}},
+	}
+
+	assert.Equal(t, &nodeCapacity{cpu: 2000, memoryMB: 4608}, nodeFreeCapacity(node, allocs))
+}
+
+func Test_groupAllocResources(t *testing.T) {
+	tg := api.NewTaskGroup("cache", 1).
+		AddTask(&api.Task{Name: "redis", Resources: &api.Resources{CPU: helper.IntToPointer(500), MemoryMB: helper.IntToPointer(256)}}).
+		AddTask(&api.Task{Name: "exporter", Resources: &api.Resources{CPU: helper.IntToPointer(100), MemoryMB: helper.IntToPointer(64)}}).
+		AddTask(&api.Task{Name: "sidecar"})
+
+	cpu, mem := groupAllocResources(tg)
+	assert.Equal(t, 600, cpu)
+	assert.Equal(t, 320, mem)
+}
+
+func Test_placeableCount(t *testing.T) {
+	testCases := []struct {
+		inputCapacity  []*nodeCapacity
+		inputCPU       int
+		inputMem       int
+		inputWant      int
+		expectedOutput int
+		name           string
+	}{
+		{
+			inputCapacity:  []*nodeCapacity{{cpu: 1000, memoryMB: 1024}, {cpu: 2000, memoryMB: 512}},
+			inputCPU:       500,
+			inputMem:       256,
+			inputWant:      3,
+			expectedOutput: 3,
+			name:           "all allocations fit",
+		},
+		{
+			inputCapacity:  []*nodeCapacity{{cpu: 1000, memoryMB: 1024}, {cpu: 2000, memoryMB: 512}},
+			inputCPU:       500,
+			inputMem:       512,
+			inputWant:      5,
+			expectedOutput: 3,
+			name:           "allocations limited by node memory",
+		},
+		{
+			inputCapacity:  []*nodeCapacity{{cpu: 100, memoryMB: 1024}},
+			inputCPU:       500,
+			inputMem:       256,
+			inputWant:      2,
+			expectedOutput: 0,
+			name:           "no allocations fit",
+		},
+		{
+			inputCapacity:  nil,
+			inputCPU:       0,
+			inputMem:       0,
+			inputWant:      2,
+			expectedOutput: 2,
+			name:           "group without resources",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, placeableCount(tc.inputCapacity, tc.inputCPU, tc.inputMem, tc.inputWant), tc.name)
+	}
+}
@@ -12,6 +12,10 @@ type Scale interface {
 	// Trigger performs scaling of 1 or more job groups which belong to the same job.
 	Trigger(string, []*GroupReq, state.Source) (*ScalingResponse, int, error)
 
+	// DryRun performs the checks of Trigger on 1 or more job groups which belong to the same job,
+	// recording the result as a dry-run scaling event without changing the job.
+	DryRun(string, []*GroupReq, state.Source) (*ScalingResponse, int, error)
+
 	// GetDeploymentChannel is used to return the channel where updates to Nomad deployments should
 	// be sent.
 	GetDeploymentChannel() chan interface{}
@@ -46,6 +50,16 @@ type GroupReq struct {
 	// populate this field for use and moves this logic away from the trigger.
 	Count int
 
+	// Absolute indicates that Count is the desired count of the job group, rather than the number
+	// by which to change the count. This is used by target tracking policies which calculate the
+	// desired count directly. The Direction should still reflect the change being made.
+	Absolute bool
+
+	// Percentage indicates that Count is a percentage of the current job group count, rather than
+	// the number by which to change the count. It is converted using the GroupScalingPolicy
+	// adjustment configuration once the current count is known.
+	Percentage bool
+
 	// GroupName is the name of the job group to scale in this request.
 	GroupName string
 
@@ -61,6 +75,10 @@ type GroupReq struct {
 	// Meta is the meta data which is optionally submitted when requesting a scaling activity for a
 	// job group. This is free-form and can contain any information the user deems relevant.
 	Meta map[string]string
+
+	// Identity is the identity of the API client which requested the scaling activity, taken
+	// from its verified TLS client certificate. This is empty for other scaling sources.
+	Identity string
 }
 
 type ScalingResponse struct {
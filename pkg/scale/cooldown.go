@@ -1,5 +1,17 @@
 package scale
 
+import "github.com/jrasell/sherpa/pkg/state"
+
+const (
+	// failureBackoffBase is the cooldown period in seconds applied after the first failed scaling
+	// event of a job group. Each consecutive failure doubles the period, up to failureBackoffMax.
+	failureBackoffBase = 30
+
+	// failureBackoffMax is the maximum cooldown period in seconds applied after consecutive failed
+	// scaling events of a job group.
+	failureBackoffMax = 3600
+)
+
 // JobGroupIsInCooldown satisfies the JobGroupIsInCooldown func within the Scale interface.
 func (s *Scaler) JobGroupIsInCooldown(job, group string, cooldown int, time int64) (bool, error) {
 
@@ -15,8 +27,30 @@ func (s *Scaler) JobGroupIsInCooldown(job, group string, cooldown int, time int6
 		return false, nil
 	}
 
-	if (time - int64(cooldown*1000000000)) < last.Time {
+	// If the last scaling event failed, back off exponentially so that a job group which cannot
+	// be scaled is not resubmitted to Nomad on every attempt.
+	if last.Status == state.StatusFailed {
+		if backoff := failureBackoff(last.Failures); backoff > cooldown {
+			cooldown = backoff
+		}
+	}
+
+	if (time - int64(cooldown)*1000000000) < last.Time {
 		return true, nil
 	}
 	return false, nil
 }
+
+// failureBackoff returns the cooldown period in seconds to apply after the number of consecutive
+// failed scaling events.
+func failureBackoff(failures int) int {
+	backoff := failureBackoffBase
+
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= failureBackoffMax {
+			return failureBackoffMax
+		}
+	}
+	return backoff
+}
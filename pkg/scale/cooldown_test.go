@@ -65,12 +65,48 @@ func TestScaler_JobGroupIsInCooldown(t *testing.T) {
 				Direction: "in",
 			},
 		},
+		{
+			inputJobName:         "test-job-1",
+			inputGroupName:       "test-group-1",
+			inputCoolDown:        60,
+			inputTime:            helper.GenerateEventTimestamp(),
+			expectedCooldownResp: true,
+			name:                 "job group backing off after consecutive failures",
+			lastScalingEvent: &state.ScalingEventMessage{
+				ID:        uuid.UUID{},
+				GroupName: "test-group-1",
+				Source:    "test",
+				Time:      helper.GenerateEventTimestamp() - 100000000000,
+				Status:    state.StatusFailed,
+				Count:     1,
+				Direction: "out",
+				Failures:  3,
+			},
+		},
+		{
+			inputJobName:         "test-job-1",
+			inputGroupName:       "test-group-1",
+			inputCoolDown:        60,
+			inputTime:            helper.GenerateEventTimestamp(),
+			expectedCooldownResp: false,
+			name:                 "job group failure backoff has passed",
+			lastScalingEvent: &state.ScalingEventMessage{
+				ID:        uuid.UUID{},
+				GroupName: "test-group-1",
+				Source:    "test",
+				Time:      helper.GenerateEventTimestamp() - 100000000000,
+				Status:    state.StatusFailed,
+				Count:     1,
+				Direction: "out",
+				Failures:  1,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 
 		// Create a new Scaler for each test, to ensure to conflicting resources.
-		sc := Scaler{logger: zerolog.Logger{}, nomadClient: nil, state: stateMemory.NewStateBackend(), strict: true}
+		sc := Scaler{logger: zerolog.Logger{}, nomad: nil, state: stateMemory.NewStateBackend(), strict: true}
 
 		// Write the last event to check against if this isn't nil, meaning we do not have one.
 		if tc.lastScalingEvent != nil {
@@ -82,3 +118,38 @@ func TestScaler_JobGroupIsInCooldown(t *testing.T) {
 		assert.Equal(t, tc.expectedCooldownResp, cooldown, tc.name)
 	}
 }
+
+func Test_failureBackoff(t *testing.T) {
+	testCases := []struct {
+		inputFailures  int
+		expectedOutput int
+	}{
+		{inputFailures: 1, expectedOutput: 30},
+		{inputFailures: 2, expectedOutput: 60},
+		{inputFailures: 4, expectedOutput: 240},
+		{inputFailures: 8, expectedOutput: 3600},
+		{inputFailures: 100, expectedOutput: 3600},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, failureBackoff(tc.inputFailures))
+	}
+}
+
+func TestScaler_consecutiveFailures(t *testing.T) {
+	sc := Scaler{logger: zerolog.Logger{}, state: stateMemory.NewStateBackend()}
+
+	assert.Equal(t, 0, sc.consecutiveFailures("test-job-1", "test-group-1", state.StatusCompleted))
+	assert.Equal(t, 1, sc.consecutiveFailures("test-job-1", "test-group-1", state.StatusFailed))
+
+	for i := 0; i < 2; i++ {
+		sc.sendScalingEventToState("test-job-1", "", 0, state.SourceAPI,
+			[]*GroupReq{{GroupName: "test-group-1", Direction: DirectionOut, Count: 1}}, state.StatusFailed, nil)
+	}
+
+	last, err := sc.state.GetLatestScalingEvent("test-job-1", "test-group-1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, last.Failures)
+	assert.Equal(t, 3, sc.consecutiveFailures("test-job-1", "test-group-1", state.StatusFailed))
+	assert.Equal(t, 0, sc.consecutiveFailures("test-job-1", "test-group-1", state.StatusCompleted))
+}
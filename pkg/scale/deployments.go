@@ -1,7 +1,8 @@
 package scale
 
 import (
-	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	deploymentWatcher "github.com/jrasell/sherpa/pkg/watcher/deployment"
 )
 
 // deploymentsKey is a composite key used for storing in-progress Nomad deployments.
@@ -39,16 +40,24 @@ func (s *Scaler) RunDeploymentUpdateHandler() {
 }
 
 func (s *Scaler) handleDeploymentMessage(msg interface{}) {
-	deployment, ok := msg.(*api.Deployment)
+	update, ok := msg.(*deploymentWatcher.Update)
 	if !ok {
 		s.logger.Error().Msg("received unexpected deployment update message type")
 		return
 	}
+	deployment := update.Deployment
+
 	s.logger.Debug().
 		Str("status", deployment.Status).
+		Str("region", update.Region).
+		Str("namespace", deployment.Namespace).
 		Str("job", deployment.JobID).
 		Msg("received deployment update message to handle")
 
+	// Deployments are tracked using the Sherpa job identifier, which includes the region and
+	// namespace of jobs outside the primary Nomad target and default namespace.
+	job := helper.RegionalJobID(update.Region, helper.NamespacedJobID(deployment.Namespace, deployment.JobID))
+
 	s.deploymentsLock.Lock()
 	defer s.deploymentsLock.Unlock()
 
@@ -57,7 +66,7 @@ func (s *Scaler) handleDeploymentMessage(msg interface{}) {
 		// If the deployment is running, then we need to ensure that this is correctly tracked in
 		// the scaler.
 		for tg := range deployment.TaskGroups {
-			s.deployments[deploymentsKey{job: deployment.JobID, group: tg}] = nil
+			s.deployments[deploymentsKey{job: job, group: tg}] = nil
 		}
 
 	default:
@@ -65,7 +74,7 @@ func (s *Scaler) handleDeploymentMessage(msg interface{}) {
 		// These result in the internal tracking of the deployment to be removed, indicating that
 		// the job group is not in deployment and can therefore be scaled.
 		for tg := range deployment.TaskGroups {
-			delete(s.deployments, deploymentsKey{job: deployment.JobID, group: tg})
+			delete(s.deployments, deploymentsKey{job: job, group: tg})
 		}
 	}
 }
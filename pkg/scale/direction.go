@@ -6,6 +6,10 @@ const (
 	DirectionIn   Direction = "in"
 	DirectionOut  Direction = "out"
 	DirectionNone Direction = "none"
+
+	// DirectionSet sets the job group to an absolute count, rather than changing the count by a
+	// delta.
+	DirectionSet Direction = "set"
 )
 
 func (d *Direction) String() string {
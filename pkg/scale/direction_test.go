@@ -14,6 +14,7 @@ func TestDirection_String(t *testing.T) {
 		{direction: DirectionOut, expectedResp: "out"},
 		{direction: DirectionIn, expectedResp: "in"},
 		{direction: DirectionNone, expectedResp: "none"},
+		{direction: DirectionSet, expectedResp: "set"},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,78 @@
+package scale
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/scale/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaler_DryRun(t *testing.T) {
+	job := api.NewServiceJob("example", "example", "global", 50)
+	job.AddTaskGroup(api.NewTaskGroup("cache", 3))
+
+	var registered bool
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			registered = true
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer ts.Close()
+
+	nomadClient, err := api.NewClient(&api.Config{Address: ts.URL})
+	assert.Nil(t, err)
+
+	stateBackend := memory.NewStateBackend()
+	scaler := NewScaler(client.NewNomadTargets(nomadClient, nil), zerolog.Nop(), stateBackend, true, nil, nil, nil)
+
+	req := &GroupReq{
+		Direction:          DirectionOut,
+		Count:              2,
+		GroupName:          "cache",
+		GroupScalingPolicy: &policy.GroupScalingPolicy{Enabled: true, DryRun: true, MinCount: 1, MaxCount: 10},
+		Time:               1572858000000000000,
+		Meta:               map[string]string{"nomad-cpu-value": "92.00", "nomad-cpu-threshold": "80.00"},
+	}
+
+	resp, code, err := scaler.DryRun("example", []*GroupReq{req}, state.SourceInternalAutoscaler)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.False(t, registered)
+
+	events, err := stateBackend.GetScalingEvent(resp.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*state.ScalingEvent{
+		"example:cache": {
+			ID:      resp.ID,
+			Source:  state.SourceInternalAutoscaler,
+			Time:    req.Time,
+			Status:  state.StatusDryRun,
+			Details: state.EventDetails{Count: 2, Direction: "out"},
+			Meta:    req.Meta,
+		},
+	}, events)
+
+	// The dry-run should not affect the job group cooldown.
+	latest, err := stateBackend.GetLatestScalingEvent("example", "cache")
+	assert.Nil(t, err)
+	assert.Nil(t, latest)
+
+	// Checks are still performed during a dry-run.
+	req.Count = 10
+	resp, code, err = scaler.DryRun("example", []*GroupReq{req}, state.SourceInternalAutoscaler)
+	assert.EqualError(t, err, "scaling action will break job group maximum threshold")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Nil(t, resp)
+}
@@ -2,29 +2,36 @@ package scale
 
 import (
 	"github.com/gofrs/uuid"
+	"github.com/jrasell/sherpa/pkg/notify"
 	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/stream"
 )
 
-func (s *Scaler) sendScalingEventToState(job, id string, source state.Source, groupReqs []*GroupReq, err error) uuid.UUID {
-
-	status := s.generateEventStatus(err)
-
-	scaleID, err := uuid.NewV4()
-	if err != nil {
-		s.logger.Error().Err(err).Msg("failed to generate scaling UUID")
+// sendScalingEventToState records the scaling event of each job group to the state, publishes each
+// to the event stream hub, and sends a notification of each to the notifiers routed by the job
+// group policy. The error is the cause of the event status, if any, and is included within the
+// notifications.
+func (s *Scaler) sendScalingEventToState(job, id string, nomadEventIndex uint64, source state.Source, groupReqs []*GroupReq,
+	status state.Status, err error) uuid.UUID {
+	scaleID, idErr := uuid.NewV4()
+	if idErr != nil {
+		s.logger.Error().Err(idErr).Msg("failed to generate scaling UUID")
 	}
 
 	for i := range groupReqs {
 		event := state.ScalingEventMessage{
-			ID:        scaleID,
-			EvalID:    id,
-			GroupName: groupReqs[i].GroupName,
-			Status:    status,
-			Source:    source,
-			Time:      groupReqs[i].Time,
-			Count:     groupReqs[i].Count,
-			Direction: groupReqs[i].Direction.String(),
-			Meta:      groupReqs[i].Meta,
+			ID:              scaleID,
+			EvalID:          id,
+			NomadEventIndex: nomadEventIndex,
+			GroupName:       groupReqs[i].GroupName,
+			Status:          status,
+			Source:          source,
+			Time:            groupReqs[i].Time,
+			Count:           groupReqs[i].Count,
+			Direction:       groupReqs[i].Direction.String(),
+			Failures:        s.consecutiveFailures(job, groupReqs[i].GroupName, status),
+			Meta:            groupReqs[i].Meta,
+			Identity:        groupReqs[i].Identity,
 		}
 
 		if err := s.state.PutScalingEvent(job, &event); err != nil {
@@ -33,11 +40,111 @@ func (s *Scaler) sendScalingEventToState(job, id string, source state.Source, gr
 				Str("group", event.GroupName).
 				Err(err).Msg("failed to update state with scaling event")
 		}
+
+		s.hub.Publish(&stream.Event{
+			Topic:   stream.TopicScaling,
+			Job:     job,
+			Group:   event.GroupName,
+			Source:  source.String(),
+			Payload: scalingEventFromMessage(&event),
+		})
+
+		s.notifier.Dispatch(&notify.Event{
+			ID:        scaleID.String(),
+			Job:       job,
+			Group:     event.GroupName,
+			Status:    status.String(),
+			Source:    source.String(),
+			Time:      event.Time,
+			Count:     event.Count,
+			Direction: event.Direction,
+			EvalID:    id,
+			Identity:  event.Identity,
+			Reason:    errorReason(err),
+			Meta:      event.Meta,
+		}, groupReqNotifiers(groupReqs[i]))
 	}
 
 	return scaleID
 }
 
+// notifySkipped sends a notification of each job group whose scaling request was rejected before
+// a scaling event could be recorded. Requests which were not rejected, but made no changes to
+// the job, are not notified.
+func (s *Scaler) notifySkipped(job string, groupReqs []*GroupReq, source state.Source, err error) {
+	if err == nil {
+		return
+	}
+
+	for i := range groupReqs {
+		s.notifier.Dispatch(&notify.Event{
+			Job:       job,
+			Group:     groupReqs[i].GroupName,
+			Status:    notify.StatusSkipped,
+			Source:    source.String(),
+			Time:      groupReqs[i].Time,
+			Count:     groupReqs[i].Count,
+			Direction: groupReqs[i].Direction.String(),
+			Identity:  groupReqs[i].Identity,
+			Reason:    err.Error(),
+			Meta:      groupReqs[i].Meta,
+		}, groupReqNotifiers(groupReqs[i]))
+	}
+}
+
+// scalingEventFromMessage returns the scaling event as it is recorded to the state.
+func scalingEventFromMessage(msg *state.ScalingEventMessage) *state.ScalingEvent {
+	return &state.ScalingEvent{
+		ID:              msg.ID,
+		EvalID:          msg.EvalID,
+		NomadEventIndex: msg.NomadEventIndex,
+		Source:          msg.Source,
+		Time:            msg.Time,
+		Status:          msg.Status,
+		Details:         state.EventDetails{Count: msg.Count, Direction: msg.Direction},
+		Failures:        msg.Failures,
+		Identity:        msg.Identity,
+		Meta:            msg.Meta,
+	}
+}
+
+// groupReqNotifiers returns the notifiers routed by the job group scaling policy.
+func groupReqNotifiers(req *GroupReq) []string {
+	if req.GroupScalingPolicy == nil {
+		return nil
+	}
+	return req.GroupScalingPolicy.Notifiers
+}
+
+func errorReason(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// consecutiveFailures returns the number of consecutive failed scaling events for the job group,
+// including the event being recorded with the passed status.
+func (s *Scaler) consecutiveFailures(job, group string, status state.Status) int {
+	if status != state.StatusFailed {
+		return 0
+	}
+
+	last, err := s.state.GetLatestScalingEvent(job, group)
+	if err != nil {
+		s.logger.Error().
+			Str("job", job).
+			Str("group", group).
+			Err(err).Msg("failed to get latest scaling event to track consecutive failures")
+		return 1
+	}
+
+	if last != nil && last.Status == state.StatusFailed {
+		return last.Failures + 1
+	}
+	return 1
+}
+
 func (s *Scaler) generateEventStatus(err error) state.Status {
 	switch err {
 	case nil:
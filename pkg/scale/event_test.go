@@ -1 +1,122 @@
 package scale
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/notify"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/scale/memory"
+	"github.com/jrasell/sherpa/pkg/stream"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testNotifier struct {
+	events chan *notify.Event
+}
+
+func (t *testNotifier) Notify(e *notify.Event) error {
+	t.events <- e
+	return nil
+}
+
+func TestScaler_notifications(t *testing.T) {
+	job := api.NewServiceJob("example", "example", "global", 50)
+	job.AddTaskGroup(api.NewTaskGroup("cache", 3))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer ts.Close()
+
+	nomadClient, err := api.NewClient(&api.Config{Address: ts.URL})
+	assert.Nil(t, err)
+
+	ops := &testNotifier{events: make(chan *notify.Event, 1)}
+	dispatcher, err := notify.NewDispatcher(zerolog.Nop(), map[string]notify.Notifier{"ops": ops}, nil)
+	assert.Nil(t, err)
+
+	scaler := NewScaler(client.NewNomadTargets(nomadClient, nil), zerolog.Nop(), memory.NewStateBackend(), true, nil, dispatcher, nil)
+
+	testCases := []struct {
+		policy         *policy.GroupScalingPolicy
+		expectedStatus string
+		expectedReason string
+		name           string
+	}{
+		{
+			policy:         &policy.GroupScalingPolicy{Enabled: true, MinCount: 1, MaxCount: 10, Notifiers: []string{"ops"}},
+			expectedStatus: state.StatusDryRun,
+			name:           "recorded scaling event",
+		},
+		{
+			policy:         &policy.GroupScalingPolicy{Enabled: false, Notifiers: []string{"ops"}},
+			expectedStatus: notify.StatusSkipped,
+			expectedReason: "job group scaling policy is currently disabled",
+			name:           "skipped scaling request",
+		},
+	}
+
+	for _, tc := range testCases {
+		req := &GroupReq{Direction: DirectionOut, Count: 2, GroupName: "cache", GroupScalingPolicy: tc.policy, Time: 1572858000000000000}
+		_, _, _ = scaler.DryRun("example", []*GroupReq{req}, state.SourceAPI)
+
+		select {
+		case e := <-ops.events:
+			assert.Equal(t, "example", e.Job, tc.name)
+			assert.Equal(t, "cache", e.Group, tc.name)
+			assert.Equal(t, tc.expectedStatus, e.Status, tc.name)
+			assert.Equal(t, tc.expectedReason, e.Reason, tc.name)
+			assert.Equal(t, 2, e.Count, tc.name)
+		case <-time.After(time.Second):
+			t.Fatalf("%s: notification not received", tc.name)
+		}
+	}
+}
+
+func TestScaler_streamEvents(t *testing.T) {
+	job := api.NewServiceJob("example", "example", "global", 50)
+	job.AddTaskGroup(api.NewTaskGroup("cache", 3))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer ts.Close()
+
+	nomadClient, err := api.NewClient(&api.Config{Address: ts.URL})
+	assert.Nil(t, err)
+
+	hub := stream.NewHub(zerolog.Nop())
+	sub, err := hub.Subscribe(&stream.Filter{})
+	assert.Nil(t, err)
+
+	scaler := NewScaler(client.NewNomadTargets(nomadClient, nil), zerolog.Nop(), memory.NewStateBackend(), true, nil, nil, hub)
+
+	pol := &policy.GroupScalingPolicy{Enabled: true, MinCount: 1, MaxCount: 10}
+	req := &GroupReq{Direction: DirectionOut, Count: 2, GroupName: "cache", GroupScalingPolicy: pol, Time: 1572858000000000000}
+
+	resp, _, err := scaler.DryRun("example", []*GroupReq{req}, state.SourceAPI)
+	assert.Nil(t, err)
+
+	select {
+	case e := <-sub.Events():
+		assert.Equal(t, stream.TopicScaling, e.Topic)
+		assert.Equal(t, "example", e.Job)
+		assert.Equal(t, "cache", e.Group)
+		assert.Equal(t, state.SourceAPI.String(), e.Source)
+
+		event := e.Payload.(*state.ScalingEvent)
+		assert.Equal(t, resp.ID, event.ID)
+		assert.Equal(t, state.Status(state.StatusDryRun), event.Status)
+		assert.Equal(t, state.EventDetails{Count: 2, Direction: "out"}, event.Details)
+	default:
+		t.Fatal("scaling event not published")
+	}
+}
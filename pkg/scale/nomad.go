@@ -0,0 +1,196 @@
+package scale
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/state"
+)
+
+// nomadScaleMessage is the message included within scaling events recorded by Nomad.
+const nomadScaleMessage = "job group scaled by Sherpa"
+
+// nomadScalingRequest is the request body of the Nomad job scale API. The vendored Nomad API
+// client predates this endpoint, and so the request is performed using raw API calls.
+type nomadScalingRequest struct {
+	Count   *int64
+	Target  map[string]string
+	Message string
+	Meta    map[string]interface{}
+}
+
+// nomadJobScaleStatus is the response of the Nomad job scale status API.
+type nomadJobScaleStatus struct {
+	JobID      string
+	TaskGroups map[string]*nomadTaskGroupScaleStatus
+}
+
+// nomadTaskGroupScaleStatus is the scale status of a single job group, including the scaling
+// events Nomad has recorded.
+type nomadTaskGroupScaleStatus struct {
+	Events []*nomadScalingEvent
+}
+
+// nomadScalingEvent is a scaling event recorded by Nomad. Nomad does not assign scaling events an
+// ID, and so they are identified by the index at which they were created.
+type nomadScalingEvent struct {
+	EvalID      *string
+	CreateIndex uint64
+}
+
+// nomadScaleUnsupportedTTL is the period after which Nomad regions found not to support the job
+// scale API are probed again, so that the API is used once the Nomad servers are upgraded.
+const nomadScaleUnsupportedTTL = 10 * time.Minute
+
+// nomadScaleSupport tracks whether the Nomad servers of each region support the job scale API.
+// The result is determined on first use; support is cached for the life of the scaler, whereas a
+// lack of support is cached for nomadScaleUnsupportedTTL.
+type nomadScaleSupport struct {
+	regions map[string]nomadScaleRegion
+	sync.Mutex
+}
+
+// nomadScaleRegion is the cached job scale API support of a Nomad region.
+type nomadScaleRegion struct {
+	supported bool
+	checked   time.Time
+}
+
+// submitJob submits the scaled job to Nomad. Single job group requests use the Nomad job scale
+// API if the Nomad servers support it, so that only the group count is changed. Otherwise the job
+// is registered enforcing the job modify index read by the scaler, so that any changes made to the
+// job since it was read are not overwritten. The returned index identifies the scaling event
+// recorded by Nomad, and is zero if the job was registered.
+func (s *Scaler) submitJob(jobID string, job *api.Job, groupReqs []*GroupReq, source state.Source) (*api.JobRegisterResponse, uint64, error) {
+	nomad, ns, _, err := s.nomad.Job(jobID)
+	if err != nil {
+		return nil, 0, err
+	}
+	region, _ := helper.SplitRegionalJobID(jobID)
+
+	if len(groupReqs) == 1 && s.nomadScaleSupported(nomad, region, ns, *job.ID) {
+		tg := s.checkJobGroupExists(job, groupReqs[0].GroupName)
+		return s.triggerNomadScale(nomad, ns, *job.ID, tg, groupReqs[0], source)
+	}
+
+	resp, err := s.triggerNomadRegister(nomad, ns, job)
+	return resp, 0, err
+}
+
+// triggerNomadScale is used to change the job group count using the Nomad job scale API.
+func (s *Scaler) triggerNomadScale(nomad *api.Client, ns, jobID string, tg *api.TaskGroup, req *GroupReq, source state.Source) (*api.JobRegisterResponse, uint64, error) {
+	count := int64(*tg.Count)
+
+	meta := map[string]interface{}{"source": source.String()}
+	if req.Identity != "" {
+		meta["identity"] = req.Identity
+	}
+	for k, v := range req.Meta {
+		meta[k] = v
+	}
+
+	scaleReq := &nomadScalingRequest{
+		Count:   &count,
+		Target:  map[string]string{"Job": jobID, "Group": req.GroupName},
+		Message: nomadScaleMessage,
+		Meta:    meta,
+	}
+
+	var resp api.JobRegisterResponse
+
+	w := &api.WriteOptions{Namespace: ns}
+
+	if _, err := nomad.Raw().Write(nomadScaleEndpoint(jobID), scaleReq, &resp, w); err != nil {
+		return nil, 0, err
+	}
+	return &resp, s.nomadScalingEventIndex(nomad, ns, jobID, req.GroupName, resp.EvalID), nil
+}
+
+// triggerNomadRegister is used to submit the updated job to the Nomad API, enforcing the job
+// modify index.
+func (s *Scaler) triggerNomadRegister(nomad *api.Client, ns string, job *api.Job) (*api.JobRegisterResponse, error) {
+	var index uint64
+	if job.JobModifyIndex != nil {
+		index = *job.JobModifyIndex
+	}
+
+	resp, _, err := nomad.Jobs().EnforceRegister(job, index, &api.WriteOptions{Namespace: ns})
+	return resp, err
+}
+
+// nomadScaleSupported identifies whether the Nomad servers of the region support the job scale
+// API. Servers which do not support the API return a 404 when reading the scale status of a job
+// which exists.
+func (s *Scaler) nomadScaleSupported(nomad *api.Client, region, ns, jobID string) bool {
+	s.nomadScale.Lock()
+	defer s.nomadScale.Unlock()
+
+	if r, ok := s.nomadScale.regions[region]; ok && (r.supported || time.Since(r.checked) < nomadScaleUnsupportedTTL) {
+		return r.supported
+	}
+
+	var status nomadJobScaleStatus
+
+	_, err := nomad.Raw().Query(nomadScaleEndpoint(jobID), &status, &api.QueryOptions{Namespace: ns})
+	if err != nil && !strings.Contains(err.Error(), "404") {
+		s.logger.Error().Err(err).Msg("failed to determine whether Nomad supports the job scale API")
+		return false
+	}
+
+	if s.nomadScale.regions == nil {
+		s.nomadScale.regions = make(map[string]nomadScaleRegion)
+	}
+	s.nomadScale.regions[region] = nomadScaleRegion{supported: err == nil, checked: time.Now()}
+
+	s.logger.Info().
+		Str("region", region).
+		Bool("supported", err == nil).
+		Msg("determined Nomad job scale API support")
+	return err == nil
+}
+
+// nomadScalingEventIndex finds the index of the scaling event Nomad recorded for the job group
+// which created the evaluation. Zero is returned if the event could not be found.
+func (s *Scaler) nomadScalingEventIndex(nomad *api.Client, ns, jobID, group, evalID string) uint64 {
+	var status nomadJobScaleStatus
+
+	q := &api.QueryOptions{Namespace: ns}
+
+	if _, err := nomad.Raw().Query(nomadScaleEndpoint(jobID), &status, q); err != nil {
+		s.logger.Error().
+			Str("namespace", ns).
+			Str("job", jobID).
+			Str("group", group).
+			Err(err).
+			Msg("failed to read Nomad job scale status")
+		return 0
+	}
+	return findNomadScalingEventIndex(&status, group, evalID)
+}
+
+// findNomadScalingEventIndex returns the index of the job group scaling event which created the
+// evaluation, or zero if it is not found.
+func findNomadScalingEventIndex(status *nomadJobScaleStatus, group, evalID string) uint64 {
+	tg, ok := status.TaskGroups[group]
+	if !ok || evalID == "" {
+		return 0
+	}
+
+	for _, event := range tg.Events {
+		if event.EvalID != nil && *event.EvalID == evalID {
+			return event.CreateIndex
+		}
+	}
+	return 0
+}
+
+// nomadScaleEndpoint returns the path of the Nomad job scale API for the job. The job ID is escaped
+// as Nomad job IDs may contain characters which are not valid within a URL path segment.
+func nomadScaleEndpoint(jobID string) string {
+	return fmt.Sprintf("/v1/job/%s/scale", url.PathEscape(jobID))
+}
@@ -0,0 +1,190 @@
+package scale
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/scale/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNomad is a minimal Nomad API used to test how scaled jobs are submitted.
+type fakeNomad struct {
+	job            *api.Job
+	scaleSupported bool
+
+	scaleReq    *nomadScalingRequest
+	registerReq *api.RegisterJobRequest
+}
+
+func (f *fakeNomad) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/v1/job/example" && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(f.job)
+
+	case r.URL.Path == "/v1/job/example/scale" && !f.scaleSupported:
+		w.WriteHeader(http.StatusNotFound)
+
+	case r.URL.Path == "/v1/job/example/scale" && r.Method == http.MethodGet:
+		evalID := "scale-eval"
+		_ = json.NewEncoder(w).Encode(&nomadJobScaleStatus{
+			JobID: "example",
+			TaskGroups: map[string]*nomadTaskGroupScaleStatus{
+				"cache": {Events: []*nomadScalingEvent{{EvalID: &evalID, CreateIndex: 99}}},
+			},
+		})
+
+	case r.URL.Path == "/v1/job/example/scale":
+		f.scaleReq = &nomadScalingRequest{}
+		_ = json.NewDecoder(r.Body).Decode(f.scaleReq)
+		_ = json.NewEncoder(w).Encode(&api.JobRegisterResponse{EvalID: "scale-eval"})
+
+	case r.URL.Path == "/v1/jobs":
+		f.registerReq = &api.RegisterJobRequest{}
+		_ = json.NewDecoder(r.Body).Decode(f.registerReq)
+		_ = json.NewEncoder(w).Encode(&api.JobRegisterResponse{EvalID: "register-eval"})
+
+	default:
+		_ = json.NewEncoder(w).Encode(&api.Evaluation{Status: evalStatusComplete})
+	}
+}
+
+func TestScaler_submitJob(t *testing.T) {
+	testCases := []struct {
+		scaleSupported     bool
+		groups             []string
+		expectedNative     bool
+		expectedEvalID     string
+		expectedEventIndex uint64
+		name               string
+	}{
+		{
+			scaleSupported:     true,
+			groups:             []string{"cache"},
+			expectedNative:     true,
+			expectedEvalID:     "scale-eval",
+			expectedEventIndex: 99,
+			name:               "single group scaled using the job scale API",
+		},
+		{
+			scaleSupported: true,
+			groups:         []string{"cache", "web"},
+			expectedEvalID: "register-eval",
+			name:           "multiple groups registered enforcing the modify index",
+		},
+		{
+			scaleSupported: false,
+			groups:         []string{"cache"},
+			expectedEvalID: "register-eval",
+			name:           "job scale API not supported by Nomad",
+		},
+	}
+
+	for _, tc := range testCases {
+		job := api.NewServiceJob("example", "example", "global", 50)
+		job.AddTaskGroup(api.NewTaskGroup("cache", 3))
+		job.AddTaskGroup(api.NewTaskGroup("web", 3))
+		modifyIndex := uint64(42)
+		job.JobModifyIndex = &modifyIndex
+
+		nomad := &fakeNomad{job: job, scaleSupported: tc.scaleSupported}
+		ts := httptest.NewServer(nomad)
+
+		nomadClient, err := api.NewClient(&api.Config{Address: ts.URL})
+		assert.Nil(t, err, tc.name)
+
+		stateBackend := memory.NewStateBackend()
+		scaler := NewScaler(client.NewNomadTargets(nomadClient, nil), zerolog.Nop(), stateBackend, true, nil, nil, nil)
+
+		var reqs []*GroupReq
+		for _, group := range tc.groups {
+			reqs = append(reqs, &GroupReq{
+				Direction:          DirectionOut,
+				Count:              2,
+				GroupName:          group,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{Enabled: true, MinCount: 1, MaxCount: 10},
+				Time:               helper.GenerateEventTimestamp(),
+			})
+		}
+
+		resp, code, err := scaler.Trigger("example", reqs, state.SourceAPI)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, http.StatusOK, code, tc.name)
+		assert.Equal(t, tc.expectedEvalID, resp.EvaluationID, tc.name)
+
+		if tc.expectedNative {
+			assert.Nil(t, nomad.registerReq, tc.name)
+			assert.Equal(t, int64(5), *nomad.scaleReq.Count, tc.name)
+			assert.Equal(t, map[string]string{"Job": "example", "Group": "cache"}, nomad.scaleReq.Target, tc.name)
+		} else {
+			assert.Nil(t, nomad.scaleReq, tc.name)
+			assert.True(t, nomad.registerReq.EnforceIndex, tc.name)
+			assert.Equal(t, uint64(42), nomad.registerReq.JobModifyIndex, tc.name)
+		}
+
+		events, err := stateBackend.GetScalingEvent(resp.ID)
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedEventIndex, events["example:cache"].NomadEventIndex, tc.name)
+
+		ts.Close()
+	}
+}
+
+func Test_findNomadScalingEventIndex(t *testing.T) {
+	evalID1, evalID2 := "eval-1", "eval-2"
+
+	status := &nomadJobScaleStatus{
+		TaskGroups: map[string]*nomadTaskGroupScaleStatus{
+			"cache": {Events: []*nomadScalingEvent{
+				{EvalID: &evalID2, CreateIndex: 20},
+				{CreateIndex: 15},
+				{EvalID: &evalID1, CreateIndex: 10},
+			}},
+		},
+	}
+
+	assert.Equal(t, uint64(10), findNomadScalingEventIndex(status, "cache", "eval-1"))
+	assert.Equal(t, uint64(20), findNomadScalingEventIndex(status, "cache", "eval-2"))
+	assert.Equal(t, uint64(0), findNomadScalingEventIndex(status, "cache", "eval-3"))
+	assert.Equal(t, uint64(0), findNomadScalingEventIndex(status, "web", "eval-1"))
+}
+
+func TestScaler_nomadScaleSupported(t *testing.T) {
+	nomad := &fakeNomad{}
+	ts := httptest.NewServer(nomad)
+	defer ts.Close()
+
+	nomadClient, err := api.NewClient(&api.Config{Address: ts.URL})
+	assert.Nil(t, err)
+
+	scaler := NewScaler(client.NewNomadTargets(nomadClient, nil), zerolog.Nop(), nil, true, nil, nil, nil).(*Scaler)
+	assert.False(t, scaler.nomadScaleSupported(nomadClient, "", "", "example"))
+
+	// A lack of support is cached until the TTL expires, after which the region is probed again.
+	nomad.scaleSupported = true
+	assert.False(t, scaler.nomadScaleSupported(nomadClient, "", "", "example"))
+
+	r := scaler.nomadScale.regions[""]
+	r.checked = r.checked.Add(-nomadScaleUnsupportedTTL - time.Second)
+	scaler.nomadScale.regions[""] = r
+	assert.True(t, scaler.nomadScaleSupported(nomadClient, "", "", "example"))
+
+	// Support is cached for the life of the scaler.
+	nomad.scaleSupported = false
+	assert.True(t, scaler.nomadScaleSupported(nomadClient, "", "", "example"))
+}
+
+func Test_nomadScaleEndpoint(t *testing.T) {
+	assert.Equal(t, "/v1/job/example/scale", nomadScaleEndpoint("example"))
+	assert.Equal(t, "/v1/job/batch%2Fperiodic-1700000000/scale", nomadScaleEndpoint("batch/periodic-1700000000"))
+	assert.Equal(t, "/v1/job/web%3Fv=2%23a/scale", nomadScaleEndpoint("web?v=2#a"))
+}
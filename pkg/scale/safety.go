@@ -0,0 +1,147 @@
+package scale
+
+import (
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/pkg/errors"
+)
+
+// taskStateRunning is the Nomad task state of a running task. The Nomad API does not currently
+// export this value.
+const taskStateRunning = "running"
+
+// allocHealth is a summary of the health of the allocations belonging to a job group.
+type allocHealth struct {
+	healthy   int
+	unhealthy int
+	pending   int
+}
+
+// checkScaleInSafety performs the scale in safety checks configured within the job group scaling
+// policy. Any request which reduces the group count is checked, whether it is a scale in, set or
+// absolute request, and the returned error details the check which blocked the request.
+func (s *Scaler) checkScaleInSafety(jobID string, tg *api.TaskGroup, newCount int, req *GroupReq) error {
+	if req.GroupScalingPolicy == nil || req.GroupScalingPolicy.ScaleInSafety == nil {
+		return nil
+	}
+
+	removing := *tg.Count - newCount
+	if removing <= 0 {
+		return nil
+	}
+
+	safety := req.GroupScalingPolicy.ScaleInSafety
+
+	if safety.RequiresAllocations() {
+		nomad, ns, nomadJobID, err := s.nomad.Job(jobID)
+		if err != nil {
+			return err
+		}
+
+		allocs, _, err := nomad.Jobs().Allocations(nomadJobID, false, &api.QueryOptions{Namespace: ns})
+		if err != nil {
+			return errors.Wrap(err, "failed to list job allocations for scale in safety checks")
+		}
+		health := groupAllocHealth(allocs, req.GroupName)
+
+		if safety.BlockWhileUnhealthy && (health.unhealthy > 0 || health.pending > 0) {
+			return errors.Errorf("scaling action blocked as job group has %v unhealthy and %v pending allocations",
+				health.unhealthy, health.pending)
+		}
+		if health.healthy-removing < safety.MinHealthyAllocs {
+			return errors.Errorf("scaling action will break job group minimum healthy allocations of %v",
+				safety.MinHealthyAllocs)
+		}
+	}
+
+	if safety.MaxPercentage > 0 {
+		t := req.Time
+		if t == 0 {
+			t = helper.GenerateEventTimestamp()
+		}
+
+		removed, err := s.recentScaleInCount(jobID, req.GroupName, t-int64(safety.WindowOrDefault())*1000000000)
+		if err != nil {
+			return errors.Wrap(err, "failed to read scaling events for scale in safety checks")
+		}
+
+		// The group count at the start of the window is used as the base for the percentage, so
+		// that a series of small scale in actions cannot remove more than the configured amount.
+		if !scaleInWithinRate(*tg.Count+removed, removed+removing, safety.MaxPercentage) {
+			return errors.Errorf("scaling action will remove more than %v%% of job group count within %vs",
+				safety.MaxPercentage, safety.WindowOrDefault())
+		}
+	}
+	return nil
+}
+
+// recentScaleInCount returns the number by which the job group has been scaled in by completed
+// scaling events since the passed UnixNano timestamp. Scaling events record the effective change
+// to the group count, so set and absolute requests which reduced the count are included.
+func (s *Scaler) recentScaleInCount(job, group string, since int64) (int, error) {
+	events, err := s.state.GetScalingEvents()
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+
+	for _, groups := range events {
+		event, ok := groups[job+":"+group]
+		if !ok || event.Time < since || event.Status != state.StatusCompleted {
+			continue
+		}
+		if event.Details.Direction == string(DirectionIn) {
+			removed += event.Details.Count
+		}
+	}
+	return removed, nil
+}
+
+// scaleInWithinRate identifies whether removing the count from the base job group count is within
+// the maximum percentage.
+func scaleInWithinRate(base, count, maxPercentage int) bool {
+	return count*100 <= base*maxPercentage
+}
+
+// groupAllocHealth summarises the health of the allocations of the job group. Allocations which
+// Nomad does not desire to be running, such as those being stopped, are not included.
+func groupAllocHealth(allocs []*api.AllocationListStub, group string) allocHealth {
+	var health allocHealth
+
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != group || alloc.DesiredStatus != api.AllocDesiredStatusRun {
+			continue
+		}
+
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusPending:
+			health.pending++
+		case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+			health.unhealthy++
+		case api.AllocClientStatusRunning:
+			if allocIsUnhealthy(alloc) {
+				health.unhealthy++
+			} else {
+				health.healthy++
+			}
+		}
+	}
+	return health
+}
+
+// allocIsUnhealthy identifies whether a running allocation has been marked unhealthy by its
+// deployment, or has a task which is not running.
+func allocIsUnhealthy(alloc *api.AllocationListStub) bool {
+	if alloc.DeploymentStatus != nil && alloc.DeploymentStatus.Healthy != nil && !*alloc.DeploymentStatus.Healthy {
+		return true
+	}
+
+	for _, task := range alloc.TaskStates {
+		if task.State != taskStateRunning {
+			return true
+		}
+	}
+	return false
+}
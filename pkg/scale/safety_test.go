@@ -0,0 +1,130 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/state"
+	stateMemory "github.com/jrasell/sherpa/pkg/state/scale/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_groupAllocHealth(t *testing.T) {
+	healthy := true
+	unhealthy := false
+
+	allocs := []*api.AllocationListStub{
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning,
+			TaskStates: map[string]*api.TaskState{"redis": {State: "running"}}},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning,
+			DeploymentStatus: &api.AllocDeploymentStatus{Healthy: &healthy}},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning,
+			DeploymentStatus: &api.AllocDeploymentStatus{Healthy: &unhealthy}},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning,
+			TaskStates: map[string]*api.TaskState{"redis": {State: "pending"}}},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusPending},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusLost},
+		{TaskGroup: "cache", DesiredStatus: api.AllocDesiredStatusStop, ClientStatus: api.AllocClientStatusFailed},
+		{TaskGroup: "web", DesiredStatus: api.AllocDesiredStatusRun, ClientStatus: api.AllocClientStatusRunning},
+	}
+
+	assert.Equal(t, allocHealth{healthy: 2, unhealthy: 3, pending: 1}, groupAllocHealth(allocs, "cache"))
+	assert.Equal(t, allocHealth{healthy: 1}, groupAllocHealth(allocs, "web"))
+}
+
+func Test_scaleInWithinRate(t *testing.T) {
+	testCases := []struct {
+		base, count, maxPercentage int
+		expectedOutput             bool
+	}{
+		{base: 10, count: 2, maxPercentage: 20, expectedOutput: true},
+		{base: 10, count: 3, maxPercentage: 20, expectedOutput: false},
+		{base: 3, count: 1, maxPercentage: 50, expectedOutput: true},
+		{base: 3, count: 2, maxPercentage: 50, expectedOutput: false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, scaleInWithinRate(tc.base, tc.count, tc.maxPercentage))
+	}
+}
+
+func TestScaler_checkScaleInSafety(t *testing.T) {
+	stateBackend := stateMemory.NewStateBackend()
+	scaler := NewScaler(nil, zerolog.Logger{}, stateBackend, true, nil, nil, nil)
+
+	now := helper.GenerateEventTimestamp()
+
+	events := []*state.ScalingEventMessage{
+		{GroupName: "cache", Status: state.StatusCompleted, Time: now - 600000000000, Count: 1, Direction: "in"},
+		{GroupName: "cache", Status: state.StatusCompleted, Time: now - 7200000000000, Count: 4, Direction: "in"},
+		{GroupName: "cache", Status: state.StatusFailed, Time: now - 300000000000, Count: 4, Direction: "in"},
+		{GroupName: "cache", Status: state.StatusCompleted, Time: now - 200000000000, Count: 5, Direction: "out"},
+	}
+	for _, event := range events {
+		event.ID = uuid.Must(uuid.NewV4())
+		assert.Nil(t, stateBackend.PutScalingEvent("example", event))
+	}
+
+	removed, err := scaler.(*Scaler).recentScaleInCount("example", "cache", now-3600000000000)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, removed)
+
+	pol := &policy.GroupScalingPolicy{ScaleInSafety: &policy.ScaleInSafety{MaxPercentage: 25}}
+
+	testCases := []struct {
+		newCount    int
+		groupReq    *GroupReq
+		expectedErr string
+		name        string
+	}{
+		{
+			newCount:    9,
+			groupReq:    &GroupReq{Direction: DirectionIn, GroupName: "cache", GroupScalingPolicy: pol, Time: now},
+			expectedErr: "",
+			name:        "scale in within rate",
+		},
+		{
+			newCount:    8,
+			groupReq:    &GroupReq{Direction: DirectionIn, GroupName: "cache", GroupScalingPolicy: pol, Time: now},
+			expectedErr: "scaling action will remove more than 25% of job group count within 3600s",
+			name:        "scale in breaks rate",
+		},
+		{
+			newCount:    2,
+			groupReq:    &GroupReq{Direction: DirectionSet, GroupName: "cache", GroupScalingPolicy: pol, Time: now},
+			expectedErr: "scaling action will remove more than 25% of job group count within 3600s",
+			name:        "set scale in breaks rate",
+		},
+		{
+			newCount:    2,
+			groupReq:    &GroupReq{Direction: DirectionOut, Absolute: true, Count: 2, GroupName: "cache", GroupScalingPolicy: pol, Time: now},
+			expectedErr: "scaling action will remove more than 25% of job group count within 3600s",
+			name:        "absolute scale in breaks rate",
+		},
+		{
+			newCount:    12,
+			groupReq:    &GroupReq{Direction: DirectionSet, GroupName: "cache", GroupScalingPolicy: pol, Time: now},
+			expectedErr: "",
+			name:        "set scale out is not checked",
+		},
+		{
+			newCount:    2,
+			groupReq:    &GroupReq{Direction: DirectionIn, GroupName: "cache", GroupScalingPolicy: &policy.GroupScalingPolicy{}},
+			expectedErr: "",
+			name:        "policy without scale in safety",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := scaler.(*Scaler).checkScaleInSafety("example", api.NewTaskGroup("cache", 10), tc.newCount, tc.groupReq)
+		if tc.expectedErr == "" {
+			assert.Nil(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedErr, tc.name)
+		}
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"sync"
 
 	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/notify"
 	"github.com/jrasell/sherpa/pkg/state"
 	"github.com/jrasell/sherpa/pkg/state/scale"
+	"github.com/jrasell/sherpa/pkg/stream"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -15,10 +18,20 @@ import (
 var _ Scale = (*Scaler)(nil)
 
 type Scaler struct {
-	logger      zerolog.Logger
-	nomadClient *api.Client
-	state       scale.Backend
-	strict      bool
+	logger     zerolog.Logger
+	nomad      *client.NomadTargets
+	state      scale.Backend
+	strict     bool
+	capacity   *CapacityConfig
+	nomadScale nomadScaleSupport
+
+	// notifier sends notifications of scaling events and skipped scaling requests. This is nil if
+	// no notifiers are configured.
+	notifier *notify.Dispatcher
+
+	// hub is used to publish recorded scaling events to event stream subscribers. This is nil if
+	// events are not streamed.
+	hub *stream.Hub
 
 	deployments          map[deploymentsKey]interface{}
 	deploymentsLock      sync.RWMutex
@@ -27,12 +40,19 @@ type Scaler struct {
 	shutdownChan chan interface{}
 }
 
-func NewScaler(c *api.Client, l zerolog.Logger, state scale.Backend, strictChecking bool) Scale {
+// NewScaler returns a new Scaler. The capacity config is optional, and when nil the Nomad cluster
+// capacity is not checked before scaling out. The notifier and hub are also optional, and when nil
+// no scaling notifications are sent and no scaling events are streamed.
+func NewScaler(c *client.NomadTargets, l zerolog.Logger, state scale.Backend, strictChecking bool,
+	capacity *CapacityConfig, notifier *notify.Dispatcher, hub *stream.Hub) Scale {
 	return &Scaler{
 		logger:               l,
-		nomadClient:          c,
+		nomad:                c,
 		state:                state,
 		strict:               strictChecking,
+		capacity:             capacity,
+		notifier:             notifier,
+		hub:                  hub,
 		deployments:          make(map[deploymentsKey]interface{}),
 		deploymentUpdateChan: make(chan interface{}),
 	}
@@ -45,6 +65,42 @@ func NewScaler(c *api.Client, l zerolog.Logger, state scale.Backend, strictCheck
 //		- the HTTP return code, used for the Sherpa API
 //		- any error
 func (s *Scaler) Trigger(jobID string, groupReqs []*GroupReq, source state.Source) (*ScalingResponse, int, error) {
+	job, code, err := s.prepareJob(jobID, groupReqs)
+	if job == nil {
+		s.notifySkipped(jobID, groupReqs, source, err)
+		return nil, code, err
+	}
+
+	// Cap any scale out requests to the free capacity of the Nomad cluster. If this leaves no
+	// changes, the job is not submitted as the new allocations would only be blocked.
+	if s.capacity != nil && s.capacity.Enabled {
+		if groupReqs = s.applyClusterCapacity(jobID, job, groupReqs, source); len(groupReqs) == 0 {
+			return nil, http.StatusConflict, errors.New("insufficient cluster capacity to scale job")
+		}
+	}
+
+	resp, index, err := s.submitJob(jobID, job, groupReqs, source)
+
+	return s.handleEndState(jobID, resp, index, err, groupReqs, source)
+}
+
+// DryRun performs the same checks as Trigger, but rather than submitting the updated job to Nomad,
+// the scaling event is recorded to the state with a DryRun status.
+func (s *Scaler) DryRun(jobID string, groupReqs []*GroupReq, source state.Source) (*ScalingResponse, int, error) {
+	job, code, err := s.prepareJob(jobID, groupReqs)
+	if job == nil {
+		s.notifySkipped(jobID, groupReqs, source, err)
+		return nil, code, err
+	}
+
+	scaleID := s.sendScalingEventToState(jobID, "", 0, source, groupReqs, state.StatusDryRun, nil)
+	return &ScalingResponse{ID: scaleID}, http.StatusOK, nil
+}
+
+// prepareJob reads the job from Nomad and applies the group scaling requests to it. If the job is
+// nil, the scaling should not continue and the HTTP return code and error should be returned to
+// the caller.
+func (s *Scaler) prepareJob(jobID string, groupReqs []*GroupReq) (*api.Job, int, error) {
 
 	// In order to submit a job for scaling we need to read the entire job back to Nomad as it does
 	// not currently have convenience methods for changing job group counts.
@@ -60,9 +116,9 @@ func (s *Scaler) Trigger(jobID string, groupReqs []*GroupReq, source state.Sourc
 	var changes bool
 
 	if s.strict {
-		changes, err = s.triggerWithStrictChecking(job, groupReqs)
+		changes, err = s.triggerWithStrictChecking(jobID, job, groupReqs)
 	} else {
-		changes, err = s.triggerWithoutStrictChecking(job, groupReqs)
+		changes, err = s.triggerWithoutStrictChecking(jobID, job, groupReqs)
 	}
 
 	// The error returned is always an indication of a validation check. It will contain the
@@ -77,14 +133,11 @@ func (s *Scaler) Trigger(jobID string, groupReqs []*GroupReq, source state.Sourc
 	if !changes {
 		return nil, http.StatusNotModified, nil
 	}
-
-	resp, err := s.triggerNomadRegister(job)
-
-	return s.handleEndState(jobID, resp, err, groupReqs, source)
+	return job, http.StatusOK, nil
 }
 
-func (s *Scaler) handleEndState(job string, apiResp *api.JobRegisterResponse, apiErr error, groupReqs []*GroupReq,
-	source state.Source) (*ScalingResponse, int, error) {
+func (s *Scaler) handleEndState(job string, apiResp *api.JobRegisterResponse, nomadEventIndex uint64, apiErr error,
+	groupReqs []*GroupReq, source state.Source) (*ScalingResponse, int, error) {
 
 	eval := ""
 
@@ -92,15 +145,30 @@ func (s *Scaler) handleEndState(job string, apiResp *api.JobRegisterResponse, ap
 		eval = apiResp.EvalID
 	}
 
-	scaleID := s.sendScalingEventToState(job, eval, source, groupReqs, apiErr)
+	scaleID := s.sendScalingEventToState(job, eval, nomadEventIndex, source, groupReqs, s.generateEventStatus(apiErr), apiErr)
 
 	if apiErr != nil {
 		return nil, http.StatusInternalServerError, apiErr
 	}
+
+	// Follow the Nomad evaluation in the background so the caller is not delayed while Nomad
+	// schedules the changes.
+	if eval != "" {
+		go s.verifyScalingEvent(job, scaleID, eval, groupReqNames(groupReqs))
+	}
 	return &ScalingResponse{ID: scaleID, EvaluationID: eval}, http.StatusOK, nil
 }
 
-func (s *Scaler) triggerWithStrictChecking(job *api.Job, groupReqs []*GroupReq) (bool, error) {
+// groupReqNames returns the job group names of the scaling requests.
+func groupReqNames(groupReqs []*GroupReq) []string {
+	names := make([]string, len(groupReqs))
+	for i := range groupReqs {
+		names[i] = groupReqs[i].GroupName
+	}
+	return names
+}
+
+func (s *Scaler) triggerWithStrictChecking(jobID string, job *api.Job, groupReqs []*GroupReq) (bool, error) {
 	var changes bool
 
 	for i := range groupReqs {
@@ -134,8 +202,24 @@ func (s *Scaler) triggerWithStrictChecking(job *api.Job, groupReqs []*GroupReq)
 			return changes, err
 		}
 
+		if err := s.checkScaleInSafety(jobID, tg, newCount, groupReqs[i]); err != nil {
+			s.logger.Info().
+				Str("job", *job.ID).
+				Str("group", groupReqs[i].GroupName).
+				Err(err).
+				Msg("job group scale in blocked by safety checks")
+			return changes, err
+		}
+
+		// Setting the group to its current count is not a change, and so the group does not need
+		// to be included in the job submission.
+		if newCount == *tg.Count {
+			continue
+		}
+
 		// Once the check is completed, update the job group count and ensure changes are marked as
 		// true.
+		recordGroupChange(*tg.Count, newCount, groupReqs[i])
 		*tg.Count = newCount
 		changes = true
 	}
@@ -143,7 +227,7 @@ func (s *Scaler) triggerWithStrictChecking(job *api.Job, groupReqs []*GroupReq)
 	return changes, nil
 }
 
-func (s *Scaler) triggerWithoutStrictChecking(job *api.Job, groupReqs []*GroupReq) (bool, error) {
+func (s *Scaler) triggerWithoutStrictChecking(jobID string, job *api.Job, groupReqs []*GroupReq) (bool, error) {
 	var changes bool
 
 	for i := range groupReqs {
@@ -152,18 +236,61 @@ func (s *Scaler) triggerWithoutStrictChecking(job *api.Job, groupReqs []*GroupRe
 			return changes, errors.New("job group not found on Nomad cluster")
 		}
 
+		// As we do not have strict checking, we can blindly update the task group count, unless the
+		// group is already running at the desired count.
+		newCount := s.getNewGroupCount(tg, groupReqs[i])
+		if newCount == *tg.Count {
+			continue
+		}
+
+		// Scale in safety is configured by the group scaling policy, and so is still honoured when
+		// the request includes the policy.
+		if err := s.checkScaleInSafety(jobID, tg, newCount, groupReqs[i]); err != nil {
+			s.logger.Info().
+				Str("job", *job.ID).
+				Str("group", groupReqs[i].GroupName).
+				Err(err).
+				Msg("job group scale in blocked by safety checks")
+			return changes, err
+		}
+
 		// Once we have confirmed the job group exists within the running Nomad job, we can assume
 		// there are changes to the job to submit to Nomad.
 		changes = true
-
-		// As we do not have strict checking, we can blindly update the task group count.
-		*tg.Count = s.getNewGroupCount(tg, groupReqs[i])
+		recordGroupChange(*tg.Count, newCount, groupReqs[i])
+		*tg.Count = newCount
 	}
 
 	return changes, nil
 }
 
+// getNewGroupCount calculates the desired count of the task group based on the request. Percentage
+// and absolute in or out requests are converted to the number by which to change the count, which
+// is written back to the request so that the scaling event records the actual change.
 func (s *Scaler) getNewGroupCount(taskGroup *api.TaskGroup, req *GroupReq) int {
+	if req.Direction == DirectionSet {
+		return req.Count
+	}
+
+	if req.Absolute {
+		newCount := req.Count
+
+		switch {
+		case req.Direction == DirectionIn && newCount <= *taskGroup.Count:
+			req.Count = *taskGroup.Count - newCount
+			req.Absolute = false
+		case req.Direction == DirectionOut && newCount >= *taskGroup.Count:
+			req.Count = newCount - *taskGroup.Count
+			req.Absolute = false
+		}
+		return newCount
+	}
+
+	if req.Percentage && req.GroupScalingPolicy != nil {
+		req.Count = req.GroupScalingPolicy.AdjustmentCount(*taskGroup.Count, req.Count)
+		req.Percentage = false
+	}
+
 	switch req.Direction {
 	case DirectionIn:
 		return *taskGroup.Count - req.Count
@@ -173,8 +300,30 @@ func (s *Scaler) getNewGroupCount(taskGroup *api.TaskGroup, req *GroupReq) int {
 	return 0
 }
 
+// recordGroupChange writes the effective change to the job group count back to the request, so
+// that the scaling event records the direction and number by which the group was actually scaled
+// rather than the set or absolute count which was requested.
+func recordGroupChange(current, newCount int, req *GroupReq) {
+	switch {
+	case newCount < current:
+		req.Direction, req.Count = DirectionIn, current-newCount
+	case newCount > current:
+		req.Direction, req.Count = DirectionOut, newCount-current
+	default:
+		return
+	}
+	req.Absolute = false
+}
+
 func (s *Scaler) checkNewGroupCount(newCount int, req *GroupReq) error {
-	switch req.Direction {
+	// Absolute requests which are still absolute were not resolved in their requested direction,
+	// and so may move the count either way like a set request.
+	direction := req.Direction
+	if req.Absolute {
+		direction = DirectionSet
+	}
+
+	switch direction {
 	case DirectionIn:
 		if newCount < req.GroupScalingPolicy.MinCount {
 			return errors.New("scaling action will break job group minimum threshold")
@@ -183,18 +332,24 @@ func (s *Scaler) checkNewGroupCount(newCount int, req *GroupReq) error {
 		if newCount > req.GroupScalingPolicy.MaxCount {
 			return errors.New("scaling action will break job group maximum threshold")
 		}
+	case DirectionSet:
+		if newCount < req.GroupScalingPolicy.MinCount {
+			return errors.New("scaling action will break job group minimum threshold")
+		}
+		if newCount > req.GroupScalingPolicy.MaxCount {
+			return errors.New("scaling action will break job group maximum threshold")
+		}
 	}
 	return nil
 }
 
-// triggerNomadRegister is used to submit the updated job to the Nomad API.
-func (s *Scaler) triggerNomadRegister(job *api.Job) (*api.JobRegisterResponse, error) {
-	resp, _, err := s.nomadClient.Jobs().Register(job, nil)
-	return resp, err
-}
-
 func (s *Scaler) getJob(jobID string) (*api.Job, bool, error) {
-	job, _, err := s.nomadClient.Jobs().Info(jobID, nil)
+	nomad, ns, nomadJobID, err := s.nomad.Job(jobID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	job, _, err := nomad.Jobs().Info(nomadJobID, &api.QueryOptions{Namespace: ns})
 
 	// If the job is not running on the cluster, the Nomad API will return an error which contains
 	// the 404 not found message. We want to be able to tell the difference between a 404 and an
@@ -11,7 +11,7 @@ import (
 )
 
 func TestScaler_getNewGroupCount(t *testing.T) {
-	scaler := NewScaler(nil, zerolog.Logger{}, nil, false)
+	scaler := NewScaler(nil, zerolog.Logger{}, nil, false, nil, nil, nil)
 
 	testCases := []struct {
 		taskGroup      *api.TaskGroup
@@ -28,16 +28,48 @@ func TestScaler_getNewGroupCount(t *testing.T) {
 			groupReq:       &GroupReq{Direction: DirectionIn, Count: 2},
 			expectedReturn: 2,
 		},
+		{
+			taskGroup:      api.NewTaskGroup("cache", 4),
+			groupReq:       &GroupReq{Direction: DirectionOut, Count: 9, Absolute: true},
+			expectedReturn: 9,
+		},
+		{
+			taskGroup:      api.NewTaskGroup("cache", 4),
+			groupReq:       &GroupReq{Direction: DirectionIn, Count: 3, Absolute: true},
+			expectedReturn: 3,
+		},
+		{
+			taskGroup:      api.NewTaskGroup("cache", 4),
+			groupReq:       &GroupReq{Direction: DirectionSet, Count: 20},
+			expectedReturn: 20,
+		},
+		{
+			taskGroup: api.NewTaskGroup("cache", 10),
+			groupReq: &GroupReq{Direction: DirectionOut, Count: 25, Percentage: true,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{AdjustmentType: policy.AdjustmentTypePercent}},
+			expectedReturn: 13,
+		},
+		{
+			taskGroup: api.NewTaskGroup("cache", 4),
+			groupReq: &GroupReq{Direction: DirectionIn, Count: 10, Percentage: true,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{AdjustmentType: policy.AdjustmentTypePercent, MinAdjustmentStep: 2}},
+			expectedReturn: 2,
+		},
 	}
 
 	for _, tc := range testCases {
 		newCount := scaler.getNewGroupCount(tc.taskGroup, tc.groupReq)
 		assert.Equal(t, tc.expectedReturn, newCount)
+		assert.False(t, tc.groupReq.Percentage)
+
+		if tc.groupReq.Direction != DirectionSet {
+			assert.False(t, tc.groupReq.Absolute)
+		}
 	}
 }
 
 func TestScaler_checkNewGroupCount(t *testing.T) {
-	scaler := NewScaler(nil, zerolog.Logger{}, nil, true)
+	scaler := NewScaler(nil, zerolog.Logger{}, nil, true, nil, nil, nil)
 
 	testCases := []struct {
 		newCount       int
@@ -84,6 +116,51 @@ func TestScaler_checkNewGroupCount(t *testing.T) {
 			},
 			expectedReturn: errors.New("scaling action will break job group minimum threshold"),
 		},
+		{
+			newCount: 20,
+			groupReq: &GroupReq{
+				Direction: DirectionSet,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{
+					MinCount: 2,
+					MaxCount: 20,
+				},
+			},
+			expectedReturn: nil,
+		},
+		{
+			newCount: 1,
+			groupReq: &GroupReq{
+				Direction: DirectionSet,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{
+					MinCount: 2,
+					MaxCount: 20,
+				},
+			},
+			expectedReturn: errors.New("scaling action will break job group minimum threshold"),
+		},
+		{
+			newCount: 21,
+			groupReq: &GroupReq{
+				Direction: DirectionSet,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{
+					MinCount: 2,
+					MaxCount: 20,
+				},
+			},
+			expectedReturn: errors.New("scaling action will break job group maximum threshold"),
+		},
+		{
+			newCount: 1,
+			groupReq: &GroupReq{
+				Direction: DirectionOut,
+				Absolute:  true,
+				GroupScalingPolicy: &policy.GroupScalingPolicy{
+					MinCount: 2,
+					MaxCount: 20,
+				},
+			},
+			expectedReturn: errors.New("scaling action will break job group minimum threshold"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -96,8 +173,71 @@ func TestScaler_checkNewGroupCount(t *testing.T) {
 	}
 }
 
+func TestScaler_triggerWithoutStrictChecking(t *testing.T) {
+	scaler := &Scaler{logger: zerolog.Logger{}}
+
+	testCases := []struct {
+		groupReq          *GroupReq
+		expectedChanges   bool
+		expectedCount     int
+		expectedDirection Direction
+		expectedReqCount  int
+		expectedError     error
+		name              string
+	}{
+		{
+			groupReq:          &GroupReq{GroupName: "sherpa-cache", Direction: DirectionSet, Count: 5},
+			expectedChanges:   true,
+			expectedCount:     5,
+			expectedDirection: DirectionOut,
+			expectedReqCount:  4,
+			name:              "set to new count",
+		},
+		{
+			groupReq:          &GroupReq{GroupName: "sherpa-cache", Direction: DirectionSet, Count: 1},
+			expectedChanges:   false,
+			expectedCount:     1,
+			expectedDirection: DirectionSet,
+			expectedReqCount:  1,
+			name:              "set to current count",
+		},
+		{
+			groupReq:          &GroupReq{GroupName: "sherpa-cache", Direction: DirectionIn, Absolute: true, Count: 3},
+			expectedChanges:   true,
+			expectedCount:     3,
+			expectedDirection: DirectionOut,
+			expectedReqCount:  2,
+			name:              "absolute scale in resolving above current count",
+		},
+		{
+			groupReq:          &GroupReq{GroupName: "sherpa-db", Direction: DirectionSet, Count: 5},
+			expectedChanges:   false,
+			expectedCount:     1,
+			expectedDirection: DirectionSet,
+			expectedReqCount:  5,
+			expectedError:     errors.New("job group not found on Nomad cluster"),
+			name:              "group not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		job := generateJobWithTargetGroup("sherpa-cache")
+
+		changes, err := scaler.triggerWithoutStrictChecking(*job.ID, job, []*GroupReq{tc.groupReq})
+		assert.Equal(t, tc.expectedChanges, changes, tc.name)
+		assert.Equal(t, tc.expectedCount, *job.TaskGroups[0].Count, tc.name)
+		assert.Equal(t, tc.expectedDirection, tc.groupReq.Direction, tc.name)
+		assert.Equal(t, tc.expectedReqCount, tc.groupReq.Count, tc.name)
+		if tc.expectedError != nil {
+			assert.EqualError(t, err, tc.expectedError.Error(), tc.name)
+		} else {
+			assert.Nil(t, err, tc.name)
+		}
+	}
+}
+
 func TestScaler_jobGroupExists(t *testing.T) {
-	scaler := NewScaler(nil, zerolog.Logger{}, nil, false)
+	scaler := NewScaler(nil, zerolog.Logger{}, nil, false, nil, nil, nil)
 
 	testCases := []struct {
 		job            *api.Job
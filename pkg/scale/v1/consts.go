@@ -20,5 +20,6 @@ const (
 var (
 	errInternalScaleOutNoPolicy = errors.New("scale out forbidden, no scaling policy found")
 	errInternalScaleInNoPolicy  = errors.New("scale in forbidden, no scaling policy found")
+	errInternalScaleSetNoPolicy = errors.New("scale set forbidden, no scaling policy found")
 	errJobGroupInDeployment     = errors.New("scale forbidden, job group currently deploying")
 )
@@ -23,6 +23,24 @@ func getCountFromQueryParam(r *http.Request) int {
 	return countInt
 }
 
+// getRequiredCountFromQueryParam returns the count query parameter, which must be present and a
+// non-negative integer. Unlike getCountFromQueryParam, zero is a valid count.
+func getRequiredCountFromQueryParam(r *http.Request) (int, error) {
+	count := r.FormValue("count")
+	if count == "" {
+		return countFailed, errors.New("count query parameter is required")
+	}
+
+	countInt, err := strconv.Atoi(count)
+	if err != nil {
+		return countFailed, errors.Wrap(err, "failed to parse count query parameter")
+	}
+	if countInt < 0 {
+		return countFailed, errors.New("count query parameter must not be negative")
+	}
+	return countInt, nil
+}
+
 func payloadOrPolicyCount(payloadCount int, policy *policy.GroupScalingPolicy, direction scale.Direction) (int, error) {
 	if payloadCount > 0 {
 		return payloadCount, nil
@@ -42,6 +60,13 @@ func payloadOrPolicyCount(payloadCount int, policy *policy.GroupScalingPolicy, d
 	return 0, errors.New("all possible checks failed to obtain correct count")
 }
 
+// policyCountIsPercentage identifies whether the count used to scale the job group is a
+// percentage, which is the case when the policy count is used and the policy uses the percent
+// adjustment type.
+func policyCountIsPercentage(payloadCount int, policy *policy.GroupScalingPolicy) bool {
+	return payloadCount < 1 && policy != nil && policy.PercentageAdjustment()
+}
+
 func writeJSONResponse(w http.ResponseWriter, bytes []byte, statusCode int) { // nolint:unparam
 	w.Header().Set(headerKeyContentType, headerValueContentTypeJSON)
 	w.WriteHeader(statusCode)
@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/jrasell/sherpa/pkg/policy"
@@ -58,3 +60,54 @@ func Test_payloadOrPolicyCount(t *testing.T) {
 		}
 	}
 }
+
+func Test_getRequiredCountFromQueryParam(t *testing.T) {
+	testCases := []struct {
+		query               string
+		expectedCountReturn int
+		expectedErrorReturn error
+	}{
+		{
+			query:               "count=20",
+			expectedCountReturn: 20,
+			expectedErrorReturn: nil,
+		},
+		{
+			query:               "count=0",
+			expectedCountReturn: 0,
+			expectedErrorReturn: nil,
+		},
+		{
+			query:               "",
+			expectedCountReturn: 0,
+			expectedErrorReturn: errors.New("count query parameter is required"),
+		},
+		{
+			query:               "count=-1",
+			expectedCountReturn: 0,
+			expectedErrorReturn: errors.New("count query parameter must not be negative"),
+		},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/v1/scale/set/example/cache?"+tc.query, nil)
+
+		returnCount, err := getRequiredCountFromQueryParam(req)
+		assert.Equal(t, tc.expectedCountReturn, returnCount)
+
+		if tc.expectedErrorReturn != nil {
+			assert.EqualError(t, err, tc.expectedErrorReturn.Error())
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func Test_policyCountIsPercentage(t *testing.T) {
+	percentPolicy := &policy.GroupScalingPolicy{AdjustmentType: policy.AdjustmentTypePercent}
+
+	assert.True(t, policyCountIsPercentage(0, percentPolicy))
+	assert.False(t, policyCountIsPercentage(3, percentPolicy))
+	assert.False(t, policyCountIsPercentage(0, &policy.GroupScalingPolicy{}))
+	assert.False(t, policyCountIsPercentage(0, nil))
+}
@@ -6,10 +6,12 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
 	policyBackend "github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/jrasell/sherpa/pkg/scale"
 	"github.com/jrasell/sherpa/pkg/state"
 	stateBackend "github.com/jrasell/sherpa/pkg/state/scale"
+	"github.com/jrasell/sherpa/pkg/stream"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -20,6 +22,7 @@ type Scale struct {
 	stateBackend   stateBackend.Backend
 	strictChecking bool
 	scaler         scale.Scale
+	hub            *stream.Hub
 }
 
 // ScaleConfig is a convenience for setting up the scale server. These objects are centrally built
@@ -29,6 +32,7 @@ type ScaleConfig struct {
 	Policy policyBackend.PolicyBackend
 	Scale  scale.Scale
 	State  stateBackend.Backend
+	Hub    *stream.Hub
 }
 
 type scaleRequestBody struct {
@@ -42,12 +46,13 @@ func NewScaleServer(strict bool, cfg *ScaleConfig) *Scale {
 		policyBackend:  cfg.Policy,
 		stateBackend:   cfg.State,
 		strictChecking: strict,
+		hub:            cfg.Hub,
 	}
 }
 
 func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	jobID := vars["job_id"]
+	jobID := helper.JobIDFromRequest(r)
 	groupID := vars["group"]
 
 	body, err := parseScaleRequestBody(r)
@@ -60,6 +65,7 @@ func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 		GroupName: groupID,
 		Time:      helper.GenerateEventTimestamp(),
 		Meta:      body.Meta,
+		Identity:  helper.IdentityFromContext(r.Context()),
 	}
 
 	if s.scaler.JobGroupIsDeploying(jobID, groupID) {
@@ -87,7 +93,7 @@ func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 	newReq.GroupScalingPolicy = pol
 
 	if newReq.GroupScalingPolicy != nil {
-		cd, err := s.scaler.JobGroupIsInCooldown(jobID, groupID, pol.Cooldown, newReq.Time)
+		cd, err := s.scaler.JobGroupIsInCooldown(jobID, groupID, pol.ActionCooldown(policy.ActionScaleIn), newReq.Time)
 		if err != nil {
 			s.logger.Error().
 				Err(err).
@@ -108,7 +114,9 @@ func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	newReq.Count, err = payloadOrPolicyCount(getCountFromQueryParam(r), pol, scale.DirectionIn)
+	payloadCount := getCountFromQueryParam(r)
+
+	newReq.Count, err = payloadOrPolicyCount(payloadCount, pol, scale.DirectionIn)
 	if err != nil {
 		s.logger.Error().
 			Err(err).
@@ -119,6 +127,8 @@ func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newReq.Percentage = policyCountIsPercentage(payloadCount, pol)
+
 	scaleResp, respCode, err := s.scaler.Trigger(jobID, []*scale.GroupReq{newReq}, state.SourceAPI)
 	if err != nil {
 		s.logger.Error().
@@ -157,7 +167,7 @@ func (s *Scale) InJobGroup(w http.ResponseWriter, r *http.Request) {
 
 func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	jobID := vars["job_id"]
+	jobID := helper.JobIDFromRequest(r)
 	groupID := vars["group"]
 
 	body, err := parseScaleRequestBody(r)
@@ -170,6 +180,7 @@ func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 		GroupName: groupID,
 		Time:      helper.GenerateEventTimestamp(),
 		Meta:      body.Meta,
+		Identity:  helper.IdentityFromContext(r.Context()),
 	}
 
 	if s.scaler.JobGroupIsDeploying(jobID, groupID) {
@@ -198,7 +209,7 @@ func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 	newReq.GroupScalingPolicy = pol
 
 	if newReq.GroupScalingPolicy != nil {
-		cd, err := s.scaler.JobGroupIsInCooldown(jobID, groupID, pol.Cooldown, newReq.Time)
+		cd, err := s.scaler.JobGroupIsInCooldown(jobID, groupID, pol.ActionCooldown(policy.ActionScaleOut), newReq.Time)
 		if err != nil {
 			s.logger.Error().
 				Err(err).
@@ -219,7 +230,9 @@ func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	newReq.Count, err = payloadOrPolicyCount(getCountFromQueryParam(r), pol, scale.DirectionIn)
+	payloadCount := getCountFromQueryParam(r)
+
+	newReq.Count, err = payloadOrPolicyCount(payloadCount, pol, scale.DirectionOut)
 	if err != nil {
 		s.logger.Error().
 			Err(err).
@@ -230,6 +243,8 @@ func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	newReq.Percentage = policyCountIsPercentage(payloadCount, pol)
+
 	scaleResp, respCode, err := s.scaler.Trigger(jobID, []*scale.GroupReq{newReq}, state.SourceAPI)
 	if err != nil {
 		s.logger.Error().
@@ -266,6 +281,117 @@ func (s *Scale) OutJobGroup(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, bytes, http.StatusCreated)
 }
 
+// SetJobGroup scales the job group to the absolute count specified within the request.
+func (s *Scale) SetJobGroup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := helper.JobIDFromRequest(r)
+	groupID := vars["group"]
+
+	count, err := getRequiredCountFromQueryParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := parseScaleRequestBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	newReq := &scale.GroupReq{
+		Direction: scale.DirectionSet,
+		Count:     count,
+		Absolute:  true,
+		GroupName: groupID,
+		Time:      helper.GenerateEventTimestamp(),
+		Meta:      body.Meta,
+		Identity:  helper.IdentityFromContext(r.Context()),
+	}
+
+	if s.scaler.JobGroupIsDeploying(jobID, groupID) {
+		s.logger.Info().
+			Str("job", jobID).
+			Str("group", groupID).
+			Msg("job group is currently in deployment and cannot be scaled")
+		http.Error(w, errJobGroupInDeployment.Error(), http.StatusForbidden)
+		return
+	}
+
+	pol, err := s.policyBackend.GetJobGroupPolicy(jobID, groupID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if s.strictChecking && pol == nil {
+		s.logger.Info().
+			Str("job", jobID).
+			Str("group", groupID).
+			Msg("strict checking enabled and job group does not have scaling policy")
+		http.Error(w, errInternalScaleSetNoPolicy.Error(), http.StatusForbidden)
+		return
+	}
+	newReq.GroupScalingPolicy = pol
+
+	if newReq.GroupScalingPolicy != nil {
+		cd, err := s.scaler.JobGroupIsInCooldown(jobID, groupID, pol.Cooldown, newReq.Time)
+		if err != nil {
+			s.logger.Error().
+				Err(err).
+				Str("job", jobID).
+				Str("group", groupID).
+				Msg("failed to check if job group is currently in scaling cooldown")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if cd {
+			s.logger.Info().
+				Str("job", jobID).
+				Str("group", groupID).
+				Msg(jobGroupInCooldownMsg)
+			http.Error(w, jobGroupInCooldownMsg, http.StatusConflict)
+			return
+		}
+	}
+
+	scaleResp, respCode, err := s.scaler.Trigger(jobID, []*scale.GroupReq{newReq}, state.SourceAPI)
+	if err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("job", jobID).
+			Str("group", groupID).
+			Msg("failed to set Nomad job group count")
+		http.Error(w, err.Error(), respCode)
+		return
+	}
+
+	if respCode == http.StatusNotFound {
+		http.NotFound(w, r)
+		return
+	}
+
+	if respCode == http.StatusNotModified {
+		http.Error(w, "unable to scale job", http.StatusNotModified)
+		return
+	}
+
+	s.logger.Info().
+		Str("job", jobID).
+		Str("group", groupID).
+		Int("count", count).
+		Msg("successfully set Nomad job group count")
+
+	bytes, err := json.Marshal(scaleResp)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to marshal scaling response")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSONResponse(w, bytes, http.StatusCreated)
+}
+
 func parseScaleRequestBody(r *http.Request) (*scaleRequestBody, error) {
 	if r.ContentLength < 1 {
 		empty := &scaleRequestBody{
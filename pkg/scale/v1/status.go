@@ -10,8 +10,10 @@ import (
 )
 
 func (s *Scale) StatusList(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+
 	if l := r.URL.Query().Get("latest"); l == "true" {
-		s.statusListLatest(w)
+		s.statusListLatest(w, status)
 		return
 	}
 
@@ -22,7 +24,7 @@ func (s *Scale) StatusList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	bytes, err := json.Marshal(list)
+	bytes, err := json.Marshal(filterEventsByStatus(list, status))
 	if err != nil {
 		s.logger.Error().Err(err).Msg("failed to marshal scaling state response")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -32,7 +34,7 @@ func (s *Scale) StatusList(w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, bytes, http.StatusOK)
 }
 
-func (s *Scale) statusListLatest(w http.ResponseWriter) {
+func (s *Scale) statusListLatest(w http.ResponseWriter, status string) {
 	list, err := s.stateBackend.GetLatestScalingEvents()
 	if err != nil {
 		s.logger.Error().Err(err).Msg("failed to get latest scaling events from state")
@@ -45,7 +47,7 @@ func (s *Scale) statusListLatest(w http.ResponseWriter) {
 		out[event.ID] = map[string]*state.ScalingEvent{jg: event}
 	}
 
-	bytes, err := json.Marshal(out)
+	bytes, err := json.Marshal(filterEventsByStatus(out, status))
 	if err != nil {
 		s.logger.Error().Err(err).Msg("failed to marshal latest scaling state response")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -87,3 +89,26 @@ func (s *Scale) StatusInfo(w http.ResponseWriter, r *http.Request) {
 
 	writeJSONResponse(w, bytes, http.StatusOK)
 }
+
+// filterEventsByStatus returns the scaling events which have the passed status. If the status is
+// empty, all events are returned.
+func filterEventsByStatus(events map[uuid.UUID]map[string]*state.ScalingEvent, status string) map[uuid.UUID]map[string]*state.ScalingEvent {
+	if status == "" {
+		return events
+	}
+
+	out := make(map[uuid.UUID]map[string]*state.ScalingEvent)
+
+	for id, jobEvents := range events {
+		for jg, event := range jobEvents {
+			if event.Status.String() != status {
+				continue
+			}
+			if _, ok := out[id]; !ok {
+				out[id] = make(map[string]*state.ScalingEvent)
+			}
+			out[id][jg] = event
+		}
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterEventsByStatus(t *testing.T) {
+	id1, _ := uuid.NewV4()
+	id2, _ := uuid.NewV4()
+
+	completed := &state.ScalingEvent{ID: id1, Status: state.StatusCompleted}
+	dryRunCache := &state.ScalingEvent{ID: id2, Status: state.StatusDryRun}
+	failedWeb := &state.ScalingEvent{ID: id2, Status: state.StatusFailed}
+
+	events := map[uuid.UUID]map[string]*state.ScalingEvent{
+		id1: {"example:cache": completed},
+		id2: {"example:cache": dryRunCache, "example:web": failedWeb},
+	}
+
+	assert.Equal(t, events, filterEventsByStatus(events, ""))
+	assert.Equal(t, map[uuid.UUID]map[string]*state.ScalingEvent{
+		id2: {"example:cache": dryRunCache},
+	}, filterEventsByStatus(events, state.StatusDryRun))
+	assert.Equal(t, map[uuid.UUID]map[string]*state.ScalingEvent{}, filterEventsByStatus(events, "Unknown"))
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/stream"
+	"github.com/pkg/errors"
+)
+
+const (
+	headerValueContentTypeEventStream = "text/event-stream"
+
+	// streamHeartbeatInterval is the period between the comments sent on idle event streams, which
+	// prevent proxies from closing the connection and detect disconnected clients.
+	streamHeartbeatInterval = 10 * time.Second
+)
+
+// Stream streams the scaling, policy and leadership events published by the Sherpa server to the
+// client as server-sent events. The stream ends when the server loses the cluster leadership, as
+// scaling events are only published by the leader, and clients should reconnect to follow the
+// new leader.
+func (s *Scale) Stream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	filter, err := streamFilterFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Leadership events are always subscribed to, so that the stream can be ended when leadership
+	// is lost even if the client has not requested them.
+	subFilter := *filter
+	if !subFilter.MatchesTopic(stream.TopicLeadership) {
+		subFilter.Topics = append(append([]stream.Topic{}, filter.Topics...), stream.TopicLeadership)
+	}
+
+	sub, err := s.hub.Subscribe(&subFilter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set(headerKeyContentType, headerValueContentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+
+			if filter.MatchesTopic(e.Topic) {
+				if err := writeStreamEvent(w, e); err != nil {
+					s.logger.Debug().Err(err).Msg("failed to write event to stream")
+					return
+				}
+				flusher.Flush()
+			}
+
+			if change, ok := e.Payload.(*stream.LeadershipChange); ok && !change.IsLeader {
+				return
+			}
+		}
+	}
+}
+
+// streamFilterFromRequest builds the event stream filter from the request query parameters. The
+// job is combined with the namespace and region parameters to form the Sherpa job identifier.
+func streamFilterFromRequest(r *http.Request) (*stream.Filter, error) {
+	q := r.URL.Query()
+
+	filter := stream.Filter{
+		Group:  q.Get("group"),
+		Source: q.Get("source"),
+	}
+
+	if job := q.Get("job"); job != "" {
+		filter.Job = helper.RegionalJobID(q.Get(helper.RegionQueryParam),
+			helper.NamespacedJobID(q.Get(helper.NamespaceQueryParam), job))
+	}
+
+	if topics := q.Get("topic"); topics != "" {
+		for _, t := range strings.Split(topics, ",") {
+			topic := stream.Topic(strings.TrimSpace(t))
+			if !stream.ValidTopic(topic) {
+				return nil, errors.Errorf("unknown event topic %q", topic)
+			}
+			filter.Topics = append(filter.Topics, topic)
+		}
+	}
+	return &filter, nil
+}
+
+// writeStreamEvent writes the event as a server-sent event, using the event index as its ID and
+// the topic as its type.
+func writeStreamEvent(w io.Writer, e *stream.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Index, e.Topic, data)
+	return err
+}
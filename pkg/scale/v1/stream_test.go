@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/stream"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_streamFilterFromRequest(t *testing.T) {
+	testCases := []struct {
+		url            string
+		expectedOutput *stream.Filter
+		expectedError  bool
+	}{
+		{
+			url:            "/v1/scale/stream",
+			expectedOutput: &stream.Filter{},
+		},
+		{
+			url:            "/v1/scale/stream?job=example&group=cache&source=API",
+			expectedOutput: &stream.Filter{Job: "example", Group: "cache", Source: "API"},
+		},
+		{
+			url:            "/v1/scale/stream?job=example&namespace=platform&region=eu-west-1",
+			expectedOutput: &stream.Filter{Job: "eu-west-1@platform/example"},
+		},
+		{
+			url:            "/v1/scale/stream?namespace=platform",
+			expectedOutput: &stream.Filter{},
+		},
+		{
+			url:            "/v1/scale/stream?topic=ScalingEvent,Policy",
+			expectedOutput: &stream.Filter{Topics: []stream.Topic{stream.TopicScaling, stream.TopicPolicy}},
+		},
+		{
+			url:           "/v1/scale/stream?topic=Unknown",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		actualOutput, err := streamFilterFromRequest(httptest.NewRequest(http.MethodGet, tc.url, nil))
+		assert.Equal(t, tc.expectedError, err != nil, tc.url)
+		assert.Equal(t, tc.expectedOutput, actualOutput, tc.url)
+	}
+}
+
+func TestScale_Stream(t *testing.T) {
+	hub := stream.NewHub(zerolog.Nop())
+	s := NewScaleServer(true, &ScaleConfig{Logger: zerolog.Nop(), Hub: hub})
+
+	ts := httptest.NewServer(http.HandlerFunc(s.Stream))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "?topic=ScalingEvent&group=cache")
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, headerValueContentTypeEventStream, resp.Header.Get(headerKeyContentType))
+
+	// The handler subscribes before sending the response headers, so events published now are
+	// streamed.
+	hub.Publish(&stream.Event{Topic: stream.TopicScaling, Job: "example", Group: "web", Time: 1})
+	hub.Publish(&stream.Event{Topic: stream.TopicPolicy, Job: "example", Group: "cache", Time: 2})
+	hub.Publish(&stream.Event{Topic: stream.TopicScaling, Job: "example", Group: "cache", Time: 3})
+	hub.Publish(&stream.Event{Topic: stream.TopicLeadership, Time: 4, Payload: &stream.LeadershipChange{}})
+
+	// Only the matching scaling event is written, and losing leadership ends the stream even
+	// though leadership events were not requested.
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	assert.Equal(t, []string{
+		"id: 3",
+		"event: ScalingEvent",
+		`data: {"Index":3,"Topic":"ScalingEvent","Time":3,"Job":"example","Group":"cache","Payload":null}`,
+		"",
+	}, lines)
+}
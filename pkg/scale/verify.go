@@ -0,0 +1,313 @@
+package scale
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/gofrs/uuid"
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/pkg/errors"
+)
+
+const (
+	// evalStatusComplete, evalStatusFailed and evalStatusCancelled are the terminal Nomad
+	// evaluation statuses. The Nomad API does not currently export these values.
+	evalStatusComplete  = "complete"
+	evalStatusFailed    = "failed"
+	evalStatusCancelled = "canceled"
+
+	// deploymentStatusSuccessful, deploymentStatusFailed and deploymentStatusCancelled are the
+	// terminal Nomad deployment statuses. The Nomad API does not currently export these values.
+	deploymentStatusSuccessful = "successful"
+	deploymentStatusFailed     = "failed"
+	deploymentStatusCancelled  = "cancelled"
+
+	// allocDesiredStatusRun is the desired status of allocations Nomad intends to be running. The
+	// Nomad API does not currently export this value.
+	allocDesiredStatusRun = "run"
+
+	// verificationTimeout is the maximum time to follow a Nomad evaluation and the resulting
+	// deployment or allocations before the scaling event outcome is recorded as failed. It is
+	// longer than the default Nomad deployment progress deadline of 10 minutes.
+	verificationTimeout = 15 * time.Minute
+
+	// verificationWaitTime is the maximum time each blocking query against the Nomad API waits
+	// for a change.
+	verificationWaitTime = 30 * time.Second
+
+	// verificationRetryInterval is the time waited before retrying a failed call to the Nomad
+	// API.
+	verificationRetryInterval = 5 * time.Second
+)
+
+// verifyScalingEvent follows the Nomad evaluation created by the scaling event until it has
+// completed, and then the resulting deployment or allocations until placement of each job group
+// is confirmed, recording the outcome of each job group on the scaling event.
+func (s *Scaler) verifyScalingEvent(job string, id uuid.UUID, evalID string, groups []string) {
+	start := time.Now()
+	deadline := start.Add(verificationTimeout)
+
+	nomad, ns, _, err := s.nomad.Job(job)
+	if err != nil {
+		s.logger.Error().Str("job", job).Err(err).Msg("failed to find Nomad target of job")
+		return
+	}
+
+	outcomes := s.placementOutcomes(nomad, ns, evalID, groups, deadline)
+	metrics.MeasureSince([]string{"scale", "verification"}, start)
+
+	for _, group := range groups {
+		outcome := outcomes[group]
+		outcome.Time = helper.GenerateEventTimestamp()
+
+		s.logger.Info().
+			Str("job", job).
+			Str("group", group).
+			Str("evaluation", evalID).
+			Str("outcome", outcome.Status.String()).
+			Strs("reasons", outcome.Reasons).
+			Msg("scaling event outcome determined")
+
+		sendVerificationMetrics(job, outcome.Status)
+
+		if err := s.state.PutScalingEventOutcome(job, group, id, outcome); err != nil {
+			s.logger.Error().
+				Str("job", job).
+				Str("group", group).
+				Err(err).
+				Msg("failed to update state with scaling event outcome")
+		}
+	}
+}
+
+// placementOutcomes follows the Nomad evaluation, and then either the deployment it created or
+// the allocations it placed, to determine the outcome of each job group.
+func (s *Scaler) placementOutcomes(nomad *api.Client, ns, evalID string, groups []string, deadline time.Time) map[string]*state.EventOutcome {
+	outcomes := make(map[string]*state.EventOutcome, len(groups))
+
+	eval, err := s.waitForEvaluation(nomad, ns, evalID, deadline)
+	if err != nil {
+		s.logger.Error().Str("evaluation", evalID).Err(err).Msg("failed to follow Nomad evaluation to completion")
+		for _, group := range groups {
+			outcomes[group] = failedOutcome(err)
+		}
+		return outcomes
+	}
+
+	var deployment *api.Deployment
+
+	for _, group := range groups {
+		if outcomes[group] = evaluationOutcome(eval, group); outcomes[group] != nil {
+			continue
+		}
+
+		// Evaluations of jobs with an update strategy create a deployment which tracks the
+		// health of the job group allocations. Otherwise the allocations placed by the
+		// evaluation are followed until they are running.
+		if eval.DeploymentID == "" {
+			outcomes[group] = s.waitForAllocations(nomad, ns, evalID, group, deadline)
+			continue
+		}
+
+		if deployment == nil {
+			if deployment, err = s.waitForDeployment(nomad, ns, eval.DeploymentID, deadline); err != nil {
+				s.logger.Error().
+					Str("deployment", eval.DeploymentID).
+					Err(err).
+					Msg("failed to follow Nomad deployment to completion")
+				outcomes[group] = failedOutcome(err)
+				continue
+			}
+		}
+		outcomes[group] = deploymentOutcome(deployment)
+	}
+	return outcomes
+}
+
+// waitForEvaluation uses blocking queries to wait for the Nomad evaluation to reach a terminal
+// status, returning an error if the deadline is reached first.
+func (s *Scaler) waitForEvaluation(nomad *api.Client, ns, evalID string, deadline time.Time) (*api.Evaluation, error) {
+	var index uint64
+
+	for time.Now().Before(deadline) {
+		q := &api.QueryOptions{Namespace: ns, WaitIndex: index, WaitTime: verificationWaitTime}
+
+		eval, meta, err := nomad.Evaluations().Info(evalID, q)
+		if err != nil {
+			s.logger.Debug().Str("evaluation", evalID).Err(err).Msg("failed to read Nomad evaluation")
+			time.Sleep(verificationRetryInterval)
+			continue
+		}
+
+		switch eval.Status {
+		case evalStatusComplete, evalStatusFailed, evalStatusCancelled:
+			return eval, nil
+		}
+		index = meta.LastIndex
+	}
+	return nil, errors.Errorf("timed out waiting for evaluation %s to complete", evalID)
+}
+
+// waitForDeployment uses blocking queries to wait for the Nomad deployment to reach a terminal
+// status, returning an error if the deadline is reached first.
+func (s *Scaler) waitForDeployment(nomad *api.Client, ns, deploymentID string, deadline time.Time) (*api.Deployment, error) {
+	var index uint64
+
+	for time.Now().Before(deadline) {
+		q := &api.QueryOptions{Namespace: ns, WaitIndex: index, WaitTime: verificationWaitTime}
+
+		deployment, meta, err := nomad.Deployments().Info(deploymentID, q)
+		if err != nil {
+			s.logger.Debug().Str("deployment", deploymentID).Err(err).Msg("failed to read Nomad deployment")
+			time.Sleep(verificationRetryInterval)
+			continue
+		}
+
+		switch deployment.Status {
+		case deploymentStatusSuccessful, deploymentStatusFailed, deploymentStatusCancelled:
+			return deployment, nil
+		}
+		index = meta.LastIndex
+	}
+	return nil, errors.Errorf("timed out waiting for deployment %s to complete", deploymentID)
+}
+
+// waitForAllocations uses blocking queries to wait for the allocations of the job group placed by
+// the Nomad evaluation to start running, returning a failed outcome if the deadline is reached
+// first.
+func (s *Scaler) waitForAllocations(nomad *api.Client, ns, evalID, group string, deadline time.Time) *state.EventOutcome {
+	var index uint64
+
+	for time.Now().Before(deadline) {
+		q := &api.QueryOptions{Namespace: ns, WaitIndex: index, WaitTime: verificationWaitTime}
+
+		allocs, meta, err := nomad.Evaluations().Allocations(evalID, q)
+		if err != nil {
+			s.logger.Debug().Str("evaluation", evalID).Err(err).Msg("failed to read Nomad evaluation allocations")
+			time.Sleep(verificationRetryInterval)
+			continue
+		}
+
+		if outcome := allocationsOutcome(allocs, group); outcome != nil {
+			return outcome
+		}
+		index = meta.LastIndex
+	}
+	return failedOutcome(errors.Errorf("timed out waiting for allocations of evaluation %s to start", evalID))
+}
+
+// evaluationOutcome determines the outcome of the job group from the terminal Nomad evaluation.
+// It returns nil when the evaluation completed without any allocations of the job group failing
+// to be placed, in which case placement is confirmed using the resulting deployment or
+// allocations.
+func evaluationOutcome(eval *api.Evaluation, group string) *state.EventOutcome {
+	switch eval.Status {
+	case evalStatusFailed:
+		return &state.EventOutcome{Status: state.OutcomeFailed, Reasons: []string{eval.StatusDescription}}
+	case evalStatusCancelled:
+		return &state.EventOutcome{Status: state.OutcomeFailed, Reasons: []string{"evaluation was cancelled"}}
+	}
+
+	if metric, ok := eval.FailedTGAllocs[group]; ok {
+		return &state.EventOutcome{Status: state.OutcomeBlocked, Reasons: allocationMetricReasons(metric)}
+	}
+	return nil
+}
+
+// deploymentOutcome determines the outcome of a job group from the terminal Nomad deployment.
+func deploymentOutcome(deployment *api.Deployment) *state.EventOutcome {
+	if deployment.Status == deploymentStatusSuccessful {
+		return &state.EventOutcome{Status: state.OutcomePlaced}
+	}
+	return &state.EventOutcome{Status: state.OutcomeFailed, Reasons: []string{deployment.StatusDescription}}
+}
+
+// allocationsOutcome determines the outcome of the job group from the allocations placed by the
+// Nomad evaluation. It returns nil while any of the allocations are still pending. A job group
+// without any allocations placed, such as after scaling in, is considered placed.
+func allocationsOutcome(allocs []*api.AllocationListStub, group string) *state.EventOutcome {
+	var (
+		pending bool
+		reasons []string
+	)
+
+	for _, alloc := range allocs {
+		if alloc.TaskGroup != group || alloc.DesiredStatus != allocDesiredStatusRun {
+			continue
+		}
+
+		switch alloc.ClientStatus {
+		case api.AllocClientStatusPending:
+			pending = true
+		case api.AllocClientStatusFailed, api.AllocClientStatusLost:
+			reasons = append(reasons, fmt.Sprintf("allocation %s %s: %s", alloc.ID, alloc.ClientStatus, alloc.ClientDescription))
+		}
+	}
+
+	switch {
+	case len(reasons) > 0:
+		return &state.EventOutcome{Status: state.OutcomeFailed, Reasons: reasons}
+	case pending:
+		return nil
+	default:
+		return &state.EventOutcome{Status: state.OutcomePlaced}
+	}
+}
+
+func failedOutcome(err error) *state.EventOutcome {
+	return &state.EventOutcome{Status: state.OutcomeFailed, Reasons: []string{err.Error()}}
+}
+
+// allocationMetricReasons builds the human readable reasons why allocations could not be placed
+// from the Nomad allocation metric.
+func allocationMetricReasons(metric *api.AllocationMetric) []string {
+	var reasons []string
+
+	if metric.NodesEvaluated == 0 {
+		reasons = append(reasons, "no nodes were eligible for evaluation")
+	}
+	for _, class := range sortedKeys(metric.ClassFiltered) {
+		reasons = append(reasons, fmt.Sprintf("class %q filtered %v nodes", class, metric.ClassFiltered[class]))
+	}
+	for _, constraint := range sortedKeys(metric.ConstraintFiltered) {
+		reasons = append(reasons, fmt.Sprintf("constraint %q filtered %v nodes", constraint, metric.ConstraintFiltered[constraint]))
+	}
+	if metric.NodesExhausted > 0 {
+		reasons = append(reasons, fmt.Sprintf("resources exhausted on %v nodes", metric.NodesExhausted))
+	}
+	for _, class := range sortedKeys(metric.ClassExhausted) {
+		reasons = append(reasons, fmt.Sprintf("class %q exhausted on %v nodes", class, metric.ClassExhausted[class]))
+	}
+	for _, dimension := range sortedKeys(metric.DimensionExhausted) {
+		reasons = append(reasons, fmt.Sprintf("dimension %q exhausted on %v nodes", dimension, metric.DimensionExhausted[dimension]))
+	}
+	for _, quota := range metric.QuotaExhausted {
+		reasons = append(reasons, fmt.Sprintf("quota limit reached: %s", quota))
+	}
+	if metric.CoalescedFailures > 0 {
+		reasons = append(reasons, fmt.Sprintf("%v additional allocations could not be placed", metric.CoalescedFailures))
+	}
+	return reasons
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sendVerificationMetrics is a helper to track the outcome of scaling events. This is done by
+// tracking both overall, and job specific counters.
+func sendVerificationMetrics(job string, status state.OutcomeStatus) {
+	outcome := strings.ToLower(status.String())
+	metrics.IncrCounter([]string{"scale", "outcome", outcome}, 1)
+	metrics.IncrCounter([]string{"scale", job, "outcome", outcome}, 1)
+}
@@ -0,0 +1,188 @@
+package scale
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_evaluationOutcome(t *testing.T) {
+	testCases := []struct {
+		inputEval      *api.Evaluation
+		inputGroup     string
+		expectedOutput *state.EventOutcome
+		name           string
+	}{
+		{
+			inputEval:      &api.Evaluation{Status: "complete"},
+			inputGroup:     "cache",
+			expectedOutput: nil,
+			name:           "evaluation completed without placement failures",
+		},
+		{
+			inputEval: &api.Evaluation{
+				Status: "complete",
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"cache": {
+						NodesEvaluated:     3,
+						NodesExhausted:     3,
+						DimensionExhausted: map[string]int{"memory": 2, "cpu": 1},
+						CoalescedFailures:  4,
+					},
+				},
+				BlockedEval: "blocked-eval",
+			},
+			inputGroup: "cache",
+			expectedOutput: &state.EventOutcome{
+				Status: state.OutcomeBlocked,
+				Reasons: []string{
+					"resources exhausted on 3 nodes",
+					"dimension \"cpu\" exhausted on 1 nodes",
+					"dimension \"memory\" exhausted on 2 nodes",
+					"4 additional allocations could not be placed",
+				},
+			},
+			name: "evaluation completed with the group blocked",
+		},
+		{
+			inputEval: &api.Evaluation{
+				Status: "complete",
+				FailedTGAllocs: map[string]*api.AllocationMetric{
+					"web": {NodesEvaluated: 0},
+				},
+			},
+			inputGroup:     "cache",
+			expectedOutput: nil,
+			name:           "evaluation completed with another group blocked",
+		},
+		{
+			inputEval:  &api.Evaluation{Status: "failed", StatusDescription: "maximum attempts reached (5)"},
+			inputGroup: "cache",
+			expectedOutput: &state.EventOutcome{
+				Status:  state.OutcomeFailed,
+				Reasons: []string{"maximum attempts reached (5)"},
+			},
+			name: "evaluation failed",
+		},
+		{
+			inputEval:  &api.Evaluation{Status: "canceled"},
+			inputGroup: "cache",
+			expectedOutput: &state.EventOutcome{
+				Status:  state.OutcomeFailed,
+				Reasons: []string{"evaluation was cancelled"},
+			},
+			name: "evaluation cancelled",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, evaluationOutcome(tc.inputEval, tc.inputGroup), tc.name)
+	}
+}
+
+func Test_deploymentOutcome(t *testing.T) {
+	testCases := []struct {
+		inputDeployment *api.Deployment
+		expectedOutput  *state.EventOutcome
+		name            string
+	}{
+		{
+			inputDeployment: &api.Deployment{Status: "successful", StatusDescription: "Deployment completed successfully"},
+			expectedOutput:  &state.EventOutcome{Status: state.OutcomePlaced},
+			name:            "deployment successful",
+		},
+		{
+			inputDeployment: &api.Deployment{Status: "failed", StatusDescription: "Failed due to progress deadline"},
+			expectedOutput: &state.EventOutcome{
+				Status:  state.OutcomeFailed,
+				Reasons: []string{"Failed due to progress deadline"},
+			},
+			name: "deployment failed",
+		},
+		{
+			inputDeployment: &api.Deployment{Status: "cancelled", StatusDescription: "Cancelled due to newer version of job"},
+			expectedOutput: &state.EventOutcome{
+				Status:  state.OutcomeFailed,
+				Reasons: []string{"Cancelled due to newer version of job"},
+			},
+			name: "deployment cancelled",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, deploymentOutcome(tc.inputDeployment), tc.name)
+	}
+}
+
+func Test_allocationsOutcome(t *testing.T) {
+	testCases := []struct {
+		inputAllocs    []*api.AllocationListStub
+		inputGroup     string
+		expectedOutput *state.EventOutcome
+		name           string
+	}{
+		{
+			inputAllocs: []*api.AllocationListStub{
+				{ID: "a1", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "running"},
+				{ID: "a2", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "running"},
+			},
+			inputGroup:     "cache",
+			expectedOutput: &state.EventOutcome{Status: state.OutcomePlaced},
+			name:           "all allocations running",
+		},
+		{
+			inputAllocs: []*api.AllocationListStub{
+				{ID: "a1", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "running"},
+				{ID: "a2", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "pending"},
+			},
+			inputGroup:     "cache",
+			expectedOutput: nil,
+			name:           "allocation still pending",
+		},
+		{
+			inputAllocs: []*api.AllocationListStub{
+				{ID: "a1", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "pending"},
+				{ID: "a2", TaskGroup: "cache", DesiredStatus: "run", ClientStatus: "failed", ClientDescription: "Failed tasks"},
+			},
+			inputGroup: "cache",
+			expectedOutput: &state.EventOutcome{
+				Status:  state.OutcomeFailed,
+				Reasons: []string{"allocation a2 failed: Failed tasks"},
+			},
+			name: "allocation failed",
+		},
+		{
+			inputAllocs: []*api.AllocationListStub{
+				{ID: "a1", TaskGroup: "web", DesiredStatus: "run", ClientStatus: "pending"},
+				{ID: "a2", TaskGroup: "cache", DesiredStatus: "stop", ClientStatus: "pending"},
+			},
+			inputGroup:     "cache",
+			expectedOutput: &state.EventOutcome{Status: state.OutcomePlaced},
+			name:           "no allocations placed for the group",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, allocationsOutcome(tc.inputAllocs, tc.inputGroup), tc.name)
+	}
+}
+
+func Test_allocationMetricReasons(t *testing.T) {
+	metric := &api.AllocationMetric{
+		ClassFiltered:      map[string]int{"gpu": 2},
+		ConstraintFiltered: map[string]int{"${attr.kernel.name} = linux": 1},
+		ClassExhausted:     map[string]int{"general": 4},
+		QuotaExhausted:     []string{"memory exhausted (2048 needed > 1024 limit)"},
+	}
+
+	expected := []string{
+		"no nodes were eligible for evaluation",
+		"class \"gpu\" filtered 2 nodes",
+		"constraint \"${attr.kernel.name} = linux\" filtered 1 nodes",
+		"class \"general\" exhausted on 4 nodes",
+		"quota limit reached: memory exhausted (2048 needed > 1024 limit)",
+	}
+	assert.Equal(t, expected, allocationMetricReasons(metric))
+}
@@ -5,9 +5,11 @@ import (
 )
 
 type Config struct {
+	Audit          *serverCfg.AuditConfig
 	Debug          bool
 	Cluster        *serverCfg.ClusterConfig
 	MetricProvider *serverCfg.MetricProviderConfig
+	Notifier       *serverCfg.NotifierConfig
 	Server         *serverCfg.Config
 	TLS            *serverCfg.TLSConfig
 	Telemetry      *serverCfg.TelemetryConfig
@@ -21,6 +23,8 @@ const (
 
 	routeGetScalingStatusPattern            = "/v1/scale/status"
 	routeGetScalingStatusName               = "GetScalingStatus"
+	routeGetScalingStreamPattern            = "/v1/scale/stream"
+	routeGetScalingStreamName               = "GetScalingStream"
 	routeGetScalingInfoPattern              = "/v1/scale/status/{id}"
 	routeGetScalingInfoName                 = "GetScalingInfo"
 	routeScaleOutJobGroupName               = "ScaleOutJobGroup"
@@ -31,6 +35,8 @@ const (
 	routePostScaleOutJobGroupPattern        = "/v1/scale/out/{job_id}/{group}"
 	routePostScaleInJobGroupName            = "ScaleInJobGroup"
 	routePostScaleInJobGroupPattern         = "/v1/scale/in/{job_id}/{group}"
+	routePostScaleSetJobGroupName           = "ScaleSetJobGroup"
+	routePostScaleSetJobGroupPattern        = "/v1/scale/set/{job_id}/{group}"
 	routeGetJobScalingPoliciesName          = "GetJobScalingPolicies"
 	routeGetJobScalingPoliciesPattern       = "/v1/policies"
 	routeGetJobScalingPolicyName            = "GetJobScalingPolicy"
@@ -51,8 +57,22 @@ const (
 	telemetryInterval = 10
 )
 
+// Autoscale server routes.
+const (
+	routeGetAutoScalePredictJobGroupName    = "GetAutoScalePredictJobGroup"
+	routeGetAutoScalePredictJobGroupPattern = "/v1/autoscale/predict/{job_id}/{group}"
+	routeGetAutoScaleEvaluateJobName        = "GetAutoScaleEvaluateJob"
+	routeGetAutoScaleEvaluateJobPattern     = "/v1/autoscale/evaluate/{job_id}"
+	routeGetAutoScaleJobHistoryName         = "GetAutoScaleJobHistory"
+	routeGetAutoScaleJobHistoryPattern      = "/v1/autoscale/history/{job_id}"
+	routeGetAutoScaleJobGroupHistoryName    = "GetAutoScaleJobGroupHistory"
+	routeGetAutoScaleJobGroupHistoryPattern = "/v1/autoscale/history/{job_id}/{group}"
+)
+
 // System server routes.
 const (
+	routeGetSystemAuditName     = "GetSystemAudit"
+	routeGetSystemAuditPattern  = "/v1/system/audit"
 	routeGetSystemLeaderName    = "GetSystemLeader"
 	routeGetSystemLeaderPattern = "/v1/system/leader"
 	routeSystemHealthName       = "GetSystemHealth"
@@ -61,6 +81,20 @@ const (
 	routeSystemInfoPattern      = "/v1/system/info"
 )
 
+// ACL server routes.
+const (
+	routeGetACLTokensName       = "GetACLTokens"
+	routeGetACLTokensPattern    = "/v1/acl/tokens"
+	routePostACLTokenName       = "PostACLToken"
+	routePostACLTokenPattern    = "/v1/acl/token"
+	routeGetACLTokenSelfName    = "GetACLTokenSelf"
+	routeGetACLTokenSelfPattern = "/v1/acl/token/self"
+	routeGetACLTokenName        = "GetACLToken"
+	routeGetACLTokenPattern     = "/v1/acl/token/{accessor_id}"
+	routeDeleteACLTokenName     = "DeleteACLToken"
+	routeDeleteACLTokenPattern  = "/v1/acl/token/{accessor_id}"
+)
+
 // Debug server routes.
 const (
 	routeGetDebugPPROFName           = "GetDebugPPROF"
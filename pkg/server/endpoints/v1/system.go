@@ -7,7 +7,7 @@ import (
 
 	metrics "github.com/armon/go-metrics"
 	"github.com/gofrs/uuid"
-	"github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/client"
 	serverCfg "github.com/jrasell/sherpa/pkg/config/server"
 	"github.com/jrasell/sherpa/pkg/server/cluster"
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,13 +36,17 @@ var (
 type SystemServer struct {
 	logger    zerolog.Logger
 	member    *cluster.Member
-	nomad     *api.Client
+	nomad     *client.NomadTargets
 	server    *serverCfg.Config
 	telemetry *metrics.InmemSink
 }
 
 type SystemInfoResp struct {
-	NomadAddress              string
+	NomadAddress string
+
+	// NomadRegions are the addresses of the additional Nomad targets, keyed by region.
+	NomadRegions map[string]string `json:",omitempty"`
+
 	PolicyEngine              string
 	StorageBackend            string
 	InternalAutoScalingEngine bool
@@ -63,7 +67,7 @@ type SystemLeaderResp struct {
 	LeaderClusterAddress string
 }
 
-func NewSystemServer(l zerolog.Logger, nomad *api.Client, server *serverCfg.Config, tel *metrics.InmemSink, mem *cluster.Member) *SystemServer {
+func NewSystemServer(l zerolog.Logger, nomad *client.NomadTargets, server *serverCfg.Config, tel *metrics.InmemSink, mem *cluster.Member) *SystemServer {
 	return &SystemServer{
 		logger:    l,
 		member:    mem,
@@ -79,7 +83,8 @@ func (s *SystemServer) GetHealth(w http.ResponseWriter, r *http.Request) {
 
 func (s *SystemServer) GetInfo(w http.ResponseWriter, r *http.Request) {
 	resp := &SystemInfoResp{
-		NomadAddress:              s.nomad.Address(),
+		NomadAddress:              s.nomad.Primary().Address(),
+		NomadRegions:              s.nomad.RegionAddresses(),
 		StrictPolicyChecking:      s.server.StrictPolicyChecking,
 		InternalAutoScalingEngine: s.server.InternalAutoScaler,
 		PolicyEngine:              defaultDisabledPolicyResp,
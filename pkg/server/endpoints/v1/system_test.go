@@ -24,6 +24,7 @@ func TestSystem_GetHealth(t *testing.T) {
 func TestSystem_GetInfo(t *testing.T) {
 	testCases := []struct {
 		systemServerConfig *server.Config
+		nomadRegionAddrs   []string
 		expectedRespCode   int
 		expectedRespBody   string
 	}{
@@ -47,15 +48,24 @@ func TestSystem_GetInfo(t *testing.T) {
 			expectedRespCode:   200,
 			expectedRespBody:   "{\"NomadAddress\":\"http://127.0.0.1:4646\",\"PolicyEngine\":\"Disabled\",\"StorageBackend\":\"In Memory\",\"InternalAutoScalingEngine\":false,\"StrictPolicyChecking\":false}",
 		},
+		{
+			systemServerConfig: &server.Config{},
+			nomadRegionAddrs:   []string{"eu-west-1=http://10.0.0.1:4646"},
+			expectedRespCode:   200,
+			expectedRespBody:   "{\"NomadAddress\":\"http://127.0.0.1:4646\",\"NomadRegions\":{\"eu-west-1\":\"http://10.0.0.1:4646\"},\"PolicyEngine\":\"Disabled\",\"StorageBackend\":\"In Memory\",\"InternalAutoScalingEngine\":false,\"StrictPolicyChecking\":false}",
+		},
 	}
 
-	nomadClient, _ := client.NewNomadClient()
+	nomadClient, _ := client.NewNomadClient("")
 
 	for _, tc := range testCases {
+		regions, err := client.NewNomadRegionClients(tc.nomadRegionAddrs, nil, "")
+		assert.Nil(t, err)
+
 		r := httptest.NewRequest("GET", "http://jrasell.com/v1/system/info", nil)
 		w := httptest.NewRecorder()
 
-		s := NewSystemServer(zerolog.Logger{}, nomadClient, tc.systemServerConfig, nil, nil)
+		s := NewSystemServer(zerolog.Logger{}, client.NewNomadTargets(nomadClient, regions), tc.systemServerConfig, nil, nil)
 		s.GetInfo(w, r)
 
 		assert.Equal(t, tc.expectedRespCode, w.Code)
@@ -83,6 +83,7 @@ var tmplScaleEvent = template.Must(template.New("scaling-events").Parse(`
             var $table = $('table.events');
             var thead = '<thead><tr>';
             thead += '<th>ID</th>';
+            thead += '<th>Region</th>';
             thead += '<th>Job:Group</th>';
             thead += '<th>Direction</th>';
             thead += '<th>Count</th>';
@@ -92,9 +93,14 @@ var tmplScaleEvent = template.Must(template.New("scaling-events").Parse(`
             var $tbody = $('<tbody />');
             for (var [id, job] of Object.entries(events)) {
                 for (var [jbname, event] of Object.entries(job)) {
+                    var region = regionOf(jbname);
+                    if (params.region !== undefined && decodeURIComponent(params.region) !== region) {
+                        continue;
+                    }
                     var $tr = $('<tr />');
                     $tr.append($('<td />').text(id));
-                    $tr.append($('<td />').text(jbname));
+                    $tr.append($('<td />').text(region === '' ? 'primary' : region));
+                    $tr.append($('<td />').text(jbname.substring(region === '' ? 0 : region.length + 1)));
                     $tr.append($('<td />').text(event.Details.Direction))
                     $tr.append($('<td />').text(event.Details.Count))
                     $tr.append($('<td />').text(event.Status));
@@ -106,6 +112,17 @@ var tmplScaleEvent = template.Must(template.New("scaling-events").Parse(`
             $table.empty().append($(thead)).append($tbody);
         }
 
+        // Jobs of Nomad targets other than the primary are identified as region@job, where the
+        // region always precedes any namespace separator.
+        function regionOf(key){
+            var i = key.indexOf('@');
+            var s = key.indexOf('/');
+            if (i > 0 && (s < 0 || i < s)) {
+                return key.substring(0, i);
+            }
+            return '';
+        }
+
         function timeConverter(ts){
             var a = new Date(ts/1000000);
             var year = a.getUTCFullYear();
@@ -118,9 +135,18 @@ var tmplScaleEvent = template.Must(template.New("scaling-events").Parse(`
             return year + "-" + month + "-" + date + " " + hour + ':' + min + ':' + sec + "." + ms + " +0000 UTC"
         }		
 
-        $.get("/v1/scale/status", function(data) {
-            renderEvents(data);
-        });
+        function refresh() {
+            $.get("/v1/scale/status", function(data) {
+                renderEvents(data);
+            });
+        }
+
+        refresh();
+
+        // Refresh the events whenever a new scaling event is streamed by the server.
+        if (window.EventSource !== undefined) {
+            new EventSource("/v1/scale/stream?topic=ScalingEvent").addEventListener("ScalingEvent", refresh);
+        }
     })
 </script>
 
@@ -19,6 +19,11 @@ func (h *HTTPServer) runGarbageCollectionLoop() {
 		case <-t.C:
 			h.logger.Debug().Msg("triggering internal run of state garbage collection")
 			h.stateBackend.RunGarbageCollection()
+			h.breachBackend.RunGarbageCollection()
+			h.baselineBackend.RunGarbageCollection()
+			if h.evaluationBackend != nil {
+				h.evaluationBackend.RunGarbageCollection()
+			}
 		}
 	}
 }
@@ -1,10 +1,20 @@
 package server
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
+	"strings"
 
+	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/acl"
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/jrasell/sherpa/pkg/server/cluster"
+	"github.com/jrasell/sherpa/pkg/state"
 )
 
 // leaderProtectedHandler is a HTTP handler to be used on all endpoints which require a response
@@ -61,7 +71,8 @@ func standbyResponseHandler(mem *cluster.Member, w http.ResponseWriter, reqURL *
 		Scheme:   advertiseURL.Scheme,
 		Host:     advertiseURL.Host,
 		Path:     reqURL.Path,
-		RawQuery: reqURL.RawPath,
+		RawPath:  reqURL.RawPath,
+		RawQuery: reqURL.RawQuery,
 	}
 
 	if redirectURL.Scheme == "" {
@@ -71,3 +82,177 @@ func standbyResponseHandler(mem *cluster.Member, w http.ResponseWriter, reqURL *
 	w.Header().Set("Location", redirectURL.String())
 	w.WriteHeader(http.StatusTemporaryRedirect)
 }
+
+// aclProtectedHandler is a HTTP handler to be used on all endpoints which require the request ACL
+// token to grant the capability. Endpoints which reference a job are scoped to that job, so that
+// the token rule job prefixes are applied. If ACLs are not enabled, the resolver is nil and the
+// handler is returned untouched.
+func aclProtectedHandler(resolver *acl.Resolver, capability string, handler http.HandlerFunc) http.HandlerFunc {
+	return aclHandler(resolver, handler, func(t *state.ACLToken, r *http.Request) bool {
+		return acl.Allowed(t, capability, aclJobScope(r))
+	})
+}
+
+// aclManagementHandler is a HTTP handler to be used on endpoints which require a management ACL
+// token.
+func aclManagementHandler(resolver *acl.Resolver, handler http.HandlerFunc) http.HandlerFunc {
+	return aclHandler(resolver, handler, func(t *state.ACLToken, _ *http.Request) bool {
+		return t.Management
+	})
+}
+
+// aclAuthenticatedHandler is a HTTP handler to be used on endpoints which only require the request
+// to use a valid ACL token.
+func aclAuthenticatedHandler(resolver *acl.Resolver, handler http.HandlerFunc) http.HandlerFunc {
+	return aclHandler(resolver, handler, func(_ *state.ACLToken, _ *http.Request) bool {
+		return true
+	})
+}
+
+func aclHandler(resolver *acl.Resolver, handler http.HandlerFunc,
+	authorize func(*state.ACLToken, *http.Request) bool) http.HandlerFunc {
+
+	if resolver == nil {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		t, err := resolver.Resolve(r.Header.Get(acl.TokenHeader))
+		switch {
+		case err == acl.ErrTokenNotFound:
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// Record the token on the audit entry before authorizing, so that denied requests are
+		// attributed.
+		if e := audit.EntryFromContext(r.Context()); e != nil {
+			e.TokenAccessorID = t.AccessorID
+			e.TokenName = t.Name
+		}
+
+		if !authorize(t, r) {
+			http.Error(w, "Permission denied", http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(acl.ContextWithToken(r.Context(), t)))
+	}
+}
+
+// aclJobScope returns the Sherpa job identifier referenced by the request, or an empty string if
+// the endpoint is not scoped to a single job.
+func aclJobScope(r *http.Request) string {
+	if _, ok := mux.Vars(r)["job_id"]; !ok {
+		return ""
+	}
+	return helper.JobIDFromRequest(r)
+}
+
+// maxAuditErrorLen is the maximum number of bytes of a failed response body recorded as the audit
+// entry error.
+const maxAuditErrorLen = 4096
+
+// auditSnapshotFunc returns a JSON snapshot of the state modified by a request, such as a scaling
+// policy, or nil if the state does not exist.
+type auditSnapshotFunc func(r *http.Request) json.RawMessage
+
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+// auditHandler is a HTTP handler to be used on all endpoints which mutate state, recording an
+// audit entry for every request including those which are denied. If a snapshot function is
+// passed, it is called before and after the handler to record the change made by the request. If
+// the audit log is not enabled, the auditor is nil and the handler is returned untouched.
+func auditHandler(auditor *audit.Auditor, operation string, snapshot auditSnapshotFunc,
+	handler http.HandlerFunc) http.HandlerFunc {
+
+	if auditor == nil {
+		return handler
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		e := audit.NewEntry()
+		e.Operation = operation
+		e.Method = r.Method
+		e.Path = r.URL.Path
+		e.Group = mux.Vars(r)["group"]
+		e.Job = aclJobScope(r)
+		e.Identity = helper.IdentityFromContext(r.Context())
+
+		e.RemoteAddr = r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			e.RemoteAddr = host
+		}
+
+		// Read the request body so that it can be recorded, replacing it so the handler is still
+		// able to read it.
+		if r.Body != nil {
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "failed to read request body", http.StatusInternalServerError)
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(b))
+			e.RequestBody = auditRequestBody(b)
+		}
+
+		var before json.RawMessage
+		if snapshot != nil {
+			before = snapshot(r)
+		}
+
+		rw := &auditResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(rw, r.WithContext(audit.ContextWithEntry(r.Context(), e)))
+
+		if snapshot != nil {
+			if after := snapshot(r); before != nil || after != nil {
+				e.PolicyDiff = &audit.PolicyDiff{Before: before, After: after}
+			}
+		}
+
+		e.ResponseCode = rw.statusCode
+		e.Result = audit.ResultSuccess
+		if rw.statusCode >= http.StatusBadRequest {
+			e.Result = audit.ResultFailure
+			e.Error = strings.TrimSpace(rw.body.String())
+		}
+		auditor.Record(e)
+	}
+}
+
+// auditRequestBody returns the request body as raw JSON if it is valid JSON, otherwise as a JSON
+// string.
+func auditRequestBody(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return nil
+	}
+	if json.Valid(b) {
+		return b
+	}
+	out, _ := json.Marshal(string(b))
+	return out
+}
+
+func (arw *auditResponseWriter) WriteHeader(code int) {
+	arw.statusCode = code
+	arw.ResponseWriter.WriteHeader(code)
+}
+
+// Write passes the response through to the client, capturing the body of failed responses so it
+// can be recorded as the audit entry error.
+func (arw *auditResponseWriter) Write(b []byte) (int, error) {
+	if arw.statusCode >= http.StatusBadRequest && arw.body.Len() < maxAuditErrorLen {
+		remaining := maxAuditErrorLen - arw.body.Len()
+		if len(b) < remaining {
+			remaining = len(b)
+		}
+		arw.body.Write(b[:remaining])
+	}
+	return arw.ResponseWriter.Write(b)
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jrasell/sherpa/pkg/acl"
+	"github.com/jrasell/sherpa/pkg/audit"
+	"github.com/jrasell/sherpa/pkg/server/cluster"
+	"github.com/jrasell/sherpa/pkg/state"
+	clusterState "github.com/jrasell/sherpa/pkg/state/cluster"
+	"github.com/jrasell/sherpa/pkg/state/token/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_aclProtectedHandler(t *testing.T) {
+	backend := memory.NewStateBackend()
+	assert.Nil(t, backend.PutToken(&state.ACLToken{
+		AccessorID: "accessor",
+		SecretID:   "scale-platform",
+		Rules:      []*state.ACLRule{{JobPrefix: "platform/", Capabilities: []string{acl.CapabilityScale}}},
+	}))
+	resolver := acl.NewResolver("bootstrap", backend)
+
+	okHandler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	testCases := []struct {
+		resolver     *acl.Resolver
+		token        string
+		vars         map[string]string
+		query        string
+		expectedCode int
+		name         string
+	}{
+		{resolver: nil, expectedCode: http.StatusOK, name: "ACLs disabled"},
+		{resolver: resolver, expectedCode: http.StatusForbidden, name: "no token"},
+		{resolver: resolver, token: "unknown", expectedCode: http.StatusForbidden, name: "unknown token"},
+		{resolver: resolver, token: "bootstrap", expectedCode: http.StatusOK, name: "bootstrap token"},
+		{
+			resolver:     resolver,
+			token:        "scale-platform",
+			vars:         map[string]string{"job_id": "example"},
+			query:        "?namespace=platform",
+			expectedCode: http.StatusOK,
+			name:         "matching job prefix",
+		},
+		{
+			resolver:     resolver,
+			token:        "scale-platform",
+			vars:         map[string]string{"job_id": "example"},
+			expectedCode: http.StatusForbidden,
+			name:         "non-matching job prefix",
+		},
+		{resolver: resolver, token: "scale-platform", expectedCode: http.StatusForbidden, name: "no job scope"},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/v1/scale/out/example/group"+tc.query, nil)
+		if tc.token != "" {
+			req.Header.Set(acl.TokenHeader, tc.token)
+		}
+		if tc.vars != nil {
+			req = mux.SetURLVars(req, tc.vars)
+		}
+
+		rec := httptest.NewRecorder()
+		aclProtectedHandler(tc.resolver, acl.CapabilityScale, okHandler).ServeHTTP(rec, req)
+		assert.Equal(t, tc.expectedCode, rec.Code, tc.name)
+	}
+}
+
+type testAuditSink struct {
+	entries []*audit.Entry
+}
+
+func (s *testAuditSink) Name() string { return "test" }
+
+func (s *testAuditSink) Write(e *audit.Entry) error {
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+func Test_auditHandler(t *testing.T) {
+	backend := memory.NewStateBackend()
+	assert.Nil(t, backend.PutToken(&state.ACLToken{
+		AccessorID: "accessor",
+		SecretID:   "scale-platform",
+		Name:       "platform",
+		Rules:      []*state.ACLRule{{JobPrefix: "platform/", Capabilities: []string{acl.CapabilityScale}}},
+	}))
+	resolver := acl.NewResolver("bootstrap", backend)
+
+	sink := &testAuditSink{}
+	auditor := audit.NewAuditor(zerolog.Nop(), sink)
+
+	// The wrapped handler should still be able to read the request body.
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, `{"Count":2}`, string(b))
+		w.WriteHeader(http.StatusOK)
+	}
+
+	testCases := []struct {
+		query          string
+		expectedCode   int
+		expectedResult string
+		expectedJob    string
+		name           string
+	}{
+		{
+			query:          "?namespace=platform",
+			expectedCode:   http.StatusOK,
+			expectedResult: audit.ResultSuccess,
+			expectedJob:    "platform/example",
+			name:           "allowed request",
+		},
+		{
+			expectedCode:   http.StatusForbidden,
+			expectedResult: audit.ResultFailure,
+			expectedJob:    "example",
+			name:           "denied request",
+		},
+	}
+
+	for i, tc := range testCases {
+		req := httptest.NewRequest(http.MethodPost, "/v1/scale/out/example/group"+tc.query, strings.NewReader(`{"Count":2}`))
+		req.Header.Set(acl.TokenHeader, "scale-platform")
+		req = mux.SetURLVars(req, map[string]string{"job_id": "example", "group": "group"})
+
+		rec := httptest.NewRecorder()
+		auditHandler(auditor, routePostScaleOutJobGroupName, nil,
+			aclProtectedHandler(resolver, acl.CapabilityScale, handler)).ServeHTTP(rec, req)
+		assert.Equal(t, tc.expectedCode, rec.Code, tc.name)
+
+		assert.Len(t, sink.entries, i+1, tc.name)
+		e := sink.entries[i]
+		assert.Equal(t, routePostScaleOutJobGroupName, e.Operation, tc.name)
+		assert.Equal(t, tc.expectedJob, e.Job, tc.name)
+		assert.Equal(t, "group", e.Group, tc.name)
+		assert.Equal(t, "192.0.2.1", e.RemoteAddr, tc.name)
+		assert.Equal(t, "accessor", e.TokenAccessorID, tc.name)
+		assert.Equal(t, "platform", e.TokenName, tc.name)
+		assert.JSONEq(t, `{"Count":2}`, string(e.RequestBody), tc.name)
+		assert.Equal(t, tc.expectedCode, e.ResponseCode, tc.name)
+		assert.Equal(t, tc.expectedResult, e.Result, tc.name)
+	}
+	assert.Equal(t, "Permission denied", sink.entries[1].Error)
+}
+
+// fakeClusterBackend is a cluster storage backend in which another Sherpa server holds the
+// leadership lock.
+type fakeClusterBackend struct {
+	clusterState.Backend
+	leader *state.ClusterMember
+}
+
+func (f *fakeClusterBackend) GetClusterInfo() (*state.ClusterInfo, error) {
+	return &state.ClusterInfo{ID: f.leader.ID, Name: "sherpa-test"}, nil
+}
+
+func (f *fakeClusterBackend) GetClusterLeader(_ string) (*state.ClusterMember, error) {
+	return f.leader, nil
+}
+
+func (f *fakeClusterBackend) Lock(_ string) (clusterState.BackendLock, error) {
+	return &fakeClusterLock{value: f.leader.ID.String()}, nil
+}
+
+func (f *fakeClusterBackend) SupportsHA() bool { return true }
+
+type fakeClusterLock struct {
+	clusterState.BackendLock
+	value string
+}
+
+func (f *fakeClusterLock) Value() (bool, string, error) { return true, f.value, nil }
+
+func Test_leaderProtectedHandler(t *testing.T) {
+	leader := &state.ClusterMember{
+		ID:            uuid.Must(uuid.NewV4()),
+		Addr:          "http://10.0.0.2:8000",
+		AdvertiseAddr: "https://sherpa-leader.example.com:8000",
+	}
+
+	mem, err := cluster.NewMember(zerolog.Nop(), &fakeClusterBackend{leader: leader}, "http://10.0.0.1:8000", "", "sherpa-test")
+	assert.Nil(t, err)
+
+	handler := leaderProtectedHandler(mem, func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/scale/out/batch%2Fperiodic-1/cache?namespace=platform&region=eu-west-1", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	assert.Equal(t, "https://sherpa-leader.example.com:8000/v1/scale/out/batch%2Fperiodic-1/cache?namespace=platform&region=eu-west-1",
+		w.Header().Get("Location"))
+}
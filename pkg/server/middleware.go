@@ -3,6 +3,7 @@ package server
 import (
 	"net/http"
 
+	"github.com/jrasell/sherpa/pkg/helper"
 	"github.com/rs/zerolog"
 )
 
@@ -17,12 +18,28 @@ func middlewareLogger(h http.Handler, l zerolog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		respWriter := newLoggingResponseWriter(w)
 		h.ServeHTTP(respWriter, req)
-		l.Info().
+
+		event := l.Info().
 			Str("method", req.Method).
 			Str("path", req.RequestURI).
 			Str("remote-addr", req.RemoteAddr).
-			Int("response-code", respWriter.statusCode).
-			Msg("server responded to request")
+			Int("response-code", respWriter.statusCode)
+
+		if identity := helper.IdentityFromContext(req.Context()); identity != "" {
+			event.Str("identity", identity)
+		}
+		event.Msg("server responded to request")
+	})
+}
+
+// middlewareIdentity identifies the client using its verified TLS certificate, storing the
+// identity in the request context so that it can be logged and recorded on scaling events.
+func middlewareIdentity(h http.Handler, source string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if identity := helper.RequestCertificateIdentity(req, source); identity != "" {
+			req = req.WithContext(helper.ContextWithIdentity(req.Context(), identity))
+		}
+		h.ServeHTTP(w, req)
 	})
 }
 
@@ -34,3 +51,11 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.statusCode = code
 	lrw.ResponseWriter.WriteHeader(lrw.statusCode)
 }
+
+// Flush passes flushes through to the underlying response writer, so that streaming handlers are
+// able to flush responses through the logging middleware.
+func (lrw *loggingResponseWriter) Flush() {
+	if f, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -1,9 +1,18 @@
 package server
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/pprof"
 
+	"github.com/gorilla/mux"
+
+	"github.com/jrasell/sherpa/pkg/acl"
+	aclV1 "github.com/jrasell/sherpa/pkg/acl/v1"
+	auditV1 "github.com/jrasell/sherpa/pkg/audit/v1"
+	autoscaleV1 "github.com/jrasell/sherpa/pkg/autoscale/v1"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/policy"
 	policyV1 "github.com/jrasell/sherpa/pkg/policy/v1"
 	scaleV1 "github.com/jrasell/sherpa/pkg/scale/v1"
 	v1 "github.com/jrasell/sherpa/pkg/server/endpoints/v1"
@@ -11,10 +20,13 @@ import (
 )
 
 type routes struct {
-	System *v1.SystemServer
-	Policy *policyV1.Policy
-	Scale  *scaleV1.Scale
-	UI     *v1.UIServer
+	ACL       *aclV1.ACL
+	Audit     *auditV1.Audit
+	AutoScale *autoscaleV1.AutoScale
+	System    *v1.SystemServer
+	Policy    *policyV1.Policy
+	Scale     *scaleV1.Scale
+	UI        *v1.UIServer
 }
 
 func (h *HTTPServer) setupRoutes() *router.RouteTable {
@@ -34,6 +46,18 @@ func (h *HTTPServer) setupRoutes() *router.RouteTable {
 	policyRoutes := h.setupPolicyRoutes()
 	r = append(r, policyRoutes)
 
+	// Setup the autoscaler routes if the internal autoscaler is enabled.
+	if h.cfg.Server.InternalAutoScaler {
+		autoscaleRoutes := h.setupAutoScaleRoutes()
+		r = append(r, autoscaleRoutes)
+	}
+
+	// Setup the ACL token routes if ACLs are enabled.
+	if h.cfg.Server.ACLEnabled {
+		aclRoutes := h.setupACLRoutes()
+		r = append(r, aclRoutes)
+	}
+
 	// Setup the server debug routes if enabled.
 	if h.cfg.Debug {
 		debugRoutes := h.setupDebugRoutes()
@@ -84,6 +108,7 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 		Policy: h.policyBackend,
 		Scale:  h.scaleBackend,
 		State:  h.stateBackend,
+		Hub:    h.hub,
 	})
 
 	return router.Routes{
@@ -93,7 +118,7 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 			Name:    routeScaleOutJobGroupName,
 			Method:  http.MethodPut,
 			Pattern: routeScaleOutJobGroupPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.OutJobGroup),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routeScaleOutJobGroupName, nil, aclProtectedHandler(h.aclResolver, acl.CapabilityScale, h.routes.Scale.OutJobGroup))),
 		},
 		// Deprecated: the PUT method is deprecated in favour of POST and will be removed in a
 		// future release.
@@ -101,33 +126,93 @@ func (h *HTTPServer) setupScaleRoutes() []router.Route {
 			Name:    routeScaleInJobGroupName,
 			Method:  http.MethodPut,
 			Pattern: routeScaleInJobGroupPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.InJobGroup),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routeScaleInJobGroupName, nil, aclProtectedHandler(h.aclResolver, acl.CapabilityScale, h.routes.Scale.InJobGroup))),
 		},
 		router.Route{
 			Name:    routePostScaleOutJobGroupName,
 			Method:  http.MethodPost,
 			Pattern: routePostScaleOutJobGroupPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.OutJobGroup),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostScaleOutJobGroupName, nil, aclProtectedHandler(h.aclResolver, acl.CapabilityScale, h.routes.Scale.OutJobGroup))),
 		},
 		router.Route{
 			Name:    routePostScaleInJobGroupName,
 			Method:  http.MethodPost,
 			Pattern: routePostScaleInJobGroupPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.InJobGroup),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostScaleInJobGroupName, nil, aclProtectedHandler(h.aclResolver, acl.CapabilityScale, h.routes.Scale.InJobGroup))),
+		},
+		router.Route{
+			Name:    routePostScaleSetJobGroupName,
+			Method:  http.MethodPost,
+			Pattern: routePostScaleSetJobGroupPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostScaleSetJobGroupName, nil, aclProtectedHandler(h.aclResolver, acl.CapabilityScale, h.routes.Scale.SetJobGroup))),
 		},
 		router.Route{
 			Name:    routeGetScalingStatusName,
 			Method:  http.MethodGet,
 			Pattern: routeGetScalingStatusPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.StatusList),
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Scale.StatusList)),
+		},
+		router.Route{
+			Name:    routeGetScalingStreamName,
+			Method:  http.MethodGet,
+			Pattern: routeGetScalingStreamPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Scale.Stream)),
 		},
 		router.Route{
 			Name:    routeGetScalingInfoName,
 			Method:  http.MethodGet,
 			Pattern: routeGetScalingInfoPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Scale.StatusInfo),
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Scale.StatusInfo)),
+		},
+	}
+}
+
+func (h *HTTPServer) setupAutoScaleRoutes() []router.Route {
+	h.logger.Debug().Msg("setting up server autoscale routes")
+
+	h.routes.AutoScale = autoscaleV1.NewAutoScaleServer(&autoscaleV1.AutoScaleConfig{
+		Logger:     h.logger,
+		Nomad:      h.nomad,
+		Policy:     h.policyBackend,
+		Predictor:  h.predictor,
+		AutoScaler: h.autoScale,
+		History:    h.evaluationBackend,
+	})
+
+	routes := router.Routes{
+		router.Route{
+			Name:    routeGetAutoScalePredictJobGroupName,
+			Method:  http.MethodGet,
+			Pattern: routeGetAutoScalePredictJobGroupPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.AutoScale.PredictJobGroup)),
+		},
+		router.Route{
+			Name:    routeGetAutoScaleEvaluateJobName,
+			Method:  http.MethodGet,
+			Pattern: routeGetAutoScaleEvaluateJobPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.AutoScale.EvaluateJob)),
 		},
 	}
+
+	// The evaluation history routes are only available if evaluation history is enabled.
+	if h.evaluationBackend == nil {
+		return routes
+	}
+
+	return append(routes,
+		router.Route{
+			Name:    routeGetAutoScaleJobHistoryName,
+			Method:  http.MethodGet,
+			Pattern: routeGetAutoScaleJobHistoryPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.AutoScale.JobHistory)),
+		},
+		router.Route{
+			Name:    routeGetAutoScaleJobGroupHistoryName,
+			Method:  http.MethodGet,
+			Pattern: routeGetAutoScaleJobGroupHistoryPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.AutoScale.JobGroupHistory)),
+		},
+	)
 }
 
 func (h *HTTPServer) setupSystemRoutes() []router.Route {
@@ -135,7 +220,7 @@ func (h *HTTPServer) setupSystemRoutes() []router.Route {
 
 	h.routes.System = v1.NewSystemServer(h.logger, h.nomad, h.cfg.Server, h.telemetry, h.clusterMember)
 
-	return router.Routes{
+	routes := router.Routes{
 		router.Route{
 			Name:        routeSystemHealthName,
 			Method:      http.MethodGet,
@@ -146,21 +231,37 @@ func (h *HTTPServer) setupSystemRoutes() []router.Route {
 			Name:        routeSystemInfoName,
 			Method:      http.MethodGet,
 			Pattern:     routeSystemInfoPattern,
-			HandlerFunc: h.routes.System.GetInfo,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, h.routes.System.GetInfo),
 		},
 		router.Route{
 			Name:        routeGetMetricsName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetMetricsPattern,
-			HandlerFunc: h.routes.System.GetMetrics,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, h.routes.System.GetMetrics),
 		},
 		router.Route{
 			Name:        routeGetSystemLeaderName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetSystemLeaderPattern,
-			HandlerFunc: h.routes.System.GetLeader,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, h.routes.System.GetLeader),
 		},
 	}
+
+	// The audit log route is only available if the audit log is enabled.
+	if h.auditor == nil {
+		return routes
+	}
+
+	h.routes.Audit = auditV1.NewAuditServer(h.logger, h.auditor)
+
+	return append(routes,
+		router.Route{
+			Name:    routeGetSystemAuditName,
+			Method:  http.MethodGet,
+			Pattern: routeGetSystemAuditPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, h.routes.Audit.ListEntries)),
+		},
+	)
 }
 
 func (h *HTTPServer) setupPolicyRoutes() []router.Route {
@@ -173,19 +274,19 @@ func (h *HTTPServer) setupPolicyRoutes() []router.Route {
 			Name:    routeGetJobScalingPoliciesName,
 			Method:  http.MethodGet,
 			Pattern: routeGetJobScalingPoliciesPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.GetJobPolicies),
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Policy.GetJobPolicies)),
 		},
 		router.Route{
 			Name:    routeGetJobScalingPolicyName,
 			Method:  http.MethodGet,
 			Pattern: routeGetJobScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.GetJobPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Policy.GetJobPolicy)),
 		},
 		router.Route{
 			Name:    routeGetJobGroupScalingPolicyName,
 			Method:  http.MethodGet,
 			Pattern: routeGetJobGroupScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.GetJobGroupPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, aclProtectedHandler(h.aclResolver, acl.CapabilityRead, h.routes.Policy.GetJobGroupPolicy)),
 		},
 	}
 }
@@ -198,25 +299,102 @@ func (h *HTTPServer) setupAPIPolicyRoutes() []router.Route {
 			Name:    routePostJobScalingPolicyName,
 			Method:  http.MethodPost,
 			Pattern: routePutJobScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.PutJobPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostJobScalingPolicyName, h.auditPolicySnapshot, aclProtectedHandler(h.aclResolver, acl.CapabilityPolicyWrite, h.routes.Policy.PutJobPolicy))),
 		},
 		router.Route{
 			Name:    routePostJobGroupScalingPolicyName,
 			Method:  http.MethodPost,
 			Pattern: routePutJobGroupScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.PutJobGroupPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostJobGroupScalingPolicyName, h.auditPolicySnapshot, aclProtectedHandler(h.aclResolver, acl.CapabilityPolicyWrite, h.routes.Policy.PutJobGroupPolicy))),
 		},
 		router.Route{
 			Name:    routeDeleteJobGroupScalingPolicyName,
 			Method:  http.MethodDelete,
 			Pattern: routeDeleteJobGroupScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.DeleteJobGroupPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routeDeleteJobGroupScalingPolicyName, h.auditPolicySnapshot, aclProtectedHandler(h.aclResolver, acl.CapabilityPolicyWrite, h.routes.Policy.DeleteJobGroupPolicy))),
 		},
 		router.Route{
 			Name:    routeDeleteJobScalingPolicyName,
 			Method:  http.MethodDelete,
 			Pattern: routeDeleteJobScalingPolicyPattern,
-			Handler: leaderProtectedHandler(h.clusterMember, h.routes.Policy.DeleteJobPolicy),
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routeDeleteJobScalingPolicyName, h.auditPolicySnapshot, aclProtectedHandler(h.aclResolver, acl.CapabilityPolicyWrite, h.routes.Policy.DeleteJobPolicy))),
+		},
+	}
+}
+
+// auditPolicySnapshot returns the stored scaling policy of the job, or job group, referenced by
+// the request so that policy changes can be recorded in the audit log.
+func (h *HTTPServer) auditPolicySnapshot(r *http.Request) json.RawMessage {
+	job := helper.JobIDFromRequest(r)
+
+	var (
+		p   interface{}
+		err error
+	)
+
+	if group, ok := mux.Vars(r)["group"]; ok {
+		var gp *policy.GroupScalingPolicy
+		if gp, err = h.policyBackend.GetJobGroupPolicy(job, group); gp != nil {
+			p = gp
+		}
+	} else {
+		var jp map[string]*policy.GroupScalingPolicy
+		if jp, err = h.policyBackend.GetJobPolicy(job); jp != nil {
+			p = jp
+		}
+	}
+
+	if err != nil {
+		h.logger.Error().Err(err).Str("job", job).Msg("failed to snapshot scaling policy for audit log")
+		return nil
+	}
+	if p == nil {
+		return nil
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		h.logger.Error().Err(err).Str("job", job).Msg("failed to marshal scaling policy for audit log")
+		return nil
+	}
+	return out
+}
+
+func (h *HTTPServer) setupACLRoutes() []router.Route {
+	h.logger.Debug().Msg("setting up server ACL routes")
+
+	h.routes.ACL = aclV1.NewACLServer(h.logger, h.tokenBackend)
+
+	return router.Routes{
+		router.Route{
+			Name:    routeGetACLTokensName,
+			Method:  http.MethodGet,
+			Pattern: routeGetACLTokensPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclManagementHandler(h.aclResolver, h.routes.ACL.ListTokens)),
+		},
+		router.Route{
+			Name:    routePostACLTokenName,
+			Method:  http.MethodPost,
+			Pattern: routePostACLTokenPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routePostACLTokenName, nil, aclManagementHandler(h.aclResolver, h.routes.ACL.CreateToken))),
+		},
+		router.Route{
+			Name:    routeGetACLTokenSelfName,
+			Method:  http.MethodGet,
+			Pattern: routeGetACLTokenSelfPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclAuthenticatedHandler(h.aclResolver, h.routes.ACL.GetSelf)),
+		},
+		router.Route{
+			Name:    routeGetACLTokenName,
+			Method:  http.MethodGet,
+			Pattern: routeGetACLTokenPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, aclManagementHandler(h.aclResolver, h.routes.ACL.GetToken)),
+		},
+		router.Route{
+			Name:    routeDeleteACLTokenName,
+			Method:  http.MethodDelete,
+			Pattern: routeDeleteACLTokenPattern,
+			Handler: leaderProtectedHandler(h.clusterMember, auditHandler(h.auditor, routeDeleteACLTokenName, nil, aclManagementHandler(h.aclResolver, h.routes.ACL.DeleteToken))),
 		},
 	}
 }
@@ -229,31 +407,31 @@ func (h *HTTPServer) setupDebugRoutes() []router.Route {
 			Name:        routeGetDebugPPROFName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetDebugPPROFPattern,
-			HandlerFunc: pprof.Index,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, pprof.Index),
 		},
 		router.Route{
 			Name:        routeGetDebugPPROFCMDLineName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetDebugPPROFCMDLinePattern,
-			HandlerFunc: pprof.Cmdline,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, pprof.Cmdline),
 		},
 		router.Route{
 			Name:        routeGetDebugPPROFProfileName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetDebugPPROFProfilePattern,
-			HandlerFunc: pprof.Profile,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, pprof.Profile),
 		},
 		router.Route{
 			Name:        routeGetDebugPPROFSymbolName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetDebugPPROFSymbolPattern,
-			HandlerFunc: pprof.Symbol,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, pprof.Symbol),
 		},
 		router.Route{
 			Name:        routeGetDebugPPROFTraceName,
 			Method:      http.MethodGet,
 			Pattern:     routeGetDebugPPROFTracePattern,
-			HandlerFunc: pprof.Trace,
+			HandlerFunc: aclProtectedHandler(h.aclResolver, acl.CapabilitySystem, pprof.Trace),
 		},
 	}
 }
@@ -12,9 +12,16 @@ import (
 
 	"github.com/armon/go-metrics"
 	consulAPI "github.com/hashicorp/consul/api"
-	nomadAPI "github.com/hashicorp/nomad/api"
+	"github.com/jrasell/sherpa/pkg/acl"
+	"github.com/jrasell/sherpa/pkg/audit"
+	auditConsul "github.com/jrasell/sherpa/pkg/audit/consul"
+	auditFile "github.com/jrasell/sherpa/pkg/audit/file"
+	auditWebhook "github.com/jrasell/sherpa/pkg/audit/webhook"
 	"github.com/jrasell/sherpa/pkg/autoscale"
+	"github.com/jrasell/sherpa/pkg/autoscale/predictive"
 	"github.com/jrasell/sherpa/pkg/client"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/jrasell/sherpa/pkg/notify"
 	policyBackend "github.com/jrasell/sherpa/pkg/policy/backend"
 	"github.com/jrasell/sherpa/pkg/policy/backend/consul"
 	policyMemory "github.com/jrasell/sherpa/pkg/policy/backend/memory"
@@ -22,12 +29,25 @@ import (
 	"github.com/jrasell/sherpa/pkg/scale"
 	"github.com/jrasell/sherpa/pkg/server/cluster"
 	"github.com/jrasell/sherpa/pkg/server/router"
+	baselineBackend "github.com/jrasell/sherpa/pkg/state/baseline"
+	baselineConsul "github.com/jrasell/sherpa/pkg/state/baseline/consul"
+	baselineMemory "github.com/jrasell/sherpa/pkg/state/baseline/memory"
+	breachBackend "github.com/jrasell/sherpa/pkg/state/breach"
+	breachConsul "github.com/jrasell/sherpa/pkg/state/breach/consul"
+	breachMemory "github.com/jrasell/sherpa/pkg/state/breach/memory"
 	clusterBackend "github.com/jrasell/sherpa/pkg/state/cluster"
 	clusterConsul "github.com/jrasell/sherpa/pkg/state/cluster/consul"
 	clusterMemory "github.com/jrasell/sherpa/pkg/state/cluster/memory"
+	evaluationBackend "github.com/jrasell/sherpa/pkg/state/evaluation"
+	evaluationConsul "github.com/jrasell/sherpa/pkg/state/evaluation/consul"
+	evaluationMemory "github.com/jrasell/sherpa/pkg/state/evaluation/memory"
 	stateBackend "github.com/jrasell/sherpa/pkg/state/scale"
 	stateConsul "github.com/jrasell/sherpa/pkg/state/scale/consul"
 	stateMemory "github.com/jrasell/sherpa/pkg/state/scale/memory"
+	tokenBackend "github.com/jrasell/sherpa/pkg/state/token"
+	tokenConsul "github.com/jrasell/sherpa/pkg/state/token/consul"
+	tokenMemory "github.com/jrasell/sherpa/pkg/state/token/memory"
+	"github.com/jrasell/sherpa/pkg/stream"
 	"github.com/jrasell/sherpa/pkg/watcher"
 	"github.com/jrasell/sherpa/pkg/watcher/deployment"
 	"github.com/jrasell/sherpa/pkg/watcher/job"
@@ -45,12 +65,38 @@ type HTTPServer struct {
 	scaleBackend   scale.Scale
 	clusterBackend clusterBackend.Backend
 
-	// deploymentWatcher is used to watch deployments in order to update internal tracking.
-	deploymentWatcher watcher.Watcher
+	// baselineBackend stores the job group baselines used by the predictor.
+	baselineBackend baselineBackend.Backend
 
-	// nomadMetaWatcher is used to watch Nomad jobs in order to update policies based off the Nomad
-	// meta stanzas.
-	nomadMetaWatcher watcher.Watcher
+	// breachBackend stores the recent threshold check results of each job group, used by checks
+	// configured with breach periods.
+	breachBackend breachBackend.Backend
+
+	// evaluationBackend stores the autoscaler evaluation history of each job group. This is nil
+	// if evaluation history is disabled.
+	evaluationBackend evaluationBackend.Backend
+	predictor         *predictive.Predictor
+
+	// tokenBackend stores the ACL tokens used to authenticate API requests, and aclResolver uses
+	// it to resolve the token of each request. The resolver is nil if ACLs are disabled.
+	tokenBackend tokenBackend.Backend
+	aclResolver  *acl.Resolver
+
+	// auditor records mutating API operations to the configured audit sinks. This is nil if no
+	// audit sink is configured.
+	auditor *audit.Auditor
+
+	// hub distributes scaling, policy and leadership events to the event stream API subscribers.
+	hub *stream.Hub
+
+	// deploymentWatchers are used to watch deployments in order to update internal tracking. A
+	// watcher is run for each configured Nomad namespace of each Nomad target.
+	deploymentWatchers []watcher.Watcher
+
+	// nomadMetaWatchers are used to watch Nomad jobs in order to update policies based off the
+	// Nomad meta stanzas. A watcher is run for each configured Nomad namespace of each Nomad
+	// target.
+	nomadMetaWatchers []watcher.Watcher
 
 	// nomadMetaProcessor is the processor which is used to handle job updates and decide if their
 	// scaling meta policy has changed and should be reflected in storage.
@@ -58,8 +104,9 @@ type HTTPServer struct {
 
 	clusterMember *cluster.Member
 
-	// Store the Nomad and Consul API clients for resuse.
-	nomad  *nomadAPI.Client
+	// Store the Nomad and Consul API clients for resuse. Sherpa can manage multiple Nomad targets,
+	// each identified by its region.
+	nomad  *client.NomadTargets
 	consul *consulAPI.Client
 
 	autoScale *autoscale.AutoScale
@@ -68,6 +115,10 @@ type HTTPServer struct {
 	http.Server
 	routes *routes
 
+	// tlsReloader holds the server TLS certificates, and is nil if TLS is not configured. The
+	// certificates are reloaded when the server receives a SIGHUP.
+	tlsReloader *tlsReloader
+
 	// gcIsRunning is used to track whether this Sherpa server is currently running the garbage
 	// collection loop.
 	gcIsRunning bool
@@ -97,14 +148,18 @@ func (h *HTTPServer) Start() error {
 
 	go h.leaderUpdateHandler()
 
-	// Start the deployment watcher, using the scale deployment channel for updates.
-	go h.deploymentWatcher.Run(h.scaleBackend.GetDeploymentChannel())
+	// Start the deployment watchers, using the scale deployment channel for updates.
+	for _, w := range h.deploymentWatchers {
+		go w.Run(h.scaleBackend.GetDeploymentChannel())
+	}
 
 	// If the operator has configured the Nomad meta policy engine, we should start the processes
 	// which watch and handle updates.
-	if h.cfg.Server.NomadMetaPolicyEngine && h.nomadMetaWatcher != nil {
+	if h.cfg.Server.NomadMetaPolicyEngine && len(h.nomadMetaWatchers) > 0 {
 		go h.nomadMetaProcessor.Run()
-		go h.nomadMetaWatcher.Run(h.nomadMetaProcessor.GetUpdateChannel())
+		for _, w := range h.nomadMetaWatchers {
+			go w.Run(h.nomadMetaProcessor.GetUpdateChannel())
+		}
 	}
 
 	h.handleSignals()
@@ -117,6 +172,8 @@ func (h *HTTPServer) logServerConfig() {
 		Object("tls", h.cfg.TLS).
 		Object("telemetry", h.cfg.Telemetry).
 		Object("cluster", h.cfg.Cluster).
+		Object("audit", h.cfg.Audit).
+		Object("notifier", h.cfg.Notifier).
 		Msg("Sherpa server configuration")
 }
 
@@ -134,9 +191,16 @@ func (h *HTTPServer) setup() error {
 		return errors.Wrap(err, "failed to setup telemetry handler")
 	}
 
+	h.hub = stream.NewHub(h.logger)
 	h.setupStoredBackends()
 
-	h.setupScaler()
+	if err := h.setupAudit(); err != nil {
+		return errors.Wrap(err, "failed to setup audit log")
+	}
+
+	if err := h.setupScaler(); err != nil {
+		return errors.Wrap(err, "failed to setup scaling event notifiers")
+	}
 	go h.scaleBackend.RunDeploymentUpdateHandler()
 
 	h.setupDeploymentWatcher()
@@ -158,10 +222,14 @@ func (h *HTTPServer) setup() error {
 		}
 	}
 
+	if err := helper.ValidateIdentitySource(h.cfg.TLS.ClientIdentity); err != nil {
+		return err
+	}
+
 	initialRoutes := h.setupRoutes()
 
 	r := router.WithRoutes(h.logger, *initialRoutes)
-	http.Handle("/", middlewareLogger(r, h.logger))
+	http.Handle("/", middlewareIdentity(middlewareLogger(r, h.logger), h.cfg.TLS.ClientIdentity))
 
 	// Run the TLS setup process so that if the user has configured a TLS certificate pair the
 	// server uses these.
@@ -189,15 +257,36 @@ func (h *HTTPServer) setupTLS() error {
 	if h.cfg.TLS.CertPath != "" && h.cfg.TLS.CertKeyPath != "" {
 		h.logger.Debug().Msg("setting up server TLS")
 
-		cert, err := tls.LoadX509KeyPair(h.cfg.TLS.CertPath, h.cfg.TLS.CertKeyPath)
+		reloader, err := newTLSReloader(h.cfg.TLS)
 		if err != nil {
-			return errors.Wrap(err, "failed to load certificate cert/key pair")
+			return err
 		}
-		h.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+		h.tlsReloader = reloader
+		h.TLSConfig = reloader.tlsConfig()
+		return nil
+	}
+
+	if h.cfg.TLS.ClientCAPath != "" {
+		return errors.New("client certificate verification requires the server TLS certificate to be configured")
 	}
 	return nil
 }
 
+// reloadTLS reloads the server TLS certificate and client CA bundle from disk, so that they can be
+// rotated without restarting the server.
+func (h *HTTPServer) reloadTLS() {
+	if h.tlsReloader == nil {
+		h.logger.Info().Msg("server TLS is not configured, skipping certificate reload")
+		return
+	}
+
+	if err := h.tlsReloader.Reload(); err != nil {
+		h.logger.Error().Err(err).Msg("failed to reload server TLS certificates, continuing to use existing certificates")
+		return
+	}
+	h.logger.Info().Msg("successfully reloaded server TLS certificates")
+}
+
 func (h *HTTPServer) setupStoredBackends() {
 
 	// Setup the standard backends based on the operators storage type.
@@ -205,38 +294,124 @@ func (h *HTTPServer) setupStoredBackends() {
 		h.logger.Debug().Msg("setting up Consul storage backend")
 		h.stateBackend = stateConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
 		h.clusterBackend = clusterConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
+		h.baselineBackend = baselineConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
+		h.breachBackend = breachConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
+		h.tokenBackend = tokenConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
 	} else {
 		h.logger.Debug().Msg("setting up in-memory storage backend")
 		h.stateBackend = stateMemory.NewStateBackend()
 		h.clusterBackend = clusterMemory.NewStateBackend()
+		h.baselineBackend = baselineMemory.NewStateBackend()
+		h.breachBackend = breachMemory.NewStateBackend()
+		h.tokenBackend = tokenMemory.NewStateBackend()
 	}
+	h.setupACL()
+	h.setupEvaluationBackend()
 	h.setupPolicyBackend()
+	h.predictor = predictive.NewPredictor(h.logger, h.baselineBackend)
+}
+
+func (h *HTTPServer) setupACL() {
+	if !h.cfg.Server.ACLEnabled {
+		return
+	}
+
+	h.logger.Debug().Msg("setting up ACL token resolver")
+
+	if h.cfg.Server.ACLBootstrapToken == "" {
+		h.logger.Warn().Msg("ACLs are enabled without a bootstrap token, only stored tokens will be accepted")
+	}
+	h.aclResolver = acl.NewResolver(h.cfg.Server.ACLBootstrapToken, h.tokenBackend)
+}
+
+// setupAudit creates the auditor using the audit sinks configured by the operator. The Consul
+// sink is placed first so that it serves audit log queries in preference to the file sink, as its
+// entries are shared by all Sherpa servers and survive leadership changes.
+func (h *HTTPServer) setupAudit() error {
+	if !h.cfg.Audit.Enabled() {
+		return nil
+	}
+
+	h.logger.Debug().Msg("setting up audit log")
+
+	var sinks []audit.Sink
+
+	if h.cfg.Audit.ConsulEnabled {
+		s, err := auditConsul.NewSink(h.cfg.Server.ConsulStorageBackendPath, h.consul, h.cfg.Audit.ConsulRingSize)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+
+	if h.cfg.Audit.FilePath != "" {
+		s, err := auditFile.NewSink(h.cfg.Audit.FilePath)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+
+	if h.cfg.Audit.WebhookAddr != "" {
+		sinks = append(sinks, auditWebhook.NewSink(h.logger, h.cfg.Audit.WebhookAddr))
+	}
+
+	h.auditor = audit.NewAuditor(h.logger, sinks...)
+	return nil
+}
+
+func (h *HTTPServer) setupEvaluationBackend() {
+	size := h.cfg.Server.InternalAutoScalerHistorySize
+
+	// Evaluation history is only recorded by the internal autoscaler, and can be disabled by the
+	// operator to avoid the additional storage writes.
+	if !h.cfg.Server.InternalAutoScaler || size < 1 {
+		return
+	}
+
+	if h.cfg.Server.ConsulStorageBackend {
+		h.evaluationBackend = evaluationConsul.NewStateBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul, size)
+		return
+	}
+	h.evaluationBackend = evaluationMemory.NewStateBackend(size)
 }
 
 func (h *HTTPServer) setupPolicyBackend() {
 	h.logger.Debug().Msg("setting up policy backend")
 
 	if h.cfg.Server.NomadMetaPolicyEngine {
-		h.nomadMetaWatcher = job.NewWatcher(h.logger, h.nomad)
-		h.policyBackend, h.nomadMetaProcessor = nomadmeta.NewJobScalingPolicies(h.logger, h.nomad)
+		for _, region := range h.nomad.Regions() {
+			nc, _ := h.nomad.Client(region)
+			for _, ns := range h.cfg.Server.NomadNamespaces {
+				h.nomadMetaWatchers = append(h.nomadMetaWatchers, job.NewWatcher(h.logger, nc, region, ns))
+			}
+		}
+		h.policyBackend, h.nomadMetaProcessor = nomadmeta.NewJobScalingPolicies(h.logger, h.nomad, h.hub)
 		return
 	}
 
+	// Wrap the policy backend so that policy changes are published to event stream subscribers.
 	if h.cfg.Server.ConsulStorageBackend {
-		h.policyBackend = consul.NewConsulPolicyBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul)
+		h.policyBackend = stream.NewPolicyBackend(consul.NewConsulPolicyBackend(h.logger, h.cfg.Server.ConsulStorageBackendPath, h.consul), h.hub)
 		return
 	}
-	h.policyBackend = policyMemory.NewJobScalingPolicies()
+	h.policyBackend = stream.NewPolicyBackend(policyMemory.NewJobScalingPolicies(), h.hub)
 }
 
 func (h *HTTPServer) setupNomadClient() error {
 	h.logger.Debug().Msg("setting up Nomad client")
 
-	nc, err := client.NewNomadClient()
+	nc, err := client.NewNomadClient(h.cfg.Server.NomadToken)
+	if err != nil {
+		return err
+	}
+
+	regions, err := client.NewNomadRegionClients(h.cfg.Server.NomadRegionAddrs, h.cfg.Server.NomadRegionTokens,
+		h.cfg.Server.NomadToken)
 	if err != nil {
 		return err
 	}
-	h.nomad = nc
+	h.nomad = client.NewNomadTargets(nc, regions)
 
 	return nil
 }
@@ -257,6 +432,7 @@ func (h *HTTPServer) setupAutoScaling() error {
 	h.logger.Debug().Msg("setting up Sherpa internal auto-scaling engine")
 	autoscaleCfg := &autoscale.SetupConfig{
 		StrictChecking:    h.cfg.Server.StrictPolicyChecking,
+		DryRun:            h.cfg.Server.InternalAutoScalerDryRun,
 		ScalingInterval:   h.cfg.Server.InternalAutoScalerEvalPeriod,
 		ScalingThreads:    h.cfg.Server.InternalAutoScalerNumThreads,
 		MetricProviderCfg: h.cfg.MetricProvider,
@@ -264,6 +440,9 @@ func (h *HTTPServer) setupAutoScaling() error {
 		PolicyBackend:     h.policyBackend,
 		Scale:             h.scaleBackend,
 		Nomad:             h.nomad,
+		Predictor:         h.predictor,
+		History:           h.evaluationBackend,
+		Breach:            h.breachBackend,
 	}
 
 	as, err := autoscale.NewAutoScaleServer(autoscaleCfg)
@@ -275,12 +454,36 @@ func (h *HTTPServer) setupAutoScaling() error {
 	return nil
 }
 
-func (h *HTTPServer) setupScaler() {
-	h.scaleBackend = scale.NewScaler(h.nomad, h.logger, h.stateBackend, h.cfg.Server.StrictPolicyChecking)
+func (h *HTTPServer) setupScaler() error {
+	capacity := &scale.CapacityConfig{Enabled: h.cfg.Server.ScalerCapacityCheck}
+
+	if h.cfg.Server.ScalerCapacityWebhookAddr != "" {
+		capacity.Webhook = notify.NewJSONSender(h.cfg.Server.ScalerCapacityWebhookAddr, h.cfg.Notifier.Retries)
+	}
+
+	var notifier *notify.Dispatcher
+
+	if h.cfg.Notifier.Enabled() {
+		h.logger.Debug().Msg("setting up scaling event notifiers")
+
+		d, err := notify.NewDispatcherFromConfig(h.logger, h.cfg.Notifier)
+		if err != nil {
+			return err
+		}
+		notifier = d
+	}
+
+	h.scaleBackend = scale.NewScaler(h.nomad, h.logger, h.stateBackend, h.cfg.Server.StrictPolicyChecking, capacity, notifier, h.hub)
+	return nil
 }
 
 func (h *HTTPServer) setupDeploymentWatcher() {
-	h.deploymentWatcher = deployment.New(h.logger, h.nomad)
+	for _, region := range h.nomad.Regions() {
+		nc, _ := h.nomad.Client(region)
+		for _, ns := range h.cfg.Server.NomadNamespaces {
+			h.deploymentWatchers = append(h.deploymentWatchers, deployment.New(h.logger, nc, region, ns))
+		}
+	}
 }
 
 func (h *HTTPServer) setupListener() net.Listener {
@@ -313,6 +516,10 @@ func (h *HTTPServer) leaderUpdateHandler() {
 		case msg := <-h.clusterMember.UpdateChan:
 			h.logger.Debug().Str("leadership-msg", msg.Msg).Msg("server received leader update message")
 			h.handleLeaderUpdateMsg(msg.IsLeader)
+			h.hub.Publish(&stream.Event{
+				Topic:   stream.TopicLeadership,
+				Payload: &stream.LeadershipChange{IsLeader: msg.IsLeader, Addr: h.cfg.Cluster.Addr},
+			})
 		}
 	}
 }
@@ -356,6 +563,10 @@ func (h *HTTPServer) Stop() error {
 	// Send a signal to the HTTPServer stopChan instructing sub-process to stop.
 	close(h.stopChan)
 
+	// Close the event stream hub, which ends all event streams so that their connections do not
+	// prevent the HTTP server from shutting down.
+	h.hub.Close()
+
 	// When calling shutdown, the process will wait for all active connections to finish. This
 	// protects against interrupting scaling events triggered via the API.
 	return h.Shutdown(context.Background())
@@ -365,7 +576,7 @@ func (h *HTTPServer) Stop() error {
 // required by the Sherpa server.
 func (h *HTTPServer) handleSignals() {
 	signalCh := make(chan os.Signal, 1)
-	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
 	for {
 		select {
@@ -377,6 +588,8 @@ func (h *HTTPServer) handleSignals() {
 				}
 				h.logger.Info().Msg("successfully shutdown server and sub-processes")
 				return
+			case syscall.SIGHUP:
+				h.reloadTLS()
 			default:
 				panic(fmt.Sprintf("unsupported signal: %v", sig))
 			}
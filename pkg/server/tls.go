@@ -0,0 +1,81 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"sync"
+
+	serverCfg "github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/pkg/errors"
+)
+
+// tlsReloader holds the server TLS certificate and the CA pool used to verify client
+// certificates. The files are read from disk on Reload, allowing certificates to be rotated
+// without restarting the server; new connections use the most recently loaded files.
+type tlsReloader struct {
+	certPath     string
+	certKeyPath  string
+	clientCAPath string
+
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	sync.RWMutex
+}
+
+func newTLSReloader(cfg *serverCfg.TLSConfig) (*tlsReloader, error) {
+	r := &tlsReloader{
+		certPath:     cfg.CertPath,
+		certKeyPath:  cfg.CertKeyPath,
+		clientCAPath: cfg.ClientCAPath,
+	}
+	return r, r.Reload()
+}
+
+// Reload reads the certificate pair and client CA bundle from disk. If any file fails to load,
+// the previously loaded files remain in use.
+func (r *tlsReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certPath, r.certKeyPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to load certificate cert/key pair")
+	}
+
+	var pool *x509.CertPool
+
+	if r.clientCAPath != "" {
+		pem, err := ioutil.ReadFile(r.clientCAPath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read client CA bundle")
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("failed to parse client CA bundle, no certificates found")
+		}
+	}
+
+	r.Lock()
+	r.cert = &cert
+	r.clientCAs = pool
+	r.Unlock()
+	return nil
+}
+
+// tlsConfig returns the server TLS config, which looks up the current files for each new client
+// connection.
+func (r *tlsReloader) tlsConfig() *tls.Config {
+	return &tls.Config{GetConfigForClient: r.getConfigForClient}
+}
+
+func (r *tlsReloader) getConfigForClient(_ *tls.ClientHelloInfo) (*tls.Config, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	cfg := &tls.Config{Certificates: []tls.Certificate{*r.cert}}
+
+	if r.clientCAs != nil {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = r.clientCAs
+	}
+	return cfg, nil
+}
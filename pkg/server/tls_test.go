@@ -0,0 +1,139 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	serverCfg "github.com/jrasell/sherpa/pkg/config/server"
+	"github.com/jrasell/sherpa/pkg/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tlsReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sherpa-tls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, caKey := generateTestCert(t, "sherpa-ca", 1, nil, nil)
+	writeTestCert(t, dir, "ca", ca, caKey)
+
+	server, serverKey := generateTestCert(t, "sherpa-server", 2, ca, caKey)
+	writeTestCert(t, dir, "server", server, serverKey)
+
+	client, clientKey := generateTestCert(t, "ops-team", 3, ca, caKey)
+
+	reloader, err := newTLSReloader(&serverCfg.TLSConfig{
+		CertPath:     filepath.Join(dir, "server.pem"),
+		CertKeyPath:  filepath.Join(dir, "server-key.pem"),
+		ClientCAPath: filepath.Join(dir, "ca.pem"),
+	})
+	assert.Nil(t, err)
+
+	srv := httptest.NewUnstartedServer(middlewareIdentity(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(helper.IdentityFromContext(r.Context())))
+	}), helper.IdentitySourceCommonName))
+	srv.TLS = reloader.tlsConfig()
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	// Clients which do not present a certificate should be rejected.
+	_, err = newTestTLSClient(roots, nil).Get(srv.URL)
+	assert.NotNil(t, err)
+
+	// Clients presenting a certificate signed by the CA should be accepted and identified.
+	clientPair := tls.Certificate{Certificate: [][]byte{client.Raw}, PrivateKey: clientKey}
+	resp, err := newTestTLSClient(roots, &clientPair).Get(srv.URL)
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "ops-team", string(body))
+	assert.Equal(t, big.NewInt(2), resp.TLS.PeerCertificates[0].SerialNumber)
+
+	// Rotating the server certificate on disk and reloading should serve the new certificate to
+	// new connections.
+	rotated, rotatedKey := generateTestCert(t, "sherpa-server", 4, ca, caKey)
+	writeTestCert(t, dir, "server", rotated, rotatedKey)
+	assert.Nil(t, reloader.Reload())
+
+	resp, err = newTestTLSClient(roots, &clientPair).Get(srv.URL)
+	assert.Nil(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, big.NewInt(4), resp.TLS.PeerCertificates[0].SerialNumber)
+
+	// A failed reload should leave the existing certificates in place.
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "server.pem"), []byte("invalid"), 0600))
+	assert.NotNil(t, reloader.Reload())
+
+	resp, err = newTestTLSClient(roots, &clientPair).Get(srv.URL)
+	assert.Nil(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, big.NewInt(4), resp.TLS.PeerCertificates[0].SerialNumber)
+}
+
+func newTestTLSClient(roots *x509.CertPool, cert *tls.Certificate) *http.Client {
+	cfg := &tls.Config{RootCAs: roots}
+	if cert != nil {
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: cfg, DisableKeepAlives: true}}
+}
+
+// generateTestCert generates a certificate signed by the parent, or a self-signed CA certificate
+// if the parent is nil.
+func generateTestCert(t *testing.T, cn string, serial int64, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	return cert, key
+}
+
+func writeTestCert(t *testing.T, dir, name string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600))
+}
@@ -0,0 +1,40 @@
+package state
+
+// ACLToken is used to authenticate and authorize requests made to the Sherpa HTTP API. Requests
+// identify their token using the SecretID, while the AccessorID is a non-sensitive identifier used
+// to reference and manage the token.
+type ACLToken struct {
+
+	// AccessorID is the public identifier of the token.
+	AccessorID string
+
+	// SecretID is the secret used by clients to authenticate requests. This should be treated as
+	// sensitive.
+	SecretID string
+
+	// Name is a human readable description of the token.
+	Name string
+
+	// Management tokens are permitted to perform all API actions, including the management of
+	// other ACL tokens. The Rules of a management token are ignored.
+	Management bool
+
+	// Rules are the capabilities granted to the token, each scoped to the jobs matching the job
+	// prefix of the rule.
+	Rules []*ACLRule
+
+	// CreateTime is a UnixNano timestamp declaring when the token was created.
+	CreateTime int64
+}
+
+// ACLRule grants capabilities on all jobs whose Sherpa job identifier starts with the JobPrefix.
+// An empty JobPrefix matches all jobs, as well as API endpoints which are not scoped to a job.
+type ACLRule struct {
+
+	// JobPrefix is matched against the Sherpa job identifier, which includes the Nomad region and
+	// namespace of jobs not within the default namespace of the primary Nomad target.
+	JobPrefix string
+
+	// Capabilities are the API actions permitted on the matched jobs.
+	Capabilities []string
+}
@@ -0,0 +1,48 @@
+package state
+
+// Baseline is the weekly seasonal profile of a job group, used to make predictive scaling
+// decisions. The week is split into buckets, each holding a smoothed average of the values
+// recorded during that period of previous weeks.
+type Baseline struct {
+
+	// BucketSize is the resolution in seconds of each bucket within the baseline.
+	BucketSize int
+
+	// Buckets holds the recorded baseline, keyed by the bucket index within the week. The week
+	// starts Sunday 00:00 UTC.
+	Buckets map[int]*BaselineBucket
+
+	// Updated is a UnixNano timestamp declaring when the baseline was last updated.
+	Updated int64
+}
+
+// BaselineBucket holds the values recorded for a single period of the week. The samples recorded
+// during a week are averaged into a single value for that week, which is smoothed into the
+// baseline once the week has passed, so that each week carries the same weight regardless of how
+// many samples it held.
+type BaselineBucket struct {
+
+	// Value is the job group load, calculated from the predictive check value and smoothed across
+	// the completed weeks.
+	Value float64
+
+	// ValueWeeks is the number of completed weeks which the Value is built from.
+	ValueWeeks int
+
+	// Count is the job group count smoothed across the completed weeks.
+	Count float64
+
+	// CountWeeks is the number of completed weeks which the Count is built from.
+	CountWeeks int
+
+	// Week is the index of the week, counted from the Unix epoch, which the week values below
+	// were recorded within.
+	Week int64
+
+	// WeekValue and WeekCount are the averages of the samples recorded within the Week, which
+	// have not yet been added to the baseline.
+	WeekValue        float64
+	WeekValueSamples int
+	WeekCount        float64
+	WeekCountSamples int
+}
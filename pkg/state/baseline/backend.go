@@ -0,0 +1,29 @@
+package baseline
+
+import "github.com/jrasell/sherpa/pkg/state"
+
+// Backend is the interface required for a baseline storage backend. A baseline storage backend is
+// used to durably store job group predictive scaling baselines outside of Sherpa.
+type Backend interface {
+
+	// GetBaseline is used to pull the baseline for the job group from the storage backend if we
+	// have a record.
+	GetBaseline(job, group string) (*state.Baseline, error)
+
+	// PutBaseline is used to write the baseline for the job group to the storage backend,
+	// overwriting any existing entry.
+	PutBaseline(job, group string, baseline *state.Baseline) error
+
+	// RunGarbageCollection triggers a run of the baseline garbage collection which is used to
+	// clear up the baselines of job groups which have not been updated within the
+	// GarbageCollectionThreshold.
+	RunGarbageCollection()
+}
+
+const (
+	// GarbageCollectionThreshold is a nano-second time, which dictates the threshold for
+	// baselines to be declared stale. Baselines are updated on every autoscaler evaluation of a
+	// job group with predictive scaling configured, so the threshold only needs to allow for
+	// Sherpa being unavailable. The current value 1209600000000000 is 2 weeks.
+	GarbageCollectionThreshold int64 = 1209600000000000
+)
@@ -0,0 +1,108 @@
+package consul
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/baseline"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var _ baseline.Backend = (*StateBackend)(nil)
+
+const baselinesKVPath = "state/baselines/"
+
+// Define our metric keys.
+var (
+	metricKeyGetBaseline = []string{"baseline", "state", "consul", "get_baseline"}
+	metricKeyPutBaseline = []string{"baseline", "state", "consul", "put_baseline"}
+	metricKeyGC          = []string{"baseline", "state", "consul", "gc"}
+)
+
+type StateBackend struct {
+	path        string
+	gcThreshold int64
+	logger      zerolog.Logger
+
+	kv *api.KV
+}
+
+func NewStateBackend(log zerolog.Logger, path string, client *api.Client) baseline.Backend {
+	return &StateBackend{
+		path:        path + baselinesKVPath,
+		gcThreshold: baseline.GarbageCollectionThreshold,
+		logger:      log,
+		kv:          client.KV(),
+	}
+}
+
+func (s StateBackend) GetBaseline(job, group string) (*state.Baseline, error) {
+	defer metrics.MeasureSince(metricKeyGetBaseline, time.Now())
+
+	kv, _, err := s.kv.Get(s.path+job+"/"+group, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	out := state.Baseline{}
+	if err := json.Unmarshal(kv.Value, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return &out, nil
+}
+
+func (s StateBackend) PutBaseline(job, group string, b *state.Baseline) error {
+	defer metrics.MeasureSince(metricKeyPutBaseline, time.Now())
+
+	marshal, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	pair := &api.KVPair{
+		Key:   s.path + job + "/" + group,
+		Value: marshal,
+	}
+
+	_, err = s.kv.Put(pair, nil)
+	return err
+}
+
+func (s StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	kv, _, err := s.kv.List(s.path, nil)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("GC failed to list baselines in backend store")
+		return
+	}
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	for i := range kv {
+		b := &state.Baseline{}
+
+		if err := json.Unmarshal(kv[i].Value, b); err != nil {
+			s.logger.Error().Str("key", kv[i].Key).Err(err).Msg("GC failed to unmarshal baseline for inspection")
+			continue
+		}
+
+		if b.Updated < gc {
+			if _, err := s.kv.Delete(kv[i].Key, nil); err != nil {
+				s.logger.Error().
+					Str("key", kv[i].Key).
+					Err(err).
+					Msg("GC failed to delete stale baseline in backend store")
+			}
+		}
+	}
+}
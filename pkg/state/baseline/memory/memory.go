@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/baseline"
+)
+
+var _ baseline.Backend = (*StateBackend)(nil)
+
+// Define our metric keys.
+var (
+	metricKeyGetBaseline = []string{"baseline", "state", "memory", "get_baseline"}
+	metricKeyPutBaseline = []string{"baseline", "state", "memory", "put_baseline"}
+	metricKeyGC          = []string{"baseline", "state", "memory", "gc"}
+)
+
+type StateBackend struct {
+	gcThreshold int64
+	baselines   map[string]*state.Baseline
+	sync.RWMutex
+}
+
+func NewStateBackend() baseline.Backend {
+	return &StateBackend{
+		gcThreshold: baseline.GarbageCollectionThreshold,
+		baselines:   make(map[string]*state.Baseline),
+	}
+}
+
+func (s *StateBackend) GetBaseline(job, group string) (*state.Baseline, error) {
+	defer metrics.MeasureSince(metricKeyGetBaseline, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	b, ok := s.baselines[job+":"+group]
+	if !ok {
+		return nil, nil
+	}
+	return copyBaseline(b), nil
+}
+
+func (s *StateBackend) PutBaseline(job, group string, b *state.Baseline) error {
+	defer metrics.MeasureSince(metricKeyPutBaseline, time.Now())
+
+	s.Lock()
+	s.baselines[job+":"+group] = copyBaseline(b)
+	s.Unlock()
+	return nil
+}
+
+func (s *StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	s.Lock()
+	defer s.Unlock()
+
+	for key, b := range s.baselines {
+		if b.Updated < gc {
+			delete(s.baselines, key)
+		}
+	}
+}
+
+// copyBaseline performs a deep copy of the baseline, so that callers cannot modify the stored
+// state without calling PutBaseline.
+func copyBaseline(b *state.Baseline) *state.Baseline {
+	out := state.Baseline{
+		BucketSize: b.BucketSize,
+		Updated:    b.Updated,
+		Buckets:    make(map[int]*state.BaselineBucket, len(b.Buckets)),
+	}
+
+	for idx, bucket := range b.Buckets {
+		bucketCopy := *bucket
+		out.Buckets[idx] = &bucketCopy
+	}
+	return &out
+}
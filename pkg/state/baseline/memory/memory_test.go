@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStateBackend(t *testing.T) {
+	newBackend := NewStateBackend()
+
+	// Reading a baseline which does not exist should not error.
+	actual, err := newBackend.GetBaseline("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+
+	b := &state.Baseline{
+		BucketSize: 900,
+		Buckets:    map[int]*state.BaselineBucket{132: {Value: 600, ValueWeeks: 2, Count: 4, CountWeeks: 2}},
+		Updated:    time.Now().UTC().UnixNano(),
+	}
+	assert.Nil(t, newBackend.PutBaseline("test_job_name", "test_group_name", b))
+
+	actual, err = newBackend.GetBaseline("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, b, actual)
+
+	// Modifying the returned baseline should not modify the stored state.
+	actual.Buckets[132].Count = 10
+	stored, err := newBackend.GetBaseline("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, float64(4), stored.Buckets[132].Count)
+
+	// Garbage collection should only remove stale baselines.
+	assert.Nil(t, newBackend.PutBaseline("test_job_name", "test_group_stale", &state.Baseline{Updated: 1}))
+	newBackend.RunGarbageCollection()
+
+	stale, err := newBackend.GetBaseline("test_job_name", "test_group_stale")
+	assert.Nil(t, err)
+	assert.Nil(t, stale)
+
+	stored, err = newBackend.GetBaseline("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.NotNil(t, stored)
+}
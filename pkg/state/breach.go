@@ -0,0 +1,23 @@
+package state
+
+// BreachHistory holds the recent results of the threshold checks of a job group. It is used to
+// require checks to break their threshold for a number of evaluations before requesting scaling.
+type BreachHistory struct {
+
+	// Checks holds the recent datapoints of each check, keyed by check name and ordered oldest
+	// first.
+	Checks map[string][]*BreachDatapoint
+
+	// Updated is a UnixNano timestamp declaring when the history was last updated.
+	Updated int64
+}
+
+// BreachDatapoint is the result of a threshold check during a single evaluation.
+type BreachDatapoint struct {
+
+	// Time is a UnixNano timestamp declaring when the evaluation took place.
+	Time int64
+
+	// Breaching indicates whether the check broke its threshold.
+	Breaching bool
+}
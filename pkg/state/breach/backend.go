@@ -0,0 +1,28 @@
+package breach
+
+import "github.com/jrasell/sherpa/pkg/state"
+
+// Backend is the interface required for a breach history storage backend. A breach history
+// storage backend is used to durably store the recent threshold check results of each job group
+// outside of Sherpa, so that they survive leadership changes.
+type Backend interface {
+
+	// GetBreachHistory is used to pull the breach history for the job group from the storage
+	// backend if we have a record.
+	GetBreachHistory(job, group string) (*state.BreachHistory, error)
+
+	// PutBreachHistory is used to write the breach history for the job group to the storage
+	// backend, overwriting any existing entry.
+	PutBreachHistory(job, group string, history *state.BreachHistory) error
+
+	// RunGarbageCollection triggers a run of the breach history garbage collection which is used
+	// to clear up the history of job groups which have not been updated within the
+	// GarbageCollectionThreshold.
+	RunGarbageCollection()
+}
+
+const (
+	// GarbageCollectionThreshold is a nano-second time, which dictates the threshold for breach
+	// history entries to be declared stale. The current value 86400000000000 is 24 hours.
+	GarbageCollectionThreshold int64 = 86400000000000
+)
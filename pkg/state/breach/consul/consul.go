@@ -0,0 +1,108 @@
+package consul
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/breach"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var _ breach.Backend = (*StateBackend)(nil)
+
+const breachesKVPath = "state/breaches/"
+
+// Define our metric keys.
+var (
+	metricKeyGetBreachHistory = []string{"breach", "state", "consul", "get_breach_history"}
+	metricKeyPutBreachHistory = []string{"breach", "state", "consul", "put_breach_history"}
+	metricKeyGC               = []string{"breach", "state", "consul", "gc"}
+)
+
+type StateBackend struct {
+	path        string
+	gcThreshold int64
+	logger      zerolog.Logger
+
+	kv *api.KV
+}
+
+func NewStateBackend(log zerolog.Logger, path string, client *api.Client) breach.Backend {
+	return &StateBackend{
+		path:        path + breachesKVPath,
+		gcThreshold: breach.GarbageCollectionThreshold,
+		logger:      log,
+		kv:          client.KV(),
+	}
+}
+
+func (s StateBackend) GetBreachHistory(job, group string) (*state.BreachHistory, error) {
+	defer metrics.MeasureSince(metricKeyGetBreachHistory, time.Now())
+
+	kv, _, err := s.kv.Get(s.path+job+"/"+group, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	out := state.BreachHistory{}
+	if err := json.Unmarshal(kv.Value, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return &out, nil
+}
+
+func (s StateBackend) PutBreachHistory(job, group string, h *state.BreachHistory) error {
+	defer metrics.MeasureSince(metricKeyPutBreachHistory, time.Now())
+
+	marshal, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	pair := &api.KVPair{
+		Key:   s.path + job + "/" + group,
+		Value: marshal,
+	}
+
+	_, err = s.kv.Put(pair, nil)
+	return err
+}
+
+func (s StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	kv, _, err := s.kv.List(s.path, nil)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("GC failed to list breach histories in backend store")
+		return
+	}
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	for i := range kv {
+		h := &state.BreachHistory{}
+
+		if err := json.Unmarshal(kv[i].Value, h); err != nil {
+			s.logger.Error().Str("key", kv[i].Key).Err(err).Msg("GC failed to unmarshal breach history for inspection")
+			continue
+		}
+
+		if h.Updated < gc {
+			if _, err := s.kv.Delete(kv[i].Key, nil); err != nil {
+				s.logger.Error().
+					Str("key", kv[i].Key).
+					Err(err).
+					Msg("GC failed to delete stale breach history in backend store")
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/breach"
+)
+
+var _ breach.Backend = (*StateBackend)(nil)
+
+// Define our metric keys.
+var (
+	metricKeyGetBreachHistory = []string{"breach", "state", "memory", "get_breach_history"}
+	metricKeyPutBreachHistory = []string{"breach", "state", "memory", "put_breach_history"}
+	metricKeyGC               = []string{"breach", "state", "memory", "gc"}
+)
+
+type StateBackend struct {
+	gcThreshold int64
+	histories   map[string]*state.BreachHistory
+	sync.RWMutex
+}
+
+func NewStateBackend() breach.Backend {
+	return &StateBackend{
+		gcThreshold: breach.GarbageCollectionThreshold,
+		histories:   make(map[string]*state.BreachHistory),
+	}
+}
+
+func (s *StateBackend) GetBreachHistory(job, group string) (*state.BreachHistory, error) {
+	defer metrics.MeasureSince(metricKeyGetBreachHistory, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	h, ok := s.histories[job+":"+group]
+	if !ok {
+		return nil, nil
+	}
+	return copyBreachHistory(h), nil
+}
+
+func (s *StateBackend) PutBreachHistory(job, group string, h *state.BreachHistory) error {
+	defer metrics.MeasureSince(metricKeyPutBreachHistory, time.Now())
+
+	s.Lock()
+	s.histories[job+":"+group] = copyBreachHistory(h)
+	s.Unlock()
+	return nil
+}
+
+func (s *StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	s.Lock()
+	defer s.Unlock()
+
+	for key, h := range s.histories {
+		if h.Updated < gc {
+			delete(s.histories, key)
+		}
+	}
+}
+
+// copyBreachHistory performs a deep copy of the breach history, so that callers cannot modify the
+// stored state without calling PutBreachHistory.
+func copyBreachHistory(h *state.BreachHistory) *state.BreachHistory {
+	out := state.BreachHistory{
+		Updated: h.Updated,
+		Checks:  make(map[string][]*state.BreachDatapoint, len(h.Checks)),
+	}
+
+	for name, points := range h.Checks {
+		pointsCopy := make([]*state.BreachDatapoint, len(points))
+		for i := range points {
+			point := *points[i]
+			pointsCopy[i] = &point
+		}
+		out.Checks[name] = pointsCopy
+	}
+	return &out
+}
@@ -0,0 +1,46 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStateBackend(t *testing.T) {
+	newBackend := NewStateBackend()
+
+	// Reading a history which does not exist should not error.
+	actual, err := newBackend.GetBreachHistory("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+
+	h := &state.BreachHistory{
+		Checks:  map[string][]*state.BreachDatapoint{"queue": {{Time: 1572858000000000000, Breaching: true}}},
+		Updated: time.Now().UTC().UnixNano(),
+	}
+	assert.Nil(t, newBackend.PutBreachHistory("test_job_name", "test_group_name", h))
+
+	actual, err = newBackend.GetBreachHistory("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, h, actual)
+
+	// Modifying the returned history should not modify the stored state.
+	actual.Checks["queue"][0].Breaching = false
+	stored, err := newBackend.GetBreachHistory("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.True(t, stored.Checks["queue"][0].Breaching)
+
+	// Garbage collection should only remove stale histories.
+	assert.Nil(t, newBackend.PutBreachHistory("test_job_name", "test_group_stale", &state.BreachHistory{Updated: 1}))
+	newBackend.RunGarbageCollection()
+
+	stale, err := newBackend.GetBreachHistory("test_job_name", "test_group_stale")
+	assert.Nil(t, err)
+	assert.Nil(t, stale)
+
+	stored, err = newBackend.GetBreachHistory("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.NotNil(t, stored)
+}
@@ -0,0 +1,51 @@
+package state
+
+// Evaluation is the result of a single internal autoscaler evaluation of a job group. Evaluations
+// are recorded whether or not scaling was required, allowing operators to track how close the
+// job group was to breaking its thresholds.
+type Evaluation struct {
+
+	// Time is a UnixNano timestamp declaring when the evaluation took place.
+	Time int64
+
+	// Checks holds the result of each check performed during the evaluation, keyed by check name.
+	Checks map[string]*EvaluationCheck `json:",omitempty"`
+
+	// Decision is the scaling decision made by the evaluation. This is nil if no scaling was
+	// required.
+	Decision *EvaluationDecision `json:",omitempty"`
+}
+
+// EvaluationCheck is the result of a single check performed during an evaluation.
+type EvaluationCheck struct {
+
+	// Type is the type of check which was performed, such as nomad or external.
+	Type string
+
+	// Value is the metric value the check obtained. This is nil if the value could not be
+	// obtained.
+	Value *float64 `json:",omitempty"`
+
+	// Threshold is the value which the metric value was compared against. For target tracking
+	// checks, this is the target value.
+	Threshold *float64 `json:",omitempty"`
+
+	// Result is the scaling direction requested by the check.
+	Result string
+
+	// Error details any error which prevented the check from being performed.
+	Error string `json:",omitempty"`
+}
+
+// EvaluationDecision is the scaling decision made during an evaluation.
+type EvaluationDecision struct {
+	Direction string
+	Count     int
+
+	// Absolute indicates the count is the desired job group count, rather than the number to
+	// change the count by.
+	Absolute bool
+
+	// DryRun indicates the decision was recorded as a dry-run event, rather than acted upon.
+	DryRun bool
+}
@@ -0,0 +1,53 @@
+package evaluation
+
+import "github.com/jrasell/sherpa/pkg/state"
+
+// Backend is the interface required for an evaluation history storage backend. An evaluation
+// history storage backend is used to store a bounded history of the internal autoscaler
+// evaluations of each job group.
+type Backend interface {
+
+	// GetJobEvaluations returns the stored evaluation history of each group within the job, keyed
+	// by group name.
+	GetJobEvaluations(job string) (map[string][]*state.Evaluation, error)
+
+	// GetEvaluations returns the stored evaluation history of the job group, ordered oldest
+	// first.
+	GetEvaluations(job, group string) ([]*state.Evaluation, error)
+
+	// PutEvaluation is used to add an evaluation to the history of the job group. When
+	// implementing this function, care should be taken to ensure the oldest evaluations are
+	// removed once the history has reached its maximum size.
+	PutEvaluation(job, group string, eval *state.Evaluation) error
+
+	// RunGarbageCollection triggers a run of the evaluation history garbage collection which is
+	// used to clear up evaluations older than the GarbageCollectionThreshold.
+	RunGarbageCollection()
+}
+
+const (
+	// GarbageCollectionThreshold is a nano-second time, which dictates the threshold for
+	// evaluations to be declared stale. The current value 86400000000000 is 24 hours.
+	GarbageCollectionThreshold int64 = 86400000000000
+)
+
+// TrimEvaluations removes the oldest evaluations from the history so that it holds no more than
+// size entries.
+func TrimEvaluations(evals []*state.Evaluation, size int) []*state.Evaluation {
+	if len(evals) <= size {
+		return evals
+	}
+	return evals[len(evals)-size:]
+}
+
+// FilterStaleEvaluations returns the evaluations which took place after the gc time.
+func FilterStaleEvaluations(evals []*state.Evaluation, gc int64) []*state.Evaluation {
+	var out []*state.Evaluation // nolint:prealloc
+
+	for _, eval := range evals {
+		if eval.Time > gc {
+			out = append(out, eval)
+		}
+	}
+	return out
+}
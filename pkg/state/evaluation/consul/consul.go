@@ -0,0 +1,162 @@
+package consul
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var _ evaluation.Backend = (*StateBackend)(nil)
+
+const evaluationsKVPath = "state/evaluations/"
+
+// Define our metric keys.
+var (
+	metricKeyGetJobEvaluations = []string{"evaluation", "state", "consul", "get_job_evaluations"}
+	metricKeyGetEvaluations    = []string{"evaluation", "state", "consul", "get_evaluations"}
+	metricKeyPutEvaluation     = []string{"evaluation", "state", "consul", "put_evaluation"}
+	metricKeyGC                = []string{"evaluation", "state", "consul", "gc"}
+)
+
+type StateBackend struct {
+	path        string
+	gcThreshold int64
+	size        int
+	logger      zerolog.Logger
+
+	kv *api.KV
+}
+
+// NewStateBackend returns a Consul evaluation history backend, which holds up to size evaluations
+// per job group. The history of each job group is stored as a single KV entry.
+func NewStateBackend(log zerolog.Logger, path string, client *api.Client, size int) evaluation.Backend {
+	return &StateBackend{
+		path:        path + evaluationsKVPath,
+		gcThreshold: evaluation.GarbageCollectionThreshold,
+		size:        size,
+		logger:      log,
+		kv:          client.KV(),
+	}
+}
+
+func (s StateBackend) GetJobEvaluations(job string) (map[string][]*state.Evaluation, error) {
+	defer metrics.MeasureSince(metricKeyGetJobEvaluations, time.Now())
+
+	prefix := s.path + job + "/"
+
+	kv, _, err := s.kv.List(prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]*state.Evaluation)
+
+	for i := range kv {
+
+		// Keys nested further beneath the job prefix belong to jobs within a namespace of the same
+		// name as the job.
+		group := strings.TrimPrefix(kv[i].Key, prefix)
+		if strings.Contains(group, "/") {
+			continue
+		}
+
+		var evals []*state.Evaluation
+
+		if err := json.Unmarshal(kv[i].Value, &evals); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+		}
+
+		out[group] = evals
+	}
+	return out, nil
+}
+
+func (s StateBackend) GetEvaluations(job, group string) ([]*state.Evaluation, error) {
+	defer metrics.MeasureSince(metricKeyGetEvaluations, time.Now())
+	return s.getEvaluations(s.path + job + "/" + group)
+}
+
+func (s StateBackend) getEvaluations(key string) ([]*state.Evaluation, error) {
+	kv, _, err := s.kv.Get(key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	var out []*state.Evaluation
+	if err := json.Unmarshal(kv.Value, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return out, nil
+}
+
+func (s StateBackend) PutEvaluation(job, group string, eval *state.Evaluation) error {
+	defer metrics.MeasureSince(metricKeyPutEvaluation, time.Now())
+
+	key := s.path + job + "/" + group
+
+	// Each job group is only evaluated by the leader once per autoscaler run, so there is no need
+	// to guard the read and write against concurrent updates.
+	evals, err := s.getEvaluations(key)
+	if err != nil {
+		return err
+	}
+	return s.putEvaluations(key, evaluation.TrimEvaluations(append(evals, eval), s.size))
+}
+
+func (s StateBackend) putEvaluations(key string, evals []*state.Evaluation) error {
+	marshal, err := json.Marshal(evals)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.kv.Put(&api.KVPair{Key: key, Value: marshal}, nil)
+	return err
+}
+
+func (s StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	kv, _, err := s.kv.List(s.path, nil)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("GC failed to list evaluations in backend store")
+		return
+	}
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	for i := range kv {
+		var evals []*state.Evaluation
+
+		if err := json.Unmarshal(kv[i].Value, &evals); err != nil {
+			s.logger.Error().Str("key", kv[i].Key).Err(err).Msg("GC failed to unmarshal evaluations for inspection")
+			continue
+		}
+
+		filtered := evaluation.FilterStaleEvaluations(evals, gc)
+
+		switch {
+		case len(filtered) == len(evals):
+			continue
+		case len(filtered) == 0:
+			_, err = s.kv.Delete(kv[i].Key, nil)
+		default:
+			err = s.putEvaluations(kv[i].Key, filtered)
+		}
+
+		if err != nil {
+			s.logger.Error().Str("key", kv[i].Key).Err(err).Msg("GC failed to update stale evaluations in backend store")
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/evaluation"
+)
+
+var _ evaluation.Backend = (*StateBackend)(nil)
+
+// Define our metric keys.
+var (
+	metricKeyGetJobEvaluations = []string{"evaluation", "state", "memory", "get_job_evaluations"}
+	metricKeyGetEvaluations    = []string{"evaluation", "state", "memory", "get_evaluations"}
+	metricKeyPutEvaluation     = []string{"evaluation", "state", "memory", "put_evaluation"}
+	metricKeyGC                = []string{"evaluation", "state", "memory", "gc"}
+)
+
+type StateBackend struct {
+	gcThreshold int64
+	size        int
+	evaluations map[string][]*state.Evaluation
+	sync.RWMutex
+}
+
+// NewStateBackend returns an in-memory evaluation history backend, which holds up to size
+// evaluations per job group.
+func NewStateBackend(size int) evaluation.Backend {
+	return &StateBackend{
+		gcThreshold: evaluation.GarbageCollectionThreshold,
+		size:        size,
+		evaluations: make(map[string][]*state.Evaluation),
+	}
+}
+
+func (s *StateBackend) GetJobEvaluations(job string) (map[string][]*state.Evaluation, error) {
+	defer metrics.MeasureSince(metricKeyGetJobEvaluations, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	out := make(map[string][]*state.Evaluation)
+
+	for key, evals := range s.evaluations {
+		if group := strings.TrimPrefix(key, job+":"); group != key {
+			out[group] = copyEvaluations(evals)
+		}
+	}
+	return out, nil
+}
+
+func (s *StateBackend) GetEvaluations(job, group string) ([]*state.Evaluation, error) {
+	defer metrics.MeasureSince(metricKeyGetEvaluations, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+	return copyEvaluations(s.evaluations[job+":"+group]), nil
+}
+
+func (s *StateBackend) PutEvaluation(job, group string, eval *state.Evaluation) error {
+	defer metrics.MeasureSince(metricKeyPutEvaluation, time.Now())
+
+	k := job + ":" + group
+
+	s.Lock()
+	s.evaluations[k] = evaluation.TrimEvaluations(append(s.evaluations[k], eval), s.size)
+	s.Unlock()
+	return nil
+}
+
+func (s *StateBackend) RunGarbageCollection() {
+	t := time.Now()
+	defer metrics.MeasureSince(metricKeyGC, t)
+
+	gc := t.UTC().UnixNano() - s.gcThreshold
+
+	s.Lock()
+	defer s.Unlock()
+
+	for key, evals := range s.evaluations {
+		if filtered := evaluation.FilterStaleEvaluations(evals, gc); len(filtered) > 0 {
+			s.evaluations[key] = filtered
+		} else {
+			delete(s.evaluations, key)
+		}
+	}
+}
+
+// copyEvaluations returns a copy of the evaluation history, so that the returned slice is not
+// modified by subsequent calls to PutEvaluation.
+func copyEvaluations(evals []*state.Evaluation) []*state.Evaluation {
+	if evals == nil {
+		return nil
+	}
+	out := make([]*state.Evaluation, len(evals))
+	copy(out, evals)
+	return out
+}
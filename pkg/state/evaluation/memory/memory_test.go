@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStateBackend(t *testing.T) {
+	newBackend := NewStateBackend(2)
+
+	// Reading evaluations which do not exist should not error.
+	actual, err := newBackend.GetEvaluations("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+
+	now := time.Now().UTC().UnixNano()
+
+	evals := []*state.Evaluation{
+		{Time: now - 2},
+		{Time: now - 1, Decision: &state.EvaluationDecision{Direction: "out", Count: 1}},
+		{Time: now},
+	}
+	for _, eval := range evals {
+		assert.Nil(t, newBackend.PutEvaluation("test_job_name", "test_group_name", eval))
+	}
+	assert.Nil(t, newBackend.PutEvaluation("test_job_name_other", "test_group_name", evals[0]))
+
+	// The history should be bounded to the configured size, dropping the oldest.
+	actual, err = newBackend.GetEvaluations("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, evals[1:], actual)
+
+	jobEvals, err := newBackend.GetJobEvaluations("test_job_name")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]*state.Evaluation{"test_group_name": evals[1:]}, jobEvals)
+}
+
+func Test_MemoryStateBackend_RunGarbageCollection(t *testing.T) {
+	newBackend := NewStateBackend(10)
+
+	now := time.Now().UTC().UnixNano()
+	stale := &state.Evaluation{Time: now - 2*86400000000000}
+	fresh := &state.Evaluation{Time: now}
+
+	assert.Nil(t, newBackend.PutEvaluation("test_job_name", "test_group_name", stale))
+	assert.Nil(t, newBackend.PutEvaluation("test_job_name", "test_group_name", fresh))
+	assert.Nil(t, newBackend.PutEvaluation("test_job_name", "test_group_stale", stale))
+
+	newBackend.RunGarbageCollection()
+
+	jobEvals, err := newBackend.GetJobEvaluations("test_job_name")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]*state.Evaluation{"test_group_name": {fresh}}, jobEvals)
+}
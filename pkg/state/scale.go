@@ -28,6 +28,11 @@ type ScalingEvent struct {
 	// job to the Nomad API.
 	EvalID string
 
+	// NomadEventIndex identifies the scaling event recorded by Nomad when the job group was scaled
+	// using the Nomad job scale API. Nomad identifies scaling events by the index at which they
+	// were created. This is zero when the job was registered with Nomad rather than scaled.
+	NomadEventIndex uint64
+
 	// Source shows the origin source of the scaling event.
 	Source Source
 
@@ -41,9 +46,37 @@ type ScalingEvent struct {
 	// scaling event.
 	Details EventDetails
 
+	// Failures is the number of consecutive failed scaling events for the job group, including
+	// this event. This is zero unless the Status is StatusFailed, and is used to back off scaling
+	// of job groups which repeatedly fail.
+	Failures int
+
+	// Outcome is the result of Nomad scheduling the job group following the scaling event. This
+	// is nil until the Nomad evaluation created by the scaling event has been followed to
+	// completion, and for events which did not register the job with Nomad.
+	Outcome *EventOutcome
+
+	// Identity is the identity of the API client which requested the scaling event, taken from
+	// its verified TLS client certificate.
+	Identity string `json:",omitempty"`
+
 	Meta map[string]string
 }
 
+// EventOutcome describes the result of Nomad scheduling a job group after a scaling event, as
+// determined by following the Nomad evaluation created by the scaling event.
+type EventOutcome struct {
+	// Status is the scheduling outcome of the job group.
+	Status OutcomeStatus
+
+	// Reasons details why the job group was not placed. This is empty when the job group was
+	// successfully placed.
+	Reasons []string
+
+	// Time is a UnixNano timestamp declaring when the outcome was determined.
+	Time int64
+}
+
 // EventDetails contains information to describe what changes took place during the scaling action.
 type EventDetails struct {
 	// Count is the number by which the group was changed.
@@ -57,15 +90,18 @@ type EventDetails struct {
 // ScalingEventMessage is the message sent to the state writer containing all the required
 // information to construct the persistent state entry.
 type ScalingEventMessage struct {
-	ID        uuid.UUID
-	GroupName string
-	EvalID    string
-	Source    Source
-	Time      int64
-	Status    Status
-	Count     int
-	Direction string
-	Meta      map[string]string
+	ID              uuid.UUID
+	GroupName       string
+	EvalID          string
+	NomadEventIndex uint64
+	Source          Source
+	Time            int64
+	Status          Status
+	Count           int
+	Direction       string
+	Failures        int
+	Meta            map[string]string
+	Identity        string
 }
 
 // Source represents how the scaling action was invoked.
@@ -77,13 +113,17 @@ const (
 
 	// SourceInternalAutoscaler is a scaling event invoked by the internal autoscaler.
 	SourceInternalAutoscaler Source = "InternalAutoscaler"
+
+	// SourceScheduler is a scaling event invoked by the autoscaler enforcing a scheduled scaling
+	// window configured within the job group policy.
+	SourceScheduler Source = "Scheduler"
 )
 
 func (s Source) String() string { return string(s) }
 
-// Status represents whether the scaling event was classed as successful or not. Currently this is
-// dependant on if the job managed to be submitted to the Nomad API as Sherpa does not perform any
-// checking after.
+// Status represents whether the scaling event was classed as successful or not. This is dependant
+// on if the job managed to be submitted to the Nomad API; the result of Nomad scheduling the job is
+// tracked separately by the event Outcome.
 type Status string
 
 const (
@@ -94,6 +134,41 @@ const (
 	// StatusFailed means there was an error calling the Nomad API when attempting to register
 	// the job which contained altered groups as a result of a scaling event.
 	StatusFailed = "Failed"
+
+	// StatusDryRun means the scaling event was calculated by the internal autoscaler while in
+	// dry-run mode, and the Nomad job was not changed.
+	StatusDryRun = "DryRun"
+
+	// StatusInsufficientCapacity means the job group could not be scaled out by the event count
+	// as the Nomad cluster did not have enough free capacity to place the allocations. The Nomad
+	// job was not changed by this event, although the job group may have been scaled out by a
+	// lesser count within a separate event.
+	StatusInsufficientCapacity = "InsufficientCapacity"
 )
 
 func (s Status) String() string { return string(s) }
+
+// OutcomeStatus represents the result of Nomad scheduling a job group after a scaling event.
+type OutcomeStatus string
+
+const (
+	// OutcomePlaced means the Nomad evaluation completed and all allocations of the job group were
+	// placed, confirmed by the resulting deployment succeeding or the allocations running.
+	OutcomePlaced = "Placed"
+
+	// OutcomeBlocked means the Nomad evaluation completed, but allocations of the job group could
+	// not be placed, usually due to insufficient cluster capacity.
+	OutcomeBlocked = "Blocked"
+
+	// OutcomeFailed means the Nomad evaluation or deployment failed or was cancelled, allocations
+	// of the job group failed to start, or placement could not be followed to completion.
+	OutcomeFailed = "Failed"
+)
+
+func (o OutcomeStatus) String() string { return string(o) }
+
+// ChangesJobGroup identifies whether an event with the status attempted to change the job group,
+// and so should be tracked as the latest event for the job group.
+func (s Status) ChangesJobGroup() bool {
+	return s != StatusDryRun && s != StatusInsufficientCapacity
+}
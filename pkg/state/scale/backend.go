@@ -25,9 +25,15 @@ type Backend interface {
 
 	// PutScalingEvent is used to update the state with a new scaling event. When implementing this
 	// function, care should be taken to ensure both the Events and LatestEvents fields are
-	// manipulated.
+	// manipulated. Events whose status does not change the job group, such as dry-run events,
+	// must not update the LatestEvents, as they should not affect cooldown.
 	PutScalingEvent(string, *state.ScalingEventMessage) error
 
+	// PutScalingEventOutcome is used to update the job group entry of a stored scaling event with
+	// the outcome of Nomad scheduling the job. If the event is also the latest event for the job
+	// group, the latest entry should also be updated.
+	PutScalingEventOutcome(job, group string, id uuid.UUID, outcome *state.EventOutcome) error
+
 	// RunGarbageCollection triggers are run of the state event garbage collection which is used to
 	// clear up old state entries. This ensures the state backend doesn't just continually grow.
 	RunGarbageCollection()
@@ -30,6 +30,7 @@ var (
 	metricKeyGetLatestEvents = []string{"scale", "state", "consul", "get_latest_events"}
 	metricKeyGetLatestEvent  = []string{"scale", "state", "consul", "get_latest_event"}
 	metricKeyPutEvent        = []string{"scale", "state", "consul", "put_event"}
+	metricKeyPutEventOutcome = []string{"scale", "state", "consul", "put_event_outcome"}
 	metricKeyGC              = []string{"scale", "state", "consul", "gc"}
 )
 
@@ -75,8 +76,9 @@ func (s StateBackend) GetLatestScalingEvents() (map[string]*state.ScalingEvent,
 			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
 		}
 
-		keySplit := strings.Split(kv[i].Key, "/")
-		out[keySplit[len(keySplit)-1]] = event
+		// The job group key includes the namespace of jobs outside the default namespace, and so
+		// the key is taken as everything following the path.
+		out[strings.TrimPrefix(kv[i].Key, s.latestEventsPath)] = event
 	}
 
 	return out, nil
@@ -122,14 +124,22 @@ func (s StateBackend) GetScalingEvents() (map[uuid.UUID]map[string]*state.Scalin
 			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
 		}
 
-		keySplit := strings.Split(kv[i].Key, "/")
+		// Keys take the form <id>/<job group key>, where the job group key can itself contain a
+		// slash if the job is within a namespace.
+		keySplit := strings.SplitN(strings.TrimPrefix(kv[i].Key, s.eventsPath), "/", 2)
+		if len(keySplit) != 2 {
+			return nil, errors.Errorf("unexpected scaling event key %s", kv[i].Key)
+		}
 
-		id, err := uuid.FromString(keySplit[len(keySplit)-2])
+		id, err := uuid.FromString(keySplit[0])
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to get UUID from string")
 		}
 
-		out[id] = map[string]*state.ScalingEvent{keySplit[len(keySplit)-1]: keyState}
+		if _, ok := out[id]; !ok {
+			out[id] = make(map[string]*state.ScalingEvent)
+		}
+		out[id][keySplit[1]] = keyState
 	}
 
 	return out, nil
@@ -138,7 +148,9 @@ func (s StateBackend) GetScalingEvents() (map[uuid.UUID]map[string]*state.Scalin
 func (s StateBackend) GetScalingEvent(id uuid.UUID) (map[string]*state.ScalingEvent, error) {
 	defer metrics.MeasureSince(metricKeyGetEvent, time.Now())
 
-	kv, _, err := s.kv.List(s.eventsPath+id.String(), nil)
+	prefix := s.eventsPath + id.String() + "/"
+
+	kv, _, err := s.kv.List(prefix, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -155,8 +167,7 @@ func (s StateBackend) GetScalingEvent(id uuid.UUID) (map[string]*state.ScalingEv
 			return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
 		}
 
-		keySplit := strings.Split(kv[i].Key, "/")
-		out[keySplit[len(keySplit)-1]] = s
+		out[strings.TrimPrefix(kv[i].Key, prefix)] = s
 	}
 
 	return out, nil
@@ -166,13 +177,16 @@ func (s StateBackend) PutScalingEvent(job string, event *state.ScalingEventMessa
 	defer metrics.MeasureSince(metricKeyPutEvent, time.Now())
 
 	sEntry := &state.ScalingEvent{
-		ID:      event.ID,
-		EvalID:  event.EvalID,
-		Source:  event.Source,
-		Time:    event.Time,
-		Status:  event.Status,
-		Details: state.EventDetails{Count: event.Count, Direction: event.Direction},
-		Meta:    event.Meta,
+		ID:              event.ID,
+		EvalID:          event.EvalID,
+		NomadEventIndex: event.NomadEventIndex,
+		Source:          event.Source,
+		Time:            event.Time,
+		Status:          event.Status,
+		Details:         state.EventDetails{Count: event.Count, Direction: event.Direction},
+		Failures:        event.Failures,
+		Identity:        event.Identity,
+		Meta:            event.Meta,
 	}
 
 	marshal, err := json.Marshal(sEntry)
@@ -189,6 +203,12 @@ func (s StateBackend) PutScalingEvent(job string, event *state.ScalingEventMessa
 		return err
 	}
 
+	// Dry-run and insufficient capacity events did not change the job group, and so are not
+	// written to the latest store.
+	if !event.Status.ChangesJobGroup() {
+		return nil
+	}
+
 	// Write the new event to the latest store.
 	lePair := &api.KVPair{
 		Key:   fmt.Sprintf("%s%s:%s", s.latestEventsPath, job, event.GroupName),
@@ -198,6 +218,51 @@ func (s StateBackend) PutScalingEvent(job string, event *state.ScalingEventMessa
 	return err
 }
 
+func (s StateBackend) PutScalingEventOutcome(job, group string, id uuid.UUID, outcome *state.EventOutcome) error {
+	defer metrics.MeasureSince(metricKeyPutEventOutcome, time.Now())
+
+	eventKey := fmt.Sprintf("%s%s/%s:%s", s.eventsPath, id.String(), job, group)
+
+	if err := s.putEventOutcome(eventKey, id, outcome); err != nil {
+		return err
+	}
+	return s.putEventOutcome(fmt.Sprintf("%s%s:%s", s.latestEventsPath, job, group), id, outcome)
+}
+
+// putEventOutcome updates the event stored at the key with the outcome, if the stored event has
+// the passed ID.
+func (s StateBackend) putEventOutcome(key string, id uuid.UUID, outcome *state.EventOutcome) error {
+	kv, _, err := s.kv.Get(key, nil)
+	if err != nil {
+		return err
+	}
+
+	if kv == nil {
+		return nil
+	}
+
+	event := state.ScalingEvent{}
+	if err := json.Unmarshal(kv.Value, &event); err != nil {
+		return errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+
+	if event.ID != id {
+		return nil
+	}
+	event.Outcome = outcome
+
+	marshal, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// Use a check-and-set write so that a newer latest event written in the meantime is not
+	// overwritten.
+	kv.Value = marshal
+	_, _, err = s.kv.CAS(kv, nil)
+	return err
+}
+
 func (s StateBackend) RunGarbageCollection() {
 	t := time.Now()
 	defer metrics.MeasureSince(metricKeyGC, t)
@@ -8,6 +8,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/jrasell/sherpa/pkg/state"
 	"github.com/jrasell/sherpa/pkg/state/scale"
+	"github.com/pkg/errors"
 )
 
 var _ scale.Backend = (*StateBackend)(nil)
@@ -19,6 +20,7 @@ var (
 	metricKeyGetLatestEvents = []string{"scale", "state", "memory", "get_latest_events"}
 	metricKeyGetLatestEvent  = []string{"scale", "state", "memory", "get_latest_event"}
 	metricKeyPutEvent        = []string{"scale", "state", "memory", "put_event"}
+	metricKeyPutEventOutcome = []string{"scale", "state", "memory", "put_event_outcome"}
 	metricKeyGC              = []string{"scale", "state", "memory", "gc"}
 )
 
@@ -74,19 +76,52 @@ func (s *StateBackend) PutScalingEvent(job string, event *state.ScalingEventMess
 	k := job + ":" + event.GroupName
 
 	sEntry := &state.ScalingEvent{
-		ID:      event.ID,
-		EvalID:  event.EvalID,
-		Source:  event.Source,
-		Time:    event.Time,
-		Status:  event.Status,
-		Details: state.EventDetails{Count: event.Count, Direction: event.Direction},
-		Meta:    event.Meta,
+		ID:              event.ID,
+		EvalID:          event.EvalID,
+		NomadEventIndex: event.NomadEventIndex,
+		Source:          event.Source,
+		Time:            event.Time,
+		Status:          event.Status,
+		Details:         state.EventDetails{Count: event.Count, Direction: event.Direction},
+		Failures:        event.Failures,
+		Identity:        event.Identity,
+		Meta:            event.Meta,
 	}
 
-	s.state.Events[event.ID] = make(map[string]*state.ScalingEvent)
+	if _, ok := s.state.Events[event.ID]; !ok {
+		s.state.Events[event.ID] = make(map[string]*state.ScalingEvent)
+	}
 	s.state.Events[event.ID][k] = sEntry
-	s.state.LatestEvents[k] = sEntry
 
+	if event.Status.ChangesJobGroup() {
+		s.state.LatestEvents[k] = sEntry
+	}
+
+	return nil
+}
+
+func (s *StateBackend) PutScalingEventOutcome(job, group string, id uuid.UUID, outcome *state.EventOutcome) error {
+	defer metrics.MeasureSince(metricKeyPutEventOutcome, time.Now())
+
+	s.Lock()
+	defer s.Unlock()
+
+	k := job + ":" + group
+
+	event, ok := s.state.Events[id][k]
+	if !ok {
+		return errors.Errorf("scaling event %s not found for job group %s", id, k)
+	}
+
+	// The stored event is replaced, rather than modified, as callers may be holding a reference
+	// to it.
+	updated := *event
+	updated.Outcome = outcome
+	s.state.Events[id][k] = &updated
+
+	if latest, ok := s.state.LatestEvents[k]; ok && latest.ID == id {
+		s.state.LatestEvents[k] = &updated
+	}
 	return nil
 }
 
@@ -112,6 +112,7 @@ func generateTestEvent(t int64) *state.ScalingEventMessage {
 		Status:    state.StatusCompleted,
 		Count:     1,
 		Direction: "in",
+		Identity:  "ops-team",
 		Meta: map[string]string{
 			"metric": "cpu",
 			"value":  "99",
@@ -121,12 +122,85 @@ func generateTestEvent(t int64) *state.ScalingEventMessage {
 
 func convertMessageToStateRepresentation(event *state.ScalingEventMessage) *state.ScalingEvent {
 	return &state.ScalingEvent{
-		ID:      event.ID,
-		EvalID:  event.EvalID,
-		Source:  event.Source,
-		Time:    event.Time,
-		Status:  event.Status,
-		Details: state.EventDetails{Count: event.Count, Direction: event.Direction},
-		Meta:    event.Meta,
+		ID:       event.ID,
+		EvalID:   event.EvalID,
+		Source:   event.Source,
+		Time:     event.Time,
+		Status:   event.Status,
+		Details:  state.EventDetails{Count: event.Count, Direction: event.Direction},
+		Identity: event.Identity,
+		Meta:     event.Meta,
 	}
 }
+
+func Test_MemoryStateBackendDryRun(t *testing.T) {
+	newBackend := NewStateBackend()
+
+	event1 := generateTestEvent(time.Now().UnixNano())
+	assert.Nil(t, newBackend.PutScalingEvent("test_job_name", event1))
+
+	// Write a dry-run event for the same job group.
+	event2 := generateTestEvent(time.Now().UnixNano())
+	event2.Status = state.StatusDryRun
+	assert.Nil(t, newBackend.PutScalingEvent("test_job_name", event2))
+
+	// The dry-run event should be stored, but not replace the latest event.
+	actualEvent2, err := newBackend.GetScalingEvent(event2.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*state.ScalingEvent{
+		"test_job_name:test_group_name": convertMessageToStateRepresentation(event2),
+	}, actualEvent2)
+
+	latest, err := newBackend.GetLatestScalingEvent("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, convertMessageToStateRepresentation(event1), latest)
+
+	// Insufficient capacity events also should not replace the latest event.
+	event3 := generateTestEvent(time.Now().UnixNano())
+	event3.Status = state.StatusInsufficientCapacity
+	assert.Nil(t, newBackend.PutScalingEvent("test_job_name", event3))
+
+	latest, err = newBackend.GetLatestScalingEvent("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, convertMessageToStateRepresentation(event1), latest)
+}
+
+func Test_MemoryStateBackendOutcome(t *testing.T) {
+	newBackend := NewStateBackend()
+
+	event1 := generateTestEvent(time.Now().UnixNano())
+	assert.Nil(t, newBackend.PutScalingEvent("test_job_name", event1))
+
+	event2 := generateTestEvent(time.Now().UnixNano())
+	assert.Nil(t, newBackend.PutScalingEvent("test_job_name", event2))
+
+	outcome := &state.EventOutcome{
+		Status:  state.OutcomeBlocked,
+		Reasons: []string{"dimension \"memory\" exhausted on 3 nodes"},
+		Time:    time.Now().UnixNano(),
+	}
+
+	// Update the older event, which should not change the latest event.
+	assert.Nil(t, newBackend.PutScalingEventOutcome("test_job_name", "test_group_name", event1.ID, outcome))
+
+	expectedEvent1 := convertMessageToStateRepresentation(event1)
+	expectedEvent1.Outcome = outcome
+
+	actualEvent1, err := newBackend.GetScalingEvent(event1.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]*state.ScalingEvent{"test_job_name:test_group_name": expectedEvent1}, actualEvent1)
+
+	latest, err := newBackend.GetLatestScalingEvent("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Nil(t, latest.Outcome)
+
+	// Update the latest event, which should be reflected within the latest event.
+	assert.Nil(t, newBackend.PutScalingEventOutcome("test_job_name", "test_group_name", event2.ID, outcome))
+
+	latest, err = newBackend.GetLatestScalingEvent("test_job_name", "test_group_name")
+	assert.Nil(t, err)
+	assert.Equal(t, outcome, latest.Outcome)
+
+	// Updating an event which does not exist should error.
+	assert.NotNil(t, newBackend.PutScalingEventOutcome("test_job_name", "unknown", event2.ID, outcome))
+}
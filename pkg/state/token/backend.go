@@ -0,0 +1,26 @@
+package token
+
+import "github.com/jrasell/sherpa/pkg/state"
+
+// Backend is the interface required for an ACL token storage backend. An ACL token storage
+// backend is used to durably store the tokens used to authenticate requests to the Sherpa API.
+type Backend interface {
+
+	// GetTokens returns all ACL tokens held within the storage backend.
+	GetTokens() ([]*state.ACLToken, error)
+
+	// GetToken returns the ACL token identified by the accessor ID. If the token is not found,
+	// nil should be returned without error.
+	GetToken(accessorID string) (*state.ACLToken, error)
+
+	// GetTokenBySecret returns the ACL token identified by the secret ID. If the token is not
+	// found, nil should be returned without error.
+	GetTokenBySecret(secretID string) (*state.ACLToken, error)
+
+	// PutToken is used to write the ACL token to the storage backend, overwriting any existing
+	// token with the same accessor ID.
+	PutToken(token *state.ACLToken) error
+
+	// DeleteToken removes the ACL token identified by the accessor ID from the storage backend.
+	DeleteToken(accessorID string) error
+}
@@ -0,0 +1,188 @@
+package consul
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/consul/api"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/token"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var _ token.Backend = (*StateBackend)(nil)
+
+const (
+	tokensKVPath = "state/acl/tokens/"
+
+	// secretsKVPath holds an index of token accessor IDs keyed by the SHA256 hash of the token
+	// secret ID, so that tokens can be found by secret without listing every token. The secret is
+	// hashed so that it is not exposed within the KV key.
+	secretsKVPath = "state/acl/secrets/"
+)
+
+// Define our metric keys.
+var (
+	metricKeyGetTokens        = []string{"token", "state", "consul", "get_tokens"}
+	metricKeyGetToken         = []string{"token", "state", "consul", "get_token"}
+	metricKeyGetTokenBySecret = []string{"token", "state", "consul", "get_token_by_secret"}
+	metricKeyPutToken         = []string{"token", "state", "consul", "put_token"}
+	metricKeyDeleteToken      = []string{"token", "state", "consul", "delete_token"}
+)
+
+type StateBackend struct {
+	path        string
+	secretsPath string
+	logger      zerolog.Logger
+
+	kv *api.KV
+}
+
+func NewStateBackend(log zerolog.Logger, path string, client *api.Client) token.Backend {
+	return &StateBackend{
+		path:        path + tokensKVPath,
+		secretsPath: path + secretsKVPath,
+		logger:      log,
+		kv:          client.KV(),
+	}
+}
+
+func (s StateBackend) GetTokens() ([]*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetTokens, time.Now())
+	return s.listTokens()
+}
+
+func (s StateBackend) GetToken(accessorID string) (*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetToken, time.Now())
+	return s.getToken(accessorID)
+}
+
+func (s StateBackend) getToken(accessorID string) (*state.ACLToken, error) {
+	kv, _, err := s.kv.Get(s.path+accessorID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	out := state.ACLToken{}
+	if err := json.Unmarshal(kv.Value, &out); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal Consul KV value")
+	}
+	return &out, nil
+}
+
+func (s StateBackend) GetTokenBySecret(secretID string) (*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetTokenBySecret, time.Now())
+
+	kv, _, err := s.kv.Get(s.secretsPath+secretHash(secretID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if kv == nil {
+		return nil, nil
+	}
+
+	t, err := s.getToken(string(kv.Value))
+	if err != nil || t == nil {
+		return nil, err
+	}
+
+	// The index only locates the token, so the secret of the stored token is still checked.
+	if subtle.ConstantTimeCompare([]byte(t.SecretID), []byte(secretID)) != 1 {
+		return nil, nil
+	}
+	return t, nil
+}
+
+func (s StateBackend) PutToken(t *state.ACLToken) error {
+	defer metrics.MeasureSince(metricKeyPutToken, time.Now())
+
+	marshal, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.getToken(t.AccessorID)
+	if err != nil {
+		return err
+	}
+
+	kvOpts := []*api.KVTxnOp{
+		{Verb: api.KVSet, Key: s.path + t.AccessorID, Value: marshal},
+		{Verb: api.KVSet, Key: s.secretsPath + secretHash(t.SecretID), Value: []byte(t.AccessorID)},
+	}
+
+	if existing != nil && existing.SecretID != t.SecretID {
+		kvOpts = append(kvOpts, &api.KVTxnOp{Verb: api.KVDelete, Key: s.secretsPath + secretHash(existing.SecretID)})
+	}
+
+	success, _, _, err := s.kv.Txn(kvOpts, nil)
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return errors.New("failed to write ACL token Consul transaction")
+	}
+	return nil
+}
+
+func (s StateBackend) DeleteToken(accessorID string) error {
+	defer metrics.MeasureSince(metricKeyDeleteToken, time.Now())
+
+	existing, err := s.getToken(accessorID)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	kvOpts := []*api.KVTxnOp{
+		{Verb: api.KVDelete, Key: s.path + accessorID},
+		{Verb: api.KVDelete, Key: s.secretsPath + secretHash(existing.SecretID)},
+	}
+
+	success, _, _, err := s.kv.Txn(kvOpts, nil)
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return errors.New("failed to delete ACL token Consul transaction")
+	}
+	return nil
+}
+
+func (s StateBackend) listTokens() ([]*state.ACLToken, error) {
+	kv, _, err := s.kv.List(s.path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*state.ACLToken, 0, len(kv))
+
+	for i := range kv {
+		t := &state.ACLToken{}
+
+		if err := json.Unmarshal(kv[i].Value, t); err != nil {
+			s.logger.Error().Str("key", kv[i].Key).Err(err).Msg("failed to unmarshal ACL token")
+			continue
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// secretHash returns the hex encoded SHA256 hash of the secret ID, used as the key of the secret
+// index.
+func secretHash(secretID string) string {
+	sum := sha256.Sum256([]byte(secretID))
+	return hex.EncodeToString(sum[:])
+}
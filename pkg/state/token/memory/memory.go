@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"crypto/subtle"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/jrasell/sherpa/pkg/state/token"
+)
+
+var _ token.Backend = (*StateBackend)(nil)
+
+// Define our metric keys.
+var (
+	metricKeyGetTokens        = []string{"token", "state", "memory", "get_tokens"}
+	metricKeyGetToken         = []string{"token", "state", "memory", "get_token"}
+	metricKeyGetTokenBySecret = []string{"token", "state", "memory", "get_token_by_secret"}
+	metricKeyPutToken         = []string{"token", "state", "memory", "put_token"}
+	metricKeyDeleteToken      = []string{"token", "state", "memory", "delete_token"}
+)
+
+type StateBackend struct {
+	tokens map[string]*state.ACLToken
+	sync.RWMutex
+}
+
+func NewStateBackend() token.Backend {
+	return &StateBackend{
+		tokens: make(map[string]*state.ACLToken),
+	}
+}
+
+func (s *StateBackend) GetTokens() ([]*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetTokens, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	out := make([]*state.ACLToken, 0, len(s.tokens))
+	for _, t := range s.tokens {
+		out = append(out, copyToken(t))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].AccessorID < out[j].AccessorID })
+	return out, nil
+}
+
+func (s *StateBackend) GetToken(accessorID string) (*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetToken, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	t, ok := s.tokens[accessorID]
+	if !ok {
+		return nil, nil
+	}
+	return copyToken(t), nil
+}
+
+func (s *StateBackend) GetTokenBySecret(secretID string) (*state.ACLToken, error) {
+	defer metrics.MeasureSince(metricKeyGetTokenBySecret, time.Now())
+
+	s.RLock()
+	defer s.RUnlock()
+
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.SecretID), []byte(secretID)) == 1 {
+			return copyToken(t), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *StateBackend) PutToken(t *state.ACLToken) error {
+	defer metrics.MeasureSince(metricKeyPutToken, time.Now())
+
+	s.Lock()
+	s.tokens[t.AccessorID] = copyToken(t)
+	s.Unlock()
+	return nil
+}
+
+func (s *StateBackend) DeleteToken(accessorID string) error {
+	defer metrics.MeasureSince(metricKeyDeleteToken, time.Now())
+
+	s.Lock()
+	delete(s.tokens, accessorID)
+	s.Unlock()
+	return nil
+}
+
+// copyToken performs a deep copy of the token, so that callers cannot modify the stored state
+// without calling PutToken.
+func copyToken(t *state.ACLToken) *state.ACLToken {
+	out := *t
+	out.Rules = make([]*state.ACLRule, len(t.Rules))
+
+	for i := range t.Rules {
+		rule := state.ACLRule{
+			JobPrefix:    t.Rules[i].JobPrefix,
+			Capabilities: append([]string(nil), t.Rules[i].Capabilities...),
+		}
+		out.Rules[i] = &rule
+	}
+	return &out
+}
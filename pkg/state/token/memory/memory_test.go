@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryStateBackend(t *testing.T) {
+	newBackend := NewStateBackend()
+
+	// Reading a token which does not exist should not error.
+	actual, err := newBackend.GetToken("accessor")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+
+	tok := &state.ACLToken{
+		AccessorID: "accessor",
+		SecretID:   "secret",
+		Name:       "operator",
+		Rules:      []*state.ACLRule{{JobPrefix: "platform/", Capabilities: []string{"read", "scale"}}},
+		CreateTime: 1572858000000000000,
+	}
+	assert.Nil(t, newBackend.PutToken(tok))
+
+	actual, err = newBackend.GetToken("accessor")
+	assert.Nil(t, err)
+	assert.Equal(t, tok, actual)
+
+	actual, err = newBackend.GetTokenBySecret("secret")
+	assert.Nil(t, err)
+	assert.Equal(t, tok, actual)
+
+	actual, err = newBackend.GetTokenBySecret("unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+
+	// Modifying the returned token should not modify the stored state.
+	actual, _ = newBackend.GetToken("accessor")
+	actual.Rules[0].Capabilities[0] = "system"
+	stored, err := newBackend.GetToken("accessor")
+	assert.Nil(t, err)
+	assert.Equal(t, "read", stored.Rules[0].Capabilities[0])
+
+	list, err := newBackend.GetTokens()
+	assert.Nil(t, err)
+	assert.Len(t, list, 1)
+
+	assert.Nil(t, newBackend.DeleteToken("accessor"))
+	actual, err = newBackend.GetToken("accessor")
+	assert.Nil(t, err)
+	assert.Nil(t, actual)
+}
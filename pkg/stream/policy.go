@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/policy/backend"
+)
+
+var _ backend.PolicyBackend = (*PolicyBackend)(nil)
+
+// PolicyBackend wraps a policy backend, publishing a policy event to the hub for each job group
+// scaling policy which is changed by a successful write or delete. Writes which do not change the
+// stored policy, such as the periodic updates of the Nomad meta policy engine, are not published.
+type PolicyBackend struct {
+	backend.PolicyBackend
+	hub *Hub
+}
+
+// NewPolicyBackend returns the policy backend wrapped so that policy changes are published to the
+// hub.
+func NewPolicyBackend(b backend.PolicyBackend, hub *Hub) backend.PolicyBackend {
+	return &PolicyBackend{PolicyBackend: b, hub: hub}
+}
+
+func (p *PolicyBackend) PutJobPolicy(job string, policies map[string]*policy.GroupScalingPolicy) error {
+	old, _ := p.PolicyBackend.GetJobPolicy(job)
+
+	if err := p.PolicyBackend.PutJobPolicy(job, policies); err != nil {
+		return err
+	}
+
+	// The job policy is overwritten, so any group not within the new policies has been deleted.
+	for _, group := range sortedGroups(policies, old) {
+		p.publishChange(job, group, old[group], policies[group])
+	}
+	return nil
+}
+
+func (p *PolicyBackend) PutJobGroupPolicy(job, group string, pol *policy.GroupScalingPolicy) error {
+	old, _ := p.PolicyBackend.GetJobGroupPolicy(job, group)
+
+	if err := p.PolicyBackend.PutJobGroupPolicy(job, group, pol); err != nil {
+		return err
+	}
+
+	p.publishChange(job, group, old, pol)
+	return nil
+}
+
+func (p *PolicyBackend) DeleteJobPolicy(job string) error {
+	old, _ := p.PolicyBackend.GetJobPolicy(job)
+
+	if err := p.PolicyBackend.DeleteJobPolicy(job); err != nil {
+		return err
+	}
+
+	for _, group := range sortedGroups(old) {
+		p.publishChange(job, group, old[group], nil)
+	}
+	return nil
+}
+
+func (p *PolicyBackend) DeleteJobGroupPolicy(job, group string) error {
+	old, _ := p.PolicyBackend.GetJobGroupPolicy(job, group)
+
+	if err := p.PolicyBackend.DeleteJobGroupPolicy(job, group); err != nil {
+		return err
+	}
+
+	p.publishChange(job, group, old, nil)
+	return nil
+}
+
+// publishChange publishes a policy event if the job group policy has changed.
+func (p *PolicyBackend) publishChange(job, group string, old, updated *policy.GroupScalingPolicy) {
+	if reflect.DeepEqual(old, updated) {
+		return
+	}
+
+	p.hub.Publish(&Event{
+		Topic:   TopicPolicy,
+		Job:     job,
+		Group:   group,
+		Payload: &PolicyChange{Policy: updated},
+	})
+}
+
+// sortedGroups returns the sorted, unique group names of the job policies.
+func sortedGroups(policies ...map[string]*policy.GroupScalingPolicy) []string {
+	seen := make(map[string]bool)
+
+	var groups []string
+	for i := range policies {
+		for group := range policies[i] {
+			if !seen[group] {
+				seen[group] = true
+				groups = append(groups, group)
+			}
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
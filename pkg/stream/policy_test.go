@@ -0,0 +1,61 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/jrasell/sherpa/pkg/policy/backend/memory"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyBackend(t *testing.T) {
+	hub := NewHub(zerolog.Nop())
+	sub, err := hub.Subscribe(&Filter{})
+	assert.Nil(t, err)
+
+	b := NewPolicyBackend(memory.NewJobScalingPolicies(), hub)
+
+	cache := &policy.GroupScalingPolicy{Enabled: true, MinCount: 1, MaxCount: 5}
+	web := &policy.GroupScalingPolicy{Enabled: true, MinCount: 2, MaxCount: 10}
+
+	// Writing the job policy publishes an event per group.
+	assert.Nil(t, b.PutJobPolicy("example", map[string]*policy.GroupScalingPolicy{"web": web, "cache": cache}))
+	assertPolicyEvent(t, sub, "example", "cache", cache)
+	assertPolicyEvent(t, sub, "example", "web", web)
+
+	// Writing an unchanged policy does not publish an event.
+	unchanged := *cache
+	assert.Nil(t, b.PutJobGroupPolicy("example", "cache", &unchanged))
+
+	// Overwriting the job policy publishes updated and deleted groups only.
+	updated := &policy.GroupScalingPolicy{Enabled: true, MinCount: 3, MaxCount: 10}
+	assert.Nil(t, b.PutJobPolicy("example", map[string]*policy.GroupScalingPolicy{"web": updated}))
+	assertPolicyEvent(t, sub, "example", "cache", nil)
+	assertPolicyEvent(t, sub, "example", "web", updated)
+
+	assert.Nil(t, b.DeleteJobGroupPolicy("example", "web"))
+	assertPolicyEvent(t, sub, "example", "web", nil)
+
+	// Deleting a policy which does not exist does not publish an event.
+	assert.Nil(t, b.DeleteJobPolicy("example"))
+	assert.Nil(t, b.DeleteJobGroupPolicy("example", "cache"))
+
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("unexpected event: %v", e)
+	default:
+	}
+}
+
+func assertPolicyEvent(t *testing.T, sub *Subscription, job, group string, pol *policy.GroupScalingPolicy) {
+	select {
+	case e := <-sub.Events():
+		assert.Equal(t, TopicPolicy, e.Topic)
+		assert.Equal(t, job, e.Job)
+		assert.Equal(t, group, e.Group)
+		assert.Equal(t, &PolicyChange{Policy: pol}, e.Payload)
+	default:
+		t.Fatalf("expected policy event for %s:%s", job, group)
+	}
+}
@@ -0,0 +1,247 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/jrasell/sherpa/pkg/policy"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Topic identifies the type of an event published to the hub.
+type Topic string
+
+const (
+	// TopicScaling events are published when a scaling event is recorded to the state. The payload
+	// is the *state.ScalingEvent.
+	TopicScaling Topic = "ScalingEvent"
+
+	// TopicPolicy events are published when a job group scaling policy is written or deleted. The
+	// payload is the *PolicyChange.
+	TopicPolicy Topic = "Policy"
+
+	// TopicLeadership events are published when the Sherpa server obtains or loses the cluster
+	// leadership. The payload is the *LeadershipChange.
+	TopicLeadership Topic = "Leadership"
+)
+
+func (t Topic) String() string { return string(t) }
+
+// subscriptionBufferSize is the number of events buffered for each subscription. Subscriptions
+// which fall this far behind are evicted, so that slow subscribers cannot block publishers.
+const subscriptionBufferSize = 128
+
+// ErrHubClosed is returned when subscribing to a hub which has been closed.
+var ErrHubClosed = errors.New("event stream hub is closed")
+
+// Define our metric keys.
+var (
+	metricKeySubscribers = []string{"stream", "subscribers"}
+	metricKeyEvicted     = []string{"stream", "evicted"}
+)
+
+// ValidTopic returns whether the topic is a known event topic.
+func ValidTopic(t Topic) bool {
+	switch t {
+	case TopicScaling, TopicPolicy, TopicLeadership:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event is a single event published to the hub.
+type Event struct {
+
+	// Index is a sequence number which increases with each event published by the Sherpa server.
+	Index uint64
+
+	// Topic identifies the type of the event and therefore the type of the Payload.
+	Topic Topic
+
+	// Time is a UnixNano timestamp declaring when the event was published.
+	Time int64
+
+	// Job and Group identify the job group which the event relates to. Leadership events are not
+	// scoped to a job group.
+	Job   string `json:",omitempty"`
+	Group string `json:",omitempty"`
+
+	// Source shows the origin source of scaling events.
+	Source string `json:",omitempty"`
+
+	Payload interface{}
+}
+
+// PolicyChange is the payload of policy events.
+type PolicyChange struct {
+
+	// Policy is the new job group scaling policy, or nil if the policy was deleted.
+	Policy *policy.GroupScalingPolicy
+}
+
+// LeadershipChange is the payload of leadership events.
+type LeadershipChange struct {
+
+	// IsLeader is whether the Sherpa server is now the cluster leader.
+	IsLeader bool
+
+	// Addr is the advertise address of the Sherpa server which published the event.
+	Addr string
+}
+
+// Filter selects the events delivered to a subscription. Empty fields match all events. The job,
+// group and source filters exclude events which do not have a matching value, except leadership
+// events which are not scoped to a job group and so are always matched.
+type Filter struct {
+	Topics []Topic
+	Job    string
+	Group  string
+	Source string
+}
+
+// Matches returns whether the event is selected by the filter.
+func (f *Filter) Matches(e *Event) bool {
+	if !f.MatchesTopic(e.Topic) {
+		return false
+	}
+
+	if e.Topic == TopicLeadership {
+		return true
+	}
+
+	if f.Job != "" && f.Job != e.Job {
+		return false
+	}
+	if f.Group != "" && f.Group != e.Group {
+		return false
+	}
+	return f.Source == "" || f.Source == e.Source
+}
+
+// MatchesTopic returns whether events of the topic are selected by the filter.
+func (f *Filter) MatchesTopic(t Topic) bool {
+	if len(f.Topics) == 0 {
+		return true
+	}
+
+	for i := range f.Topics {
+		if f.Topics[i] == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Hub is the internal pub/sub hub which distributes events published by the scaler, policy
+// backends and cluster leadership handler to subscribers such as the event stream API endpoint.
+// Publishing never blocks; subscribers which cannot keep up are evicted.
+type Hub struct {
+	logger zerolog.Logger
+
+	lock   sync.Mutex
+	index  uint64
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// Subscription receives the events published to the hub which match its filter.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	events chan *Event
+}
+
+// NewHub returns a new event hub.
+func NewHub(logger zerolog.Logger) *Hub {
+	return &Hub{
+		logger: logger,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish sets the index and time of the event and delivers it to all matching subscriptions. A
+// nil hub does nothing, allowing callers to use a nil value when events are not required.
+func (h *Hub) Publish(e *Event) {
+	if h == nil {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.index++
+	e.Index = h.index
+	if e.Time == 0 {
+		e.Time = time.Now().UnixNano()
+	}
+
+	for sub := range h.subs {
+		if !sub.filter.Matches(e) {
+			continue
+		}
+
+		select {
+		case sub.events <- e:
+		default:
+			h.logger.Warn().Msg("evicting event stream subscriber which is unable to keep up with events")
+			metrics.IncrCounter(metricKeyEvicted, 1)
+			h.unsubscribe(sub)
+		}
+	}
+}
+
+// Subscribe returns a subscription to the events which match the filter. The subscription must
+// be closed once it is no longer required.
+func (h *Hub) Subscribe(filter *Filter) (*Subscription, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	sub := &Subscription{hub: h, filter: *filter, events: make(chan *Event, subscriptionBufferSize)}
+	h.subs[sub] = struct{}{}
+	metrics.SetGauge(metricKeySubscribers, float32(len(h.subs)))
+	return sub, nil
+}
+
+// Close closes the hub along with all its subscriptions. Subsequent events are discarded.
+func (h *Hub) Close() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.unsubscribe(sub)
+	}
+}
+
+// unsubscribe removes the subscription and closes its event channel. The caller must hold the
+// hub lock.
+func (h *Hub) unsubscribe(sub *Subscription) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.events)
+	metrics.SetGauge(metricKeySubscribers, float32(len(h.subs)))
+}
+
+// Events returns the channel on which the subscription events are delivered. The channel is
+// closed when the subscription is closed or evicted, or the hub is closed.
+func (s *Subscription) Events() <-chan *Event { return s.events }
+
+// Close removes the subscription from the hub.
+func (s *Subscription) Close() {
+	s.hub.lock.Lock()
+	s.hub.unsubscribe(s)
+	s.hub.lock.Unlock()
+}
@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	scaling := &Event{Topic: TopicScaling, Job: "example", Group: "cache", Source: "API"}
+	policyChange := &Event{Topic: TopicPolicy, Job: "example", Group: "web"}
+	leadership := &Event{Topic: TopicLeadership}
+
+	testCases := []struct {
+		filter         *Filter
+		event          *Event
+		expectedOutput bool
+		name           string
+	}{
+		{filter: &Filter{}, event: scaling, expectedOutput: true, name: "empty filter"},
+		{filter: &Filter{Topics: []Topic{TopicPolicy}}, event: scaling, expectedOutput: false, name: "other topic"},
+		{filter: &Filter{Topics: []Topic{TopicPolicy, TopicScaling}}, event: scaling, expectedOutput: true, name: "matching topic"},
+		{filter: &Filter{Job: "example", Group: "cache"}, event: scaling, expectedOutput: true, name: "matching job group"},
+		{filter: &Filter{Job: "platform/example"}, event: scaling, expectedOutput: false, name: "other job"},
+		{filter: &Filter{Group: "cache"}, event: policyChange, expectedOutput: false, name: "other group"},
+		{filter: &Filter{Source: "API"}, event: scaling, expectedOutput: true, name: "matching source"},
+		{filter: &Filter{Source: "API"}, event: policyChange, expectedOutput: false, name: "event without source"},
+		{filter: &Filter{Job: "other", Source: "API"}, event: leadership, expectedOutput: true, name: "leadership not scoped to job"},
+		{filter: &Filter{Topics: []Topic{TopicScaling}}, event: leadership, expectedOutput: false, name: "leadership topic not selected"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedOutput, tc.filter.Matches(tc.event), tc.name)
+	}
+}
+
+func TestHub(t *testing.T) {
+	hub := NewHub(zerolog.Nop())
+
+	all, err := hub.Subscribe(&Filter{})
+	assert.Nil(t, err)
+	cache, err := hub.Subscribe(&Filter{Group: "cache"})
+	assert.Nil(t, err)
+
+	hub.Publish(&Event{Topic: TopicScaling, Job: "example", Group: "web"})
+	hub.Publish(&Event{Topic: TopicScaling, Job: "example", Group: "cache"})
+
+	e := <-all.Events()
+	assert.Equal(t, uint64(1), e.Index)
+	assert.Equal(t, "web", e.Group)
+	assert.NotZero(t, e.Time)
+
+	e = <-all.Events()
+	assert.Equal(t, uint64(2), e.Index)
+
+	e = <-cache.Events()
+	assert.Equal(t, uint64(2), e.Index)
+	assert.Equal(t, "cache", e.Group)
+
+	// Closing a subscription closes its channel, and closing it again is safe.
+	cache.Close()
+	cache.Close()
+	_, ok := <-cache.Events()
+	assert.False(t, ok)
+
+	// Subscribers which fall behind are evicted rather than blocking the publisher.
+	for i := 0; i <= subscriptionBufferSize; i++ {
+		hub.Publish(&Event{Topic: TopicPolicy})
+	}
+	received := 0
+	for range all.Events() {
+		received++
+	}
+	assert.Equal(t, subscriptionBufferSize, received)
+
+	hub.Close()
+	_, err = hub.Subscribe(&Filter{})
+	assert.Equal(t, ErrHubClosed, err)
+
+	// Publishing to a closed or nil hub is a no-op.
+	hub.Publish(&Event{Topic: TopicPolicy})
+	var nilHub *Hub
+	nilHub.Publish(&Event{Topic: TopicPolicy})
+}
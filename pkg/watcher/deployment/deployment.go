@@ -8,16 +8,28 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Update is the message sent by the watcher for each deployment which has changed, alongside the
+// region of the Nomad target, which is empty for the primary target.
+type Update struct {
+	Region     string
+	Deployment *api.Deployment
+}
+
 type Watcher struct {
 	logger          zerolog.Logger
 	nomad           *api.Client
+	region          string
+	namespace       string
 	lastChangeIndex uint64
 }
 
-func New(logger zerolog.Logger, nomad *api.Client) watcher.Watcher {
+// New returns a watcher of the deployments within the Nomad namespace of the region.
+func New(logger zerolog.Logger, nomad *api.Client, region, namespace string) watcher.Watcher {
 	return &Watcher{
-		logger: logger,
-		nomad:  nomad,
+		logger:    logger.With().Str("region", region).Str("namespace", namespace).Logger(),
+		nomad:     nomad,
+		region:    region,
+		namespace: namespace,
 	}
 }
 
@@ -26,7 +38,7 @@ func (w *Watcher) Run(updateChan chan interface{}) {
 
 	var maxFound uint64
 
-	q := &api.QueryOptions{WaitTime: 5 * time.Minute, WaitIndex: 1}
+	q := &api.QueryOptions{Namespace: w.namespace, WaitTime: 5 * time.Minute, WaitIndex: 1}
 
 	for {
 
@@ -64,7 +76,7 @@ func (w *Watcher) Run(updateChan chan interface{}) {
 				Msg("deployment modify index has changed is greater than last recorded")
 
 			maxFound = watcher.MaxFound(deployments[i].ModifyIndex, maxFound)
-			updateChan <- deployments[i]
+			updateChan <- &Update{Region: w.region, Deployment: deployments[i]}
 		}
 
 		// Update the Nomad API wait index to start long polling from the correct point and update
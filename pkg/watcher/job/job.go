@@ -8,16 +8,30 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Update is the message sent by the watcher for each job which has changed. The Nomad job list
+// stub does not include the namespace of the job, so it is included alongside the region of the
+// Nomad target, which is empty for the primary target.
+type Update struct {
+	Region    string
+	Namespace string
+	Job       *api.JobListStub
+}
+
 type Watcher struct {
 	logger          zerolog.Logger
 	nomad           *api.Client
+	region          string
+	namespace       string
 	lastChangeIndex uint64
 }
 
-func NewWatcher(logger zerolog.Logger, nomad *api.Client) watcher.Watcher {
+// NewWatcher returns a watcher of the jobs within the Nomad namespace of the region.
+func NewWatcher(logger zerolog.Logger, nomad *api.Client, region, namespace string) watcher.Watcher {
 	return &Watcher{
-		logger: logger,
-		nomad:  nomad,
+		logger:    logger.With().Str("region", region).Str("namespace", namespace).Logger(),
+		nomad:     nomad,
+		region:    region,
+		namespace: namespace,
 	}
 }
 
@@ -26,7 +40,7 @@ func (w *Watcher) Run(updateChan chan interface{}) {
 
 	var maxFound uint64
 
-	q := &api.QueryOptions{WaitTime: 5 * time.Minute, WaitIndex: 1}
+	q := &api.QueryOptions{Namespace: w.namespace, WaitTime: 5 * time.Minute, WaitIndex: 1}
 
 	for {
 
@@ -64,7 +78,7 @@ func (w *Watcher) Run(updateChan chan interface{}) {
 				Msg("job modify index has changed is greater than last recorded")
 
 			maxFound = watcher.MaxFound(jobs[i].ModifyIndex, maxFound)
-			updateChan <- jobs[i]
+			updateChan <- &Update{Region: w.region, Namespace: w.namespace, Job: jobs[i]}
 		}
 
 		// Update the Nomad API wait index to start long polling from the correct point and update